@@ -14,7 +14,9 @@ package v1
 
 import (
 	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,6 +33,12 @@ import (
 // +kubebuilder:validation:XValidation:rule="!(has(self.version) && self.version != \"\" && has(self.driver) && has(self.driver.initContainers) && self.driver.initContainers.exists(ic, has(ic.image) && ic.image != \"\"))",message="spec.driver.initContainers[*].image is forbidden when spec.version is set"
 // +kubebuilder:validation:XValidation:rule="!(has(self.version) && self.version != \"\" && has(self.modules) && self.modules.exists(m, has(m.components) && m.components.exists(c, has(c.envs) && c.envs.exists(e, has(e.name) && e.name == \"NGINX_PROXY_IMAGE\"))))",message="env NGINX_PROXY_IMAGE is forbidden when spec.version is set"
 // +kubebuilder:validation:XValidation:rule="!has(self.driver) || !has(self.driver.metrics) || self.driver.csiDriverType == 'powerflex'",message="spec.driver.metrics is only supported for PowerFlex driver"
+// +kubebuilder:validation:XValidation:rule="!has(self.driver) || !has(self.driver.powerflex) || self.driver.csiDriverType == 'powerflex'",message="spec.driver.powerflex is only supported for PowerFlex driver"
+// +kubebuilder:validation:XValidation:rule="!has(self.driver) || !has(self.driver.powermax) || self.driver.csiDriverType == 'powermax'",message="spec.driver.powermax is only supported for PowerMax driver"
+// +kubebuilder:validation:XValidation:rule="!has(self.driver) || !has(self.driver.powerstore) || self.driver.csiDriverType == 'powerstore'",message="spec.driver.powerstore is only supported for PowerStore driver"
+// +kubebuilder:validation:XValidation:rule="!has(self.driver) || !has(self.driver.powerscale) || self.driver.csiDriverType == 'isilon'",message="spec.driver.powerscale is only supported for PowerScale driver"
+// +kubebuilder:validation:XValidation:rule="!has(self.driver) || !has(self.driver.unity) || self.driver.csiDriverType == 'unity'",message="spec.driver.unity is only supported for Unity driver"
+// +kubebuilder:validation:XValidation:rule="!has(self.driver) || !has(self.driver.cosi) || self.driver.csiDriverType == 'cosi'",message="spec.driver.cosi is only supported for COSI driver"
 type ContainerStorageModuleSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
@@ -52,6 +60,58 @@ type ContainerStorageModuleSpec struct {
 	// RetainImageRegistryPath is the boolean flag used to retain image registry path
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retain Image Registry Path"
 	RetainImageRegistryPath bool `json:"retainImageRegistryPath,omitempty" yaml:"retainImageRegistryPath,omitempty"`
+
+	// ImagePullSecrets is the list of secrets used to pull the images of every workload deployed by the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Pull Secrets"
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty" yaml:"imagePullSecrets,omitempty"`
+
+	// CopyImagePullSecrets is the boolean flag used to copy the image pull secrets into the namespaces created for the modules
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Copy Image Pull Secrets"
+	CopyImagePullSecrets bool `json:"copyImagePullSecrets,omitempty" yaml:"copyImagePullSecrets,omitempty"`
+
+	// Paused is the boolean flag used to stop the operator from applying or deleting anything for this ContainerStorageModule
+	// Status is still reported while paused. A paused ContainerStorageModule is only removed once it is resumed
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Paused"
+	Paused bool `json:"paused,omitempty" yaml:"paused,omitempty"`
+
+	// AdoptExisting is the boolean flag used to take over the objects of a driver installed with Helm, instead of failing on them
+	// The Helm metadata is removed and the objects are updated in place, so that the attached volumes are not disrupted
+	// The Helm release is relabelled so that Helm no longer finds it, and a helm uninstall does not delete the adopted objects
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Adopt Existing"
+	AdoptExisting bool `json:"adoptExisting,omitempty" yaml:"adoptExisting,omitempty"`
+
+	// TargetClusters is the list of remote clusters the driver and modules are installed on, in addition to the local cluster
+	// Each name is a secret in the dell-replication-controller namespace holding the kubeconfig of the cluster in its data key
+	// With forceRemoveDriver, deleting the ContainerStorageModule removes the driver from every cluster
+	// The namespace and the secrets of the driver are copied to a cluster that does not have them yet
+	// The pods on the remote clusters are not watched, their status is refreshed every few minutes
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target Clusters"
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	TargetClusters []string `json:"targetClusters,omitempty" yaml:"targetClusters,omitempty"`
+
+	// Rollback is the policy used to re-apply the last successful configuration when a spec change does not succeed in time
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rollback"
+	// +optional
+	Rollback *RollbackPolicy `json:"rollback,omitempty" yaml:"rollback,omitempty"`
+
+	// RevisionHistoryLimit is the number of applied specs kept in the revision history of the ContainerStorageModule, 10 when empty
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Revision History Limit"
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty" yaml:"revisionHistoryLimit,omitempty"`
+
+	// RollbackTo is the revision of the revision history the spec is restored from
+	// The whole spec is replaced by the one of the revision, and rollbackTo is cleared
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rollback To"
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RollbackTo *int64 `json:"rollbackTo,omitempty" yaml:"rollbackTo,omitempty"`
+
+	// MultiHopUpgrade is the boolean flag used to upgrade through the intermediate versions of status.upgradePath
+	// when the requested version cannot be reached directly. Each hop is installed once the previous one has Succeeded
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Multi-Hop Upgrade"
+	MultiHopUpgrade bool `json:"multiHopUpgrade,omitempty" yaml:"multiHopUpgrade,omitempty"`
 }
 
 // ContainerStorageModuleStatus defines the observed state of ContainerStorageModule
@@ -69,6 +129,103 @@ type ContainerStorageModuleStatus struct {
 	// LastSuccessfulConfiguration is configurations details only when the CSM CR goes into a successful state
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="LastSuccessfulConfiguration",xDescriptors="urn:alm:descriptor:text"
 	LastSuccessfulConfiguration string `json:"lastSuccessfulConfiguration,omitempty"`
+
+	// Modules is the status of each enabled module
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Modules"
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Modules []ModuleStatus `json:"modules,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="ObservedGeneration",xDescriptors="urn:alm:descriptor:text"
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions are the Ready, Progressing, Degraded, PrecheckPassed, UpgradeBlocked, Paused, RolledBack and NodeRolloutPaused conditions of the installation
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Clusters is the status of the driver on the local cluster and on each target cluster, set when spec.targetClusters is not empty
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Clusters"
+	// +listType=map
+	// +listMapKey=clusterID
+	// +optional
+	Clusters []ClusterStatus `json:"clusters,omitempty"`
+
+	// AppliedClusters are the target clusters the driver is applied to, so that the ones removed from spec.targetClusters are cleaned up
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="AppliedClusters",xDescriptors="urn:alm:descriptor:text"
+	// +optional
+	AppliedClusters []string `json:"appliedClusters,omitempty"`
+
+	// RolloutGeneration is the generation of the spec the current rollout is applying, set when spec.rollback is enabled
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="RolloutGeneration",xDescriptors="urn:alm:descriptor:text"
+	// +optional
+	RolloutGeneration int64 `json:"rolloutGeneration,omitempty"`
+
+	// RolloutStartTime is when the operator started applying the current generation of the spec, cleared once it succeeds
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="RolloutStartTime",xDescriptors="urn:alm:descriptor:text"
+	// +optional
+	RolloutStartTime *metav1.Time `json:"rolloutStartTime,omitempty"`
+
+	// RolledBackGeneration is the generation of the spec that was replaced by the last successful configuration
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="RolledBackGeneration",xDescriptors="urn:alm:descriptor:text"
+	// +optional
+	RolledBackGeneration int64 `json:"rolledBackGeneration,omitempty"`
+
+	// Revision is the revision of the revision history holding the applied spec
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Revision",xDescriptors="urn:alm:descriptor:text"
+	// +optional
+	Revision int64 `json:"revision,omitempty"`
+
+	// UpgradePath is the shortest list of config versions leading from the installed version to the requested one, set during an upgrade
+	// With spec.multiHopUpgrade, the first one is the version being installed
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="UpgradePath",xDescriptors="urn:alm:descriptor:text"
+	// +optional
+	UpgradePath []string `json:"upgradePath,omitempty"`
+}
+
+// ClusterStatus defines the observed state of the driver on one cluster
+type ClusterStatus struct {
+	// ClusterID is the name of the target cluster, or default-source-cluster for the local cluster
+	// +kubebuilder:validation:Required
+	ClusterID string `json:"clusterID"`
+
+	// State is the state of the driver installation on the cluster
+	State CSMStateType `json:"state,omitempty"`
+
+	// ControllerStatus is the status of the controller pods on the cluster
+	ControllerStatus PodStatus `json:"controllerStatus,omitempty"`
+
+	// NodeStatus is the status of the node pods on the cluster
+	NodeStatus PodStatus `json:"nodeStatus,omitempty"`
+
+	// LastError is the last error reported for the cluster
+	LastError string `json:"lastError,omitempty"`
+}
+
+// ModuleStatus defines the observed state of a module
+type ModuleStatus struct {
+	// Name is the name of the module
+	// +kubebuilder:validation:Required
+	Name ModuleType `json:"name"`
+
+	// State is the state of the module installation
+	State CSMStateType `json:"state,omitempty"`
+
+	// ConfigVersion is the resolved config version of the module
+	ConfigVersion string `json:"configVersion,omitempty"`
+
+	// Desired is the number of desired pods of the module deployments
+	Desired string `json:"desired,omitempty"`
+
+	// Available is the number of available pods of the module deployments
+	Available string `json:"available,omitempty"`
+
+	// LastError is the last error reported for the module
+	LastError string `json:"lastError,omitempty"`
 }
 
 // +kubebuilder:validation:Optional
@@ -156,6 +313,45 @@ func (cr *ContainerStorageModule) IsBeingDeleted() bool {
 	return !cr.ObjectMeta.DeletionTimestamp.IsZero()
 }
 
+// IsPaused - Returns true if reconcile is paused by spec.paused or the paused annotation
+func (cr *ContainerStorageModule) IsPaused() bool {
+	return cr.Spec.Paused || cr.GetAnnotations()[PausedAnnotation] == "true"
+}
+
+// IsRolledBack - Returns true if the current generation of the spec was rolled back to the last successful configuration
+func (cr *ContainerStorageModule) IsRolledBack() bool {
+	return cr.Status.RolledBackGeneration != 0 && cr.Status.RolledBackGeneration == cr.Generation
+}
+
+// GetTimeout - Returns the rollback timeout, or DefaultRollbackTimeout when it is not set
+func (p *RollbackPolicy) GetTimeout() time.Duration {
+	if p.Timeout == nil || p.Timeout.Duration <= 0 {
+		return DefaultRollbackTimeout
+	}
+	return p.Timeout.Duration
+}
+
+// IsNodeRolloutEnabled - Returns true if the node plugin is rolled out batch by batch
+func (cr *ContainerStorageModule) IsNodeRolloutEnabled() bool {
+	return cr.Spec.Driver.NodeRollout != nil && cr.Spec.Driver.NodeRollout.Enabled
+}
+
+// GetBatchTimeout - Returns the batch timeout, or DefaultNodeRolloutBatchTimeout when it is not set
+func (s *NodeRolloutStrategy) GetBatchTimeout() time.Duration {
+	if s.BatchTimeout == nil || s.BatchTimeout.Duration <= 0 {
+		return DefaultNodeRolloutBatchTimeout
+	}
+	return s.BatchTimeout.Duration
+}
+
+// GetRevisionHistoryLimit - Returns the number of revisions to keep, or DefaultRevisionHistoryLimit when it is not set
+func (cr *ContainerStorageModule) GetRevisionHistoryLimit() int {
+	if cr.Spec.RevisionHistoryLimit == nil {
+		return DefaultRevisionHistoryLimit
+	}
+	return int(*cr.Spec.RevisionHistoryLimit)
+}
+
 // HasFinalizer returns true if the item has the specified finalizer
 func (cr *ContainerStorageModule) HasFinalizer(finalizerName string) bool {
 	for _, item := range cr.ObjectMeta.Finalizers {
@@ -13,7 +13,12 @@
 package v1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // CSMStateType - type representing the state of the ContainerStorageModule (in status)
@@ -118,6 +123,18 @@ const (
 	EventUpdated = "Updated"
 	// EventCompleted - Completed in event recorder
 	EventCompleted = "Completed"
+	// EventPaused - Paused in event recorder
+	EventPaused = "Paused"
+	// EventResumed - Resumed in event recorder
+	EventResumed = "Resumed"
+	// EventAdopted - Adopted in event recorder
+	EventAdopted = "Adopted"
+	// EventRolledBack - RolledBack in event recorder
+	EventRolledBack = "RolledBack"
+	// EventRevisionRestored - RevisionRestored in event recorder
+	EventRevisionRestored = "RevisionRestored"
+	// EventNodeRolloutPaused - NodeRolloutPaused in event recorder
+	EventNodeRolloutPaused = "NodeRolloutPaused"
 
 	// Succeeded - constant
 	Succeeded CSMOperatorConditionType = "Succeeded"
@@ -133,6 +150,93 @@ const (
 	Failed CSMOperatorConditionType = "Failed"
 )
 
+// Condition types reported in ContainerStorageModuleStatus.Conditions
+const (
+	// ConditionReady - driver and module pods are all available
+	ConditionReady = "Ready"
+	// ConditionProgressing - driver or module pods are still being rolled out
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded - driver or module pods are failing
+	ConditionDegraded = "Degraded"
+	// ConditionPrecheckPassed - the spec passed the operator prechecks
+	ConditionPrecheckPassed = "PrecheckPassed"
+	// ConditionUpgradeBlocked - the requested version cannot be reached from the installed version
+	ConditionUpgradeBlocked = "UpgradeBlocked"
+	// ConditionPaused - reconcile is paused and no changes are applied
+	ConditionPaused = "Paused"
+	// ConditionRolledBack - the current spec was replaced by the last successful configuration
+	ConditionRolledBack = "RolledBack"
+	// ConditionNodeRolloutPaused - a batch of the node plugin rollout failed and no more nodes are updated
+	ConditionNodeRolloutPaused = "NodeRolloutPaused"
+
+	// ReasonAllComponentsAvailable - condition reason when all pods are available
+	ReasonAllComponentsAvailable = "AllComponentsAvailable"
+	// ReasonPodsStarting - condition reason when pods are not yet available
+	ReasonPodsStarting = "PodsStarting"
+	// ReasonPodsFailing - condition reason when pods are failing to start
+	ReasonPodsFailing = "PodsFailing"
+	// ReasonModuleNotReady - condition reason when a module is not running
+	ReasonModuleNotReady = "ModuleNotReady"
+	// ReasonPrecheckSucceeded - condition reason when prechecks pass
+	ReasonPrecheckSucceeded = "PrecheckSucceeded"
+	// ReasonPrecheckFailed - condition reason when prechecks fail
+	ReasonPrecheckFailed = "PrecheckFailed"
+	// ReasonUpgradePathValid - condition reason when the upgrade path is supported
+	ReasonUpgradePathValid = "UpgradePathValid"
+	// ReasonUpgradePathInvalid - condition reason when the upgrade path is not supported
+	ReasonUpgradePathInvalid = "UpgradePathInvalid"
+	// ReasonReconcilePaused - condition reason when reconcile is paused
+	ReasonReconcilePaused = "ReconcilePaused"
+	// ReasonReconcileResumed - condition reason when reconcile is resumed
+	ReasonReconcileResumed = "ReconcileResumed"
+	// ReasonClusterNotReady - condition reason when the driver is not running on a target cluster
+	ReasonClusterNotReady = "ClusterNotReady"
+	// ReasonRolloutStarted - condition reason when a new generation of the spec is being applied
+	ReasonRolloutStarted = "RolloutStarted"
+	// ReasonRolloutTimedOut - condition reason when a spec change did not succeed within the rollback timeout
+	ReasonRolloutTimedOut = "RolloutTimedOut"
+	// ReasonNodeBatchFailed - condition reason when the nodes of a batch are not updated within the batch timeout
+	ReasonNodeBatchFailed = "NodeBatchFailed"
+	// ReasonNodeRolloutInProgress - condition reason when batches of nodes are still being updated
+	ReasonNodeRolloutInProgress = "NodeRolloutInProgress"
+	// ReasonNodeRolloutComplete - condition reason when the node plugin is updated on all nodes
+	ReasonNodeRolloutComplete = "NodeRolloutComplete"
+)
+
+// PausedAnnotation - annotation that pauses reconcile of a ContainerStorageModule when set to "true"
+const PausedAnnotation = "storage.dell.com/paused"
+
+// DefaultRollbackTimeout is the time a spec change has to succeed when spec.rollback.timeout is not set
+const DefaultRollbackTimeout = 10 * time.Minute
+
+const (
+	// DefaultRevisionHistoryLimit is the number of revisions kept when spec.revisionHistoryLimit is not set
+	DefaultRevisionHistoryLimit = 10
+	// RevisionLabel is the label holding the name of the ContainerStorageModule on its revisions
+	RevisionLabel = "storage.dell.com/revision-of"
+	// RevisionStateAnnotation is the annotation holding the state the spec of a revision resulted in
+	RevisionStateAnnotation = "storage.dell.com/revision-state"
+	// RevisionOperatorVersionAnnotation is the annotation holding the version of the operator that applied a revision
+	RevisionOperatorVersionAnnotation = "storage.dell.com/operator-version"
+	// RevisionChangesAnnotation is the annotation listing the fields changed since the previous revision
+	RevisionChangesAnnotation = "storage.dell.com/revision-changes"
+)
+
+// DefaultNodeRolloutBatchTimeout is the time the nodes of a batch have to be updated when spec.driver.nodeRollout.batchTimeout is not set
+const DefaultNodeRolloutBatchTimeout = 10 * time.Minute
+
+const (
+	// NodeRolloutBatchAnnotation is the annotation of the node DaemonSet listing the nodes of the batch being updated
+	NodeRolloutBatchAnnotation = "storage.dell.com/node-rollout-batch"
+	// NodeRolloutBatchStartAnnotation is the annotation of the node DaemonSet holding when the batch being updated was started
+	NodeRolloutBatchStartAnnotation = "storage.dell.com/node-rollout-batch-start"
+	// NodeRolloutPausedAnnotation is the annotation of the node DaemonSet holding the revision the rollout paused on
+	NodeRolloutPausedAnnotation = "storage.dell.com/node-rollout-paused"
+)
+
+// ContentHashAnnotation - annotation holding the hash of the rendered object the operator last wrote
+const ContentHashAnnotation = "storage.dell.com/content-hash"
+
 // Module defines the desired state of a ContainerStorageModule
 // +kubebuilder:validation:MaxProperties=10
 type Module struct {
@@ -151,7 +255,7 @@ type Module struct {
 	// Components is the specification for CSM components containers
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ContainerStorageModule components specification"
 	// +kubebuilder:validation:MaxItems=20
-	Components []ContainerTemplate `json:"components,omitempty" yaml:"components,omitempty"`
+	Components []PodTemplate `json:"components,omitempty" yaml:"components,omitempty"`
 
 	// ForceRemoveModule is the boolean flag used to remove authorization proxy server deployment when CR is deleted
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Force Remove Module"
@@ -161,6 +265,15 @@ type Module struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="InitContainer"
 	InitContainer []ContainerTemplate `json:"initContainer,omitempty" yaml:"initContainer"`
+
+	// ImagePullSecrets overrides the image pull secrets of the ContainerStorageModule for the workloads of this module
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Pull Secrets"
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty" yaml:"imagePullSecrets,omitempty"`
+
+	// VolumeGroupSnapshotClasses is the list of Volume Group Snapshot Classes created for the driver, used by the vgsnapshotter module
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Group Snapshot Classes"
+	// +kubebuilder:validation:MaxItems=20
+	VolumeGroupSnapshotClasses []SnapshotClass `json:"volumeGroupSnapshotClasses,omitempty" yaml:"volumeGroupSnapshotClasses,omitempty"`
 }
 
 // PodStatus - Represents PodStatus in a daemonset or deployment
@@ -209,11 +322,16 @@ type Driver struct {
 
 	// Controller is the specification for Controller plugin only
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Controller Specification"
-	Controller *ContainerTemplate `json:"controller,omitempty" yaml:"controller"`
+	Controller *PodTemplate `json:"controller,omitempty" yaml:"controller"`
 
 	// Node is the specification for Node plugin only
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node specification"
-	Node *ContainerTemplate `json:"node,omitempty" yaml:"node"`
+	Node *PodTemplate `json:"node,omitempty" yaml:"node"`
+
+	// NodeRollout updates the node plugin in batches of nodes, the operator deletes the node pods of a batch once the previous batch is healthy
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Rollout"
+	// +optional
+	NodeRollout *NodeRolloutStrategy `json:"nodeRollout,omitempty" yaml:"nodeRollout,omitempty"`
 
 	// SideCars is the specification for CSI sidecar containers
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CSI SideCars specification"
@@ -229,6 +347,11 @@ type Driver struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Snapshot Classes"
 	SnapshotClass []SnapshotClass `json:"snapshotClass,omitempty" yaml:"snapshotClass"`
 
+	// StorageClasses is the specification for the Storage Classes managed by the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Classes"
+	// +kubebuilder:validation:MaxItems=50
+	StorageClasses []StorageClass `json:"storageClasses,omitempty" yaml:"storageClasses,omitempty"`
+
 	// AuthSecret is the name of the credentials secret for the driver
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Auth Secret"
 	AuthSecret string `json:"authSecret,omitempty" yaml:"authSecret"` //gosec:disable G117
@@ -244,6 +367,285 @@ type Driver struct {
 	// Metrics is the configuration for the driver metrics endpoint and monitoring
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Driver Metrics Configuration"
 	Metrics *DriverMetrics `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+
+	// PowerFlex is the PowerFlex driver configuration; it takes precedence over the equivalent env vars
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PowerFlex Configuration"
+	PowerFlex *PowerFlexConfig `json:"powerflex,omitempty" yaml:"powerflex,omitempty"`
+
+	// PowerMax is the PowerMax driver configuration; it takes precedence over the equivalent env vars
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PowerMax Configuration"
+	PowerMax *PowerMaxConfig `json:"powermax,omitempty" yaml:"powermax,omitempty"`
+
+	// PowerStore is the PowerStore driver configuration; it takes precedence over the equivalent env vars
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PowerStore Configuration"
+	PowerStore *PowerStoreConfig `json:"powerstore,omitempty" yaml:"powerstore,omitempty"`
+
+	// PowerScale is the PowerScale driver configuration; it takes precedence over the equivalent env vars
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PowerScale Configuration"
+	PowerScale *PowerScaleConfig `json:"powerscale,omitempty" yaml:"powerscale,omitempty"`
+
+	// Unity is the Unity driver configuration; it takes precedence over the equivalent env vars
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Unity Configuration"
+	Unity *UnityConfig `json:"unity,omitempty" yaml:"unity,omitempty"`
+
+	// Cosi is the COSI driver configuration; it takes precedence over the equivalent env vars
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="COSI Configuration"
+	Cosi *CosiConfig `json:"cosi,omitempty" yaml:"cosi,omitempty"`
+}
+
+// HealthMonitorConfig enables volume health monitoring in the driver plugins
+type HealthMonitorConfig struct {
+	// Controller enables volume health monitoring in the controller plugin (X_CSI_HEALTH_MONITOR_ENABLED)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Controller Health Monitor Enabled"
+	Controller *bool `json:"controller,omitempty" yaml:"controller,omitempty"`
+
+	// Node enables volume health monitoring in the node plugin (X_CSI_HEALTH_MONITOR_ENABLED)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Health Monitor Enabled"
+	Node *bool `json:"node,omitempty" yaml:"node,omitempty"`
+}
+
+// PowerFlexConfig is the PowerFlex driver configuration
+type PowerFlexConfig struct {
+	// Debug enables debug logging of the PowerFlex client (GOSCALEIO_DEBUG)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Debug"
+	Debug *bool `json:"debug,omitempty" yaml:"debug,omitempty"`
+
+	// ShowHTTP enables logging of the PowerFlex HTTP requests (GOSCALEIO_SHOWHTTP)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Show HTTP"
+	ShowHTTP *bool `json:"showHTTP,omitempty" yaml:"showHTTP,omitempty"`
+
+	// ProbeTimeout is the timeout of the driver probe (X_CSI_PROBE_TIMEOUT)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Probe Timeout"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	ProbeTimeout string `json:"probeTimeout,omitempty" yaml:"probeTimeout,omitempty"`
+
+	// AuthType is the authentication type used towards the array (X_CSI_AUTH_TYPE)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Auth Type"
+	// +kubebuilder:validation:Enum=OIDC
+	AuthType string `json:"authType,omitempty" yaml:"authType,omitempty"`
+
+	// ExternalAccess is the additional IP or subnet given access to NFS volumes (X_CSI_POWERFLEX_EXTERNAL_ACCESS)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="External Access"
+	ExternalAccess string `json:"externalAccess,omitempty" yaml:"externalAccess,omitempty"`
+
+	// SdcEnabled installs the SDC on the worker nodes (X_CSI_SDC_ENABLED)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="SDC Enabled"
+	SdcEnabled *bool `json:"sdcEnabled,omitempty" yaml:"sdcEnabled,omitempty"`
+
+	// ApproveSdcEnabled approves the SDC of new nodes (X_CSI_APPROVE_SDC_ENABLED)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Approve SDC Enabled"
+	ApproveSdcEnabled *bool `json:"approveSdcEnabled,omitempty" yaml:"approveSdcEnabled,omitempty"`
+
+	// RenameSdcEnabled renames the SDC of the nodes (X_CSI_RENAME_SDC_ENABLED)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rename SDC Enabled"
+	RenameSdcEnabled *bool `json:"renameSdcEnabled,omitempty" yaml:"renameSdcEnabled,omitempty"`
+
+	// RenameSdcPrefix is the prefix used when renaming the SDC (X_CSI_RENAME_SDC_PREFIX)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rename SDC Prefix"
+	RenameSdcPrefix string `json:"renameSdcPrefix,omitempty" yaml:"renameSdcPrefix,omitempty"`
+
+	// MaxVolumesPerNode is the maximum number of volumes per node, 0 means unlimited (X_CSI_MAX_VOLUMES_PER_NODE)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Volumes Per Node"
+	// +kubebuilder:validation:Minimum=0
+	MaxVolumesPerNode *int32 `json:"maxVolumesPerNode,omitempty" yaml:"maxVolumesPerNode,omitempty"`
+
+	// SftpRepoEnabled pulls the SDC from an SFTP repository (X_CSI_SDC_SFTP_REPO_ENABLED)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="SFTP Repo Enabled"
+	SftpRepoEnabled *bool `json:"sftpRepoEnabled,omitempty" yaml:"sftpRepoEnabled,omitempty"`
+
+	// SftpRepoAddress is the address of the SFTP repository (REPO_ADDRESS)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="SFTP Repo Address"
+	SftpRepoAddress string `json:"sftpRepoAddress,omitempty" yaml:"sftpRepoAddress,omitempty"`
+
+	// SftpRepoUser is the user of the SFTP repository (REPO_USER)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="SFTP Repo User"
+	SftpRepoUser string `json:"sftpRepoUser,omitempty" yaml:"sftpRepoUser,omitempty"`
+
+	// HealthMonitor enables volume health monitoring
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Health Monitor"
+	HealthMonitor *HealthMonitorConfig `json:"healthMonitor,omitempty" yaml:"healthMonitor,omitempty"`
+}
+
+// PowerMaxConfig is the PowerMax driver configuration
+type PowerMaxConfig struct {
+	// ManagedArrays is the comma separated list of array IDs managed by the driver (X_CSI_MANAGED_ARRAYS)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Managed Arrays"
+	ManagedArrays string `json:"managedArrays,omitempty" yaml:"managedArrays,omitempty"`
+
+	// Endpoint is the Unisphere endpoint (X_CSI_POWERMAX_ENDPOINT)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint"
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+
+	// Debug enables debug logging of the PowerMax client (X_CSI_POWERMAX_DEBUG)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Debug"
+	Debug *bool `json:"debug,omitempty" yaml:"debug,omitempty"`
+
+	// PortGroups is the comma separated list of iSCSI or NVMe/TCP port groups (X_CSI_POWERMAX_PORTGROUPS)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Port Groups"
+	PortGroups string `json:"portGroups,omitempty" yaml:"portGroups,omitempty"`
+
+	// TransportProtocol is the transport protocol, automatically selected when empty (X_CSI_TRANSPORT_PROTOCOL)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Transport Protocol"
+	// +kubebuilder:validation:Enum=FC;FIBER;ISCSI;NVMETCP
+	TransportProtocol string `json:"transportProtocol,omitempty" yaml:"transportProtocol,omitempty"`
+
+	// ModifyHostName changes the host name of existing hosts to match the node name template (X_CSI_IG_MODIFY_HOSTNAME)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Modify Host Name"
+	ModifyHostName *bool `json:"modifyHostName,omitempty" yaml:"modifyHostName,omitempty"`
+
+	// NodeNameTemplate is the template used for host names on the array (X_CSI_IG_NODENAME_TEMPLATE)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Name Template"
+	NodeNameTemplate string `json:"nodeNameTemplate,omitempty" yaml:"nodeNameTemplate,omitempty"`
+
+	// DynamicSGEnabled enables dynamic storage group creation (X_CSI_DYNAMIC_SG_ENABLED)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Dynamic Storage Groups Enabled"
+	DynamicSGEnabled *bool `json:"dynamicSGEnabled,omitempty" yaml:"dynamicSGEnabled,omitempty"`
+
+	// VSphere is the configuration for hosts running on VMware vSphere
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="vSphere Configuration"
+	VSphere *PowerMaxVSphereConfig `json:"vSphere,omitempty" yaml:"vSphere,omitempty"`
+
+	// ISCSIEnableCHAP enables CHAP authentication for iSCSI (X_CSI_POWERMAX_ISCSI_ENABLE_CHAP)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="iSCSI CHAP Enabled"
+	ISCSIEnableCHAP *bool `json:"iscsiEnableCHAP,omitempty" yaml:"iscsiEnableCHAP,omitempty"`
+
+	// TopologyControlEnabled enables topology control of the node plugin (X_CSI_TOPOLOGY_CONTROL_ENABLED)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Topology Control Enabled"
+	TopologyControlEnabled *bool `json:"topologyControlEnabled,omitempty" yaml:"topologyControlEnabled,omitempty"`
+
+	// MaxVolumesPerNode is the maximum number of volumes per node, 0 means unlimited (X_CSI_MAX_VOLUMES_PER_NODE)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Volumes Per Node"
+	// +kubebuilder:validation:Minimum=0
+	MaxVolumesPerNode *int32 `json:"maxVolumesPerNode,omitempty" yaml:"maxVolumesPerNode,omitempty"`
+
+	// HealthMonitor enables volume health monitoring
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Health Monitor"
+	HealthMonitor *HealthMonitorConfig `json:"healthMonitor,omitempty" yaml:"healthMonitor,omitempty"`
+}
+
+// PowerMaxVSphereConfig is the PowerMax configuration for hosts running on VMware vSphere
+type PowerMaxVSphereConfig struct {
+	// Enabled enables vSphere support (X_CSI_VSPHERE_ENABLED)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="vSphere Enabled"
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+
+	// PortGroup is the FC port group used for vSphere hosts (X_CSI_VSPHERE_PORTGROUP)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="vSphere Port Group"
+	PortGroup string `json:"portGroup,omitempty" yaml:"portGroup,omitempty"`
+
+	// HostName is the host or host group used for vSphere hosts (X_CSI_VSPHERE_HOSTNAME)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="vSphere Host Name"
+	HostName string `json:"hostName,omitempty" yaml:"hostName,omitempty"`
+
+	// VCenterHost is the vCenter host (X_CSI_VCENTER_HOST)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="vCenter Host"
+	VCenterHost string `json:"vCenterHost,omitempty" yaml:"vCenterHost,omitempty"`
+}
+
+// PowerStoreConfig is the PowerStore driver configuration
+type PowerStoreConfig struct {
+	// Debug enables debug logging of the PowerStore client (GOPOWERSTORE_DEBUG)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Debug"
+	Debug *bool `json:"debug,omitempty" yaml:"debug,omitempty"`
+
+	// APITimeout is the timeout of PowerStore API calls (X_CSI_POWERSTORE_API_TIMEOUT)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="API Timeout"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	APITimeout string `json:"apiTimeout,omitempty" yaml:"apiTimeout,omitempty"`
+
+	// PodmonArrayConnectivityTimeout is the timeout of the resiliency array connectivity check (X_CSI_PODMON_ARRAY_CONNECTIVITY_TIMEOUT)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Podmon Array Connectivity Timeout"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	PodmonArrayConnectivityTimeout string `json:"podmonArrayConnectivityTimeout,omitempty" yaml:"podmonArrayConnectivityTimeout,omitempty"`
+
+	// NodeNamePrefix is the prefix of the host names registered on the array (X_CSI_POWERSTORE_NODE_NAME_PREFIX)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Name Prefix"
+	NodeNamePrefix string `json:"nodeNamePrefix,omitempty" yaml:"nodeNamePrefix,omitempty"`
+
+	// FCPortsFilterFilePath is the path of the file that filters the FC ports used by the node (X_CSI_FC_PORTS_FILTER_FILE_PATH)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="FC Ports Filter File Path"
+	FCPortsFilterFilePath string `json:"fcPortsFilterFilePath,omitempty" yaml:"fcPortsFilterFilePath,omitempty"`
+
+	// NFSAcls is the permissions set on NFS mount directories (X_CSI_NFS_ACLS)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="NFS ACLs"
+	NFSAcls string `json:"nfsAcls,omitempty" yaml:"nfsAcls,omitempty"`
+
+	// ExternalAccess is the additional IP or subnet given access to NFS volumes (X_CSI_POWERSTORE_EXTERNAL_ACCESS)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="External Access"
+	ExternalAccess string `json:"externalAccess,omitempty" yaml:"externalAccess,omitempty"`
+
+	// ExclusiveAccess adds only the external access entries to NFS exports (X_CSI_POWERSTORE_EXCLUSIVE_ACCESS)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exclusive Access"
+	ExclusiveAccess *bool `json:"exclusiveAccess,omitempty" yaml:"exclusiveAccess,omitempty"`
+
+	// EnableCHAP enables CHAP authentication for iSCSI (X_CSI_POWERSTORE_ENABLE_CHAP)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CHAP Enabled"
+	EnableCHAP *bool `json:"enableCHAP,omitempty" yaml:"enableCHAP,omitempty"`
+
+	// MaxVolumesPerNode is the maximum number of volumes per node, 0 means unlimited (X_CSI_POWERSTORE_MAX_VOLUMES_PER_NODE)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Volumes Per Node"
+	// +kubebuilder:validation:Minimum=0
+	MaxVolumesPerNode *int32 `json:"maxVolumesPerNode,omitempty" yaml:"maxVolumesPerNode,omitempty"`
+
+	// VolumeDisconnectMaxRetries is the maximum number of volume disconnect retries (X_CSI_VOLUME_DISCONNECT_MAX_RETRIES)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Disconnect Max Retries"
+	// +kubebuilder:validation:Minimum=0
+	VolumeDisconnectMaxRetries *int32 `json:"volumeDisconnectMaxRetries,omitempty" yaml:"volumeDisconnectMaxRetries,omitempty"`
+
+	// VolumeDisconnectRetryInterval is the wait time between volume disconnect retries (X_CSI_VOLUME_DISCONNECT_RETRY_INTERVAL)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Disconnect Retry Interval"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	VolumeDisconnectRetryInterval string `json:"volumeDisconnectRetryInterval,omitempty" yaml:"volumeDisconnectRetryInterval,omitempty"`
+
+	// VolumeDisconnectTimeout is the timeout of a volume disconnect (X_CSI_VOLUME_DISCONNECT_TIMEOUT_SECONDS)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Disconnect Timeout"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	VolumeDisconnectTimeout string `json:"volumeDisconnectTimeout,omitempty" yaml:"volumeDisconnectTimeout,omitempty"`
+
+	// HealthMonitor enables volume health monitoring
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Health Monitor"
+	HealthMonitor *HealthMonitorConfig `json:"healthMonitor,omitempty" yaml:"healthMonitor,omitempty"`
+}
+
+// PowerScaleConfig is the PowerScale driver configuration
+type PowerScaleConfig struct {
+	// Debug enables debug logging of the PowerScale client (GOISILON_DEBUG)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Debug"
+	Debug *bool `json:"debug,omitempty" yaml:"debug,omitempty"`
+
+	// VolumePrefix is the prefix of the volumes created by the driver (X_CSI_VOL_PREFIX)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Prefix"
+	VolumePrefix string `json:"volumePrefix,omitempty" yaml:"volumePrefix,omitempty"`
+
+	// HealthMonitor enables volume health monitoring
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Health Monitor"
+	HealthMonitor *HealthMonitorConfig `json:"healthMonitor,omitempty" yaml:"healthMonitor,omitempty"`
+}
+
+// UnityConfig is the Unity driver configuration
+type UnityConfig struct {
+	// Debug enables debug logging of the Unity client (GOUNITY_DEBUG)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Debug"
+	Debug *bool `json:"debug,omitempty" yaml:"debug,omitempty"`
+
+	// ShowHTTP enables logging of the Unity HTTP requests (GOUNITY_SHOWHTTP)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Show HTTP"
+	ShowHTTP *bool `json:"showHTTP,omitempty" yaml:"showHTTP,omitempty"`
+
+	// AllowedNetworks is the comma separated list of networks used for NFS and iSCSI traffic (X_CSI_ALLOWED_NETWORKS)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Allowed Networks"
+	AllowedNetworks string `json:"allowedNetworks,omitempty" yaml:"allowedNetworks,omitempty"`
+
+	// HealthMonitor enables volume health monitoring
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Health Monitor"
+	HealthMonitor *HealthMonitorConfig `json:"healthMonitor,omitempty" yaml:"healthMonitor,omitempty"`
+}
+
+// CosiConfig is the COSI driver configuration
+type CosiConfig struct {
+	// OtelCollectorAddress is the address of the OpenTelemetry collector (OTEL_COLLECTOR_ADDRESS)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OpenTelemetry Collector Address"
+	OtelCollectorAddress string `json:"otelCollectorAddress,omitempty" yaml:"otelCollectorAddress,omitempty"`
 }
 
 // DriverMetrics defines the metrics endpoint and monitoring configuration for a driver
@@ -305,6 +707,23 @@ type MetricsServiceMonitorConfig struct {
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify,omitempty"`
 }
 
+// PodTemplate is the template of a container and of the scheduling of the pods running it
+type PodTemplate struct {
+	ContainerTemplate `json:",inline" yaml:",inline"`
+
+	// Affinity is the scheduling constraints for the pods
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Affinity",xDescriptors="urn:alm:descriptor:com.tectonic.ui:nodeAffinity"
+	Affinity *corev1.Affinity `json:"affinity,omitempty" yaml:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the pods are spread across topology domains
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Topology Spread Constraints"
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty" yaml:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the priority class for the pods, e.g. system-node-critical
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Priority Class Name"
+	PriorityClassName string `json:"priorityClassName,omitempty" yaml:"priorityClassName,omitempty"`
+}
+
 // ContainerTemplate template
 type ContainerTemplate struct {
 	// Name is the name of Container
@@ -341,6 +760,22 @@ type ContainerTemplate struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="NodeSelector"
 	NodeSelector map[string]string `json:"nodeSelector,omitempty" yaml:"nodeSelector"`
 
+	// Resources are the compute resource requests and limits for the container, a core/v1 ResourceRequirements
+	// The schema is not embedded to keep the size of the CRD down, since every container template has it
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Container Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty" yaml:"resources,omitempty"`
+
+	// SecurityContext is the security context for the container, a core/v1 SecurityContext
+	// The schema is not embedded to keep the size of the CRD down, since every container template has it
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Container Security Context"
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty" yaml:"securityContext,omitempty"`
+
 	// ProxyService is the image tag for the Container
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Authorization Proxy Service Container Image"
 	ProxyService string `json:"proxyService,omitempty" yaml:"proxyService,omitempty"`
@@ -502,6 +937,46 @@ type SnapshotClass struct {
 	Parameters map[string]string `json:"parameters,omitempty" yaml:"parameters"`
 }
 
+// StorageClass is the specification of a Storage Class rendered for the driver
+type StorageClass struct {
+	// Name is the name of the Storage Class
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Class Name"
+	// +kubebuilder:validation:Required
+	Name string `json:"name" yaml:"name"`
+
+	// ArrayID is the id of the array the Storage Class provisions from: the PowerFlex systemID, the PowerStore arrayID,
+	// the PowerScale ClusterName, the PowerMax SYMID or the Unity arrayId
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Array ID"
+	ArrayID string `json:"arrayID,omitempty" yaml:"arrayID,omitempty"`
+
+	// Parameters is a map of driver specific parameters for the Storage Class, e.g. storagepool or AccessZone
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Class Parameters"
+	Parameters map[string]string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+
+	// ReclaimPolicy is the reclaim policy of the volumes provisioned by the Storage Class, defaults to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Reclaim Policy"
+	// +kubebuilder:validation:Enum=Delete;Retain
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy,omitempty" yaml:"reclaimPolicy,omitempty"`
+
+	// VolumeBindingMode indicates how the volumes of the Storage Class are bound, defaults to WaitForFirstConsumer
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Binding Mode"
+	// +kubebuilder:validation:Enum=Immediate;WaitForFirstConsumer
+	VolumeBindingMode *storagev1.VolumeBindingMode `json:"volumeBindingMode,omitempty" yaml:"volumeBindingMode,omitempty"`
+
+	// AllowVolumeExpansion is the boolean flag used to allow the volumes of the Storage Class to be expanded
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Allow Volume Expansion"
+	AllowVolumeExpansion *bool `json:"allowVolumeExpansion,omitempty" yaml:"allowVolumeExpansion,omitempty"`
+
+	// MountOptions is the list of mount options of the volumes provisioned by the Storage Class
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mount Options"
+	MountOptions []string `json:"mountOptions,omitempty" yaml:"mountOptions,omitempty"`
+
+	// AllowedTopologies restricts the nodes the volumes can be provisioned for.
+	// When empty, it is derived from the zone of the array in the array secret.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Allowed Topologies"
+	AllowedTopologies []corev1.TopologySelectorTerm `json:"allowedTopologies,omitempty" yaml:"allowedTopologies,omitempty"`
+}
+
 // ProxyServerIngress is the authorization ingress configuration struct
 type ProxyServerIngress struct {
 	// IngressClassName is the ingressClassName
@@ -607,6 +1082,48 @@ type ConjurConfigPath struct {
 	SecretPath string `json:"secretPath,omitempty" yaml:"secretPath,omitempty"`
 }
 
+// RollbackPolicy defines when the operator rolls back to the last successful configuration
+type RollbackPolicy struct {
+	// Enabled is the boolean flag used to roll back a spec change that does not reach Succeeded within the timeout
+	// The last successful configuration is applied again, while the spec of the ContainerStorageModule is left untouched
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rollback Enabled"
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+
+	// Timeout is how long a spec change has to reach Succeeded before it is rolled back, 10m when empty
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rollback Timeout"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// NodeRolloutStrategy defines how the node plugin is rolled out to the nodes
+type NodeRolloutStrategy struct {
+	// Enabled is the boolean flag used to update the node DaemonSet with the OnDelete strategy and roll it out batch by batch
+	// A node is updated once its new node pod is ready and the driver is registered again in its CSINode
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Rollout Enabled"
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+
+	// BatchSize is the number or percentage of nodes updated at a time, 1 when empty, or the whole group when groupByLabel is set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Batch Size"
+	// +optional
+	BatchSize *intstr.IntOrString `json:"batchSize,omitempty" yaml:"batchSize,omitempty"`
+
+	// GroupByLabel is the key of a node label, nodes with the same value are updated together and a batch never spans two groups
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group By Label"
+	// +optional
+	GroupByLabel string `json:"groupByLabel,omitempty" yaml:"groupByLabel,omitempty"`
+
+	// CanaryNodeSelector selects the nodes updated first, the other nodes are updated once all of them are healthy
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Canary Node Selector"
+	// +optional
+	CanaryNodeSelector map[string]string `json:"canaryNodeSelector,omitempty" yaml:"canaryNodeSelector,omitempty"`
+
+	// BatchTimeout is how long the nodes of a batch have to be updated before the rollout is paused, 10m when empty
+	// A paused rollout resumes when the node DaemonSet changes, for instance after the spec is fixed
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Batch Timeout"
+	// +optional
+	BatchTimeout *metav1.Duration `json:"batchTimeout,omitempty" yaml:"batchTimeout,omitempty"`
+}
+
 // CSIDriverSpec struct
 type CSIDriverSpec struct {
 	FSGroupPolicy   string `json:"fSGroupPolicy,omitempty" yaml:"fSGroupPolicy,omitempty"`
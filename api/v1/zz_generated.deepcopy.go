@@ -22,7 +22,10 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -40,6 +43,23 @@ func (in *CSIDriverSpec) DeepCopy() *CSIDriverSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+	*out = *in
+	out.ControllerStatus = in.ControllerStatus
+	out.NodeStatus = in.NodeStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigSecretProviderClass) DeepCopyInto(out *ConfigSecretProviderClass) {
 	*out = *in
@@ -116,7 +136,7 @@ func (in *ContainerStorageModule) DeepCopyInto(out *ContainerStorageModule) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerStorageModule.
@@ -180,6 +200,31 @@ func (in *ContainerStorageModuleSpec) DeepCopyInto(out *ContainerStorageModuleSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetClusters != nil {
+		in, out := &in.TargetClusters, &out.TargetClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RollbackPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RollbackTo != nil {
+		in, out := &in.RollbackTo, &out.RollbackTo
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerStorageModuleSpec.
@@ -197,6 +242,37 @@ func (in *ContainerStorageModuleStatus) DeepCopyInto(out *ContainerStorageModule
 	*out = *in
 	out.ControllerStatus = in.ControllerStatus
 	out.NodeStatus = in.NodeStatus
+	if in.Modules != nil {
+		in, out := &in.Modules, &out.Modules
+		*out = make([]ModuleStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedClusters != nil {
+		in, out := &in.AppliedClusters, &out.AppliedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolloutStartTime != nil {
+		in, out := &in.RolloutStartTime, &out.RolloutStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.UpgradePath != nil {
+		in, out := &in.UpgradePath, &out.UpgradePath
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerStorageModuleStatus.
@@ -243,6 +319,16 @@ func (in *ContainerTemplate) DeepCopyInto(out *ContainerTemplate) {
 			(*out)[key] = val
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProxyServerIngress != nil {
 		in, out := &in.ProxyServerIngress, &out.ProxyServerIngress
 		*out = make([]ProxyServerIngress, len(*in))
@@ -296,6 +382,21 @@ func (in *ContainerTemplate) DeepCopy() *ContainerTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CosiConfig) DeepCopyInto(out *CosiConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CosiConfig.
+func (in *CosiConfig) DeepCopy() *CosiConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CosiConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Driver) DeepCopyInto(out *Driver) {
 	*out = *in
@@ -311,12 +412,17 @@ func (in *Driver) DeepCopyInto(out *Driver) {
 	}
 	if in.Controller != nil {
 		in, out := &in.Controller, &out.Controller
-		*out = new(ContainerTemplate)
+		*out = new(PodTemplate)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Node != nil {
 		in, out := &in.Node, &out.Node
-		*out = new(ContainerTemplate)
+		*out = new(PodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeRollout != nil {
+		in, out := &in.NodeRollout, &out.NodeRollout
+		*out = new(NodeRolloutStrategy)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SideCars != nil {
@@ -340,6 +446,13 @@ func (in *Driver) DeepCopyInto(out *Driver) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = make([]StorageClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ForceRemoveDriver != nil {
 		in, out := &in.ForceRemoveDriver, &out.ForceRemoveDriver
 		*out = new(bool)
@@ -350,6 +463,36 @@ func (in *Driver) DeepCopyInto(out *Driver) {
 		*out = new(DriverMetrics)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PowerFlex != nil {
+		in, out := &in.PowerFlex, &out.PowerFlex
+		*out = new(PowerFlexConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerMax != nil {
+		in, out := &in.PowerMax, &out.PowerMax
+		*out = new(PowerMaxConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerStore != nil {
+		in, out := &in.PowerStore, &out.PowerStore
+		*out = new(PowerStoreConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerScale != nil {
+		in, out := &in.PowerScale, &out.PowerScale
+		*out = new(PowerScaleConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Unity != nil {
+		in, out := &in.Unity, &out.Unity
+		*out = new(UnityConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cosi != nil {
+		in, out := &in.Cosi, &out.Cosi
+		*out = new(CosiConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Driver.
@@ -407,6 +550,31 @@ func (in *GatewayMonitoringConfig) DeepCopy() *GatewayMonitoringConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthMonitorConfig) DeepCopyInto(out *HealthMonitorConfig) {
+	*out = *in
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Node != nil {
+		in, out := &in.Node, &out.Node
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthMonitorConfig.
+func (in *HealthMonitorConfig) DeepCopy() *HealthMonitorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthMonitorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsServiceMonitorConfig) DeepCopyInto(out *MetricsServiceMonitorConfig) {
 	*out = *in
@@ -427,7 +595,7 @@ func (in *Module) DeepCopyInto(out *Module) {
 	*out = *in
 	if in.Components != nil {
 		in, out := &in.Components, &out.Components
-		*out = make([]ContainerTemplate, len(*in))
+		*out = make([]PodTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -439,6 +607,18 @@ func (in *Module) DeepCopyInto(out *Module) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeGroupSnapshotClasses != nil {
+		in, out := &in.VolumeGroupSnapshotClasses, &out.VolumeGroupSnapshotClasses
+		*out = make([]SnapshotClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Module.
@@ -451,6 +631,53 @@ func (in *Module) DeepCopy() *Module {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleStatus) DeepCopyInto(out *ModuleStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleStatus.
+func (in *ModuleStatus) DeepCopy() *ModuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeRolloutStrategy) DeepCopyInto(out *NodeRolloutStrategy) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.CanaryNodeSelector != nil {
+		in, out := &in.CanaryNodeSelector, &out.CanaryNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BatchTimeout != nil {
+		in, out := &in.BatchTimeout, &out.BatchTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRolloutStrategy.
+func (in *NodeRolloutStrategy) DeepCopy() *NodeRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodStatus) DeepCopyInto(out *PodStatus) {
 	*out = *in
@@ -466,6 +693,234 @@ func (in *PodStatus) DeepCopy() *PodStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+	*out = *in
+	in.ContainerTemplate.DeepCopyInto(&out.ContainerTemplate)
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerFlexConfig) DeepCopyInto(out *PowerFlexConfig) {
+	*out = *in
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ShowHTTP != nil {
+		in, out := &in.ShowHTTP, &out.ShowHTTP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SdcEnabled != nil {
+		in, out := &in.SdcEnabled, &out.SdcEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ApproveSdcEnabled != nil {
+		in, out := &in.ApproveSdcEnabled, &out.ApproveSdcEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RenameSdcEnabled != nil {
+		in, out := &in.RenameSdcEnabled, &out.RenameSdcEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxVolumesPerNode != nil {
+		in, out := &in.MaxVolumesPerNode, &out.MaxVolumesPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SftpRepoEnabled != nil {
+		in, out := &in.SftpRepoEnabled, &out.SftpRepoEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HealthMonitor != nil {
+		in, out := &in.HealthMonitor, &out.HealthMonitor
+		*out = new(HealthMonitorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerFlexConfig.
+func (in *PowerFlexConfig) DeepCopy() *PowerFlexConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerFlexConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerMaxConfig) DeepCopyInto(out *PowerMaxConfig) {
+	*out = *in
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ModifyHostName != nil {
+		in, out := &in.ModifyHostName, &out.ModifyHostName
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DynamicSGEnabled != nil {
+		in, out := &in.DynamicSGEnabled, &out.DynamicSGEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VSphere != nil {
+		in, out := &in.VSphere, &out.VSphere
+		*out = new(PowerMaxVSphereConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ISCSIEnableCHAP != nil {
+		in, out := &in.ISCSIEnableCHAP, &out.ISCSIEnableCHAP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TopologyControlEnabled != nil {
+		in, out := &in.TopologyControlEnabled, &out.TopologyControlEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxVolumesPerNode != nil {
+		in, out := &in.MaxVolumesPerNode, &out.MaxVolumesPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HealthMonitor != nil {
+		in, out := &in.HealthMonitor, &out.HealthMonitor
+		*out = new(HealthMonitorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMaxConfig.
+func (in *PowerMaxConfig) DeepCopy() *PowerMaxConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerMaxConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerMaxVSphereConfig) DeepCopyInto(out *PowerMaxVSphereConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMaxVSphereConfig.
+func (in *PowerMaxVSphereConfig) DeepCopy() *PowerMaxVSphereConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerMaxVSphereConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerScaleConfig) DeepCopyInto(out *PowerScaleConfig) {
+	*out = *in
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HealthMonitor != nil {
+		in, out := &in.HealthMonitor, &out.HealthMonitor
+		*out = new(HealthMonitorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerScaleConfig.
+func (in *PowerScaleConfig) DeepCopy() *PowerScaleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerScaleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerStoreConfig) DeepCopyInto(out *PowerStoreConfig) {
+	*out = *in
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExclusiveAccess != nil {
+		in, out := &in.ExclusiveAccess, &out.ExclusiveAccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableCHAP != nil {
+		in, out := &in.EnableCHAP, &out.EnableCHAP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxVolumesPerNode != nil {
+		in, out := &in.MaxVolumesPerNode, &out.MaxVolumesPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VolumeDisconnectMaxRetries != nil {
+		in, out := &in.VolumeDisconnectMaxRetries, &out.VolumeDisconnectMaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HealthMonitor != nil {
+		in, out := &in.HealthMonitor, &out.HealthMonitor
+		*out = new(HealthMonitorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerStoreConfig.
+func (in *PowerStoreConfig) DeepCopy() *PowerStoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerStoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyServerGateway) DeepCopyInto(out *ProxyServerGateway) {
 	*out = *in
@@ -540,6 +995,26 @@ func (in *RedisSecretProviderClass) DeepCopy() *RedisSecretProviderClass {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackPolicy) DeepCopyInto(out *RollbackPolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackPolicy.
+func (in *RollbackPolicy) DeepCopy() *RollbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotClass) DeepCopyInto(out *SnapshotClass) {
 	*out = *in
@@ -562,6 +1037,55 @@ func (in *SnapshotClass) DeepCopy() *SnapshotClass {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClass) DeepCopyInto(out *StorageClass) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReclaimPolicy != nil {
+		in, out := &in.ReclaimPolicy, &out.ReclaimPolicy
+		*out = new(corev1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.VolumeBindingMode != nil {
+		in, out := &in.VolumeBindingMode, &out.VolumeBindingMode
+		*out = new(storagev1.VolumeBindingMode)
+		**out = **in
+	}
+	if in.AllowVolumeExpansion != nil {
+		in, out := &in.AllowVolumeExpansion, &out.AllowVolumeExpansion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MountOptions != nil {
+		in, out := &in.MountOptions, &out.MountOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedTopologies != nil {
+		in, out := &in.AllowedTopologies, &out.AllowedTopologies
+		*out = make([]corev1.TopologySelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClass.
+func (in *StorageClass) DeepCopy() *StorageClass {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageSystemSecretProviderClasses) DeepCopyInto(out *StorageSystemSecretProviderClasses) {
 	*out = *in
@@ -589,6 +1113,36 @@ func (in *StorageSystemSecretProviderClasses) DeepCopy() *StorageSystemSecretPro
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnityConfig) DeepCopyInto(out *UnityConfig) {
+	*out = *in
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ShowHTTP != nil {
+		in, out := &in.ShowHTTP, &out.ShowHTTP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HealthMonitor != nil {
+		in, out := &in.HealthMonitor, &out.HealthMonitor
+		*out = new(HealthMonitorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnityConfig.
+func (in *UnityConfig) DeepCopy() *UnityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UnityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Vault) DeepCopyInto(out *Vault) {
 	*out = *in
@@ -0,0 +1,76 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/helmvalues"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// runImportHelm - runs the import-helm command
+func runImportHelm(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("import-helm", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("f", "", "values.yaml of the csi-<driver> Helm chart")
+	driver := fs.String("driver", "", "driver of the chart: powerflex, powerstore, powerscale, unity or powermax")
+	name := fs.String("name", "", "name of the ContainerStorageModule, the release name of the docs when empty")
+	namespace := fs.String("namespace", "", "namespace of the ContainerStorageModule, the name when empty")
+	version := fs.String("version", "", "CSM version of the ContainerStorageModule, the chart version is used as the driver config version when empty")
+	strict := fs.Bool("strict", false, "fail when some values cannot be mapped")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("the values file is required (-f)")
+	}
+	if *driver == "" {
+		return fmt.Errorf("the driver is required (--driver)")
+	}
+
+	driverType := csmv1.DriverType(*driver)
+	if driverType == csmv1.PowerScaleName {
+		driverType = csmv1.PowerScale
+	}
+
+	buf, err := os.ReadFile(filepath.Clean(*file))
+	if err != nil {
+		return err
+	}
+	cr, unmapped, err := helmvalues.Convert(buf, helmvalues.Options{DriverType: driverType, Name: *name, Namespace: *namespace, Version: *version})
+	if err != nil {
+		return fmt.Errorf("converting %s: %v", *file, err)
+	}
+
+	for _, key := range unmapped {
+		fmt.Fprintf(stderr, "Warning: %s is not mapped onto the ContainerStorageModule\n", key)
+	}
+	if *strict && len(unmapped) > 0 {
+		return fmt.Errorf("%d values of %s cannot be mapped", len(unmapped), *file)
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cr)
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{Object: content}
+	cleanObject(obj)
+	return writeObjects(stdout, []*unstructured.Unstructured{obj})
+}
@@ -0,0 +1,64 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testValues = "../../pkg/helmvalues/testdata/powerstore_values.yaml"
+
+func TestRunImportHelm(t *testing.T) {
+	t.Run("converts the values into a valid CR", func(t *testing.T) {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		code := run([]string{"import-helm", "-f", testValues, "--driver", "powerstore", "--namespace", "csi-powerstore"}, stdout, stderr)
+		require.Equal(t, 0, code, stderr.String())
+		assert.Contains(t, stderr.String(), "Warning: podmon.image is not mapped")
+		assert.NotContains(t, stdout.String(), "creationTimestamp")
+
+		out := filepath.Join(t.TempDir(), "csm.yaml")
+		require.NoError(t, os.WriteFile(out, stdout.Bytes(), 0o600))
+		cr, _, err := loadInput(out, newScheme())
+		require.NoError(t, err)
+		assert.Equal(t, "powerstore", cr.Name)
+		assert.Equal(t, "csi-powerstore", cr.Namespace)
+		assert.True(t, cr.HasModule(csmv1.Resiliency))
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantErr  string
+	}{
+		{"without file", []string{"import-helm", "--driver", "unity"}, 1, "the values file is required"},
+		{"without driver", []string{"import-helm", "-f", testValues}, 1, "the driver is required"},
+		{"unsupported driver", []string{"import-helm", "-f", testValues, "--driver", "cosi"}, 1, "unsupported driver type cosi"},
+		{"strict", []string{"import-helm", "-f", testValues, "--driver", "powerstore", "--strict"}, 1, "4 values of"},
+		{"powerscale is an alias of isilon", []string{"import-helm", "-f", testValues, "--driver", "powerscale"}, 0, "Warning:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			assert.Equal(t, tt.wantCode, run(tt.args, stdout, stderr))
+			assert.Contains(t, stderr.String(), tt.wantErr)
+		})
+	}
+}
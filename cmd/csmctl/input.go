@@ -0,0 +1,137 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/logger"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// defaultConfigDir - operatorconfig directory of the repository
+	defaultConfigDir = "operatorconfig"
+	// defaultKubeVersion - latest kubernetes version supported by the operator
+	defaultKubeVersion = "1.36"
+)
+
+// newScheme - returns the scheme of the operator
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(csmv1.AddToScheme(scheme))
+	utilruntime.Must(apiextv1.AddToScheme(scheme))
+	utilruntime.Must(certmanagerv1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
+	return scheme
+}
+
+// getOperatorConfig - returns the operator config for the config directory and kubernetes version
+func getOperatorConfig(configDir, kubeVersion string, isOpenShift bool) (operatorutils.OperatorConfig, error) {
+	cfg := operatorutils.OperatorConfig{
+		IsOpenShift:     isOpenShift,
+		ConfigDirectory: filepath.Clean(configDir),
+	}
+
+	k8sPath := filepath.Join(cfg.ConfigDirectory, "driverconfig", "common", fmt.Sprintf("k8s-%s-values.yaml", kubeVersion))
+	buf, err := os.ReadFile(filepath.Clean(k8sPath))
+	if err != nil {
+		return cfg, fmt.Errorf("reading the config of kubernetes %s: %v", kubeVersion, err)
+	}
+	if err = yaml.Unmarshal(buf, &cfg.K8sVersion); err != nil {
+		return cfg, fmt.Errorf("unmarshalling %s: %v", k8sPath, err)
+	}
+	return cfg, nil
+}
+
+// loadInput - reads the ContainerStorageModule and the objects it reads, such as its secrets, from a multi-document YAML file
+func loadInput(path string, scheme *runtime.Scheme) (*csmv1.ContainerStorageModule, []crclient.Object, error) {
+	buf, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, nil, err
+	}
+	docs, err := operatorutils.SplitYaml(buf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("splitting %s: %v", path, err)
+	}
+
+	var cr *csmv1.ContainerStorageModule
+	var objects []crclient.Object
+	for _, doc := range docs {
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &u.Object); err != nil {
+			return nil, nil, fmt.Errorf("unmarshalling %s: %v", path, err)
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+		// the fake client refuses objects that already have a resource version
+		u.SetResourceVersion("")
+
+		gvk := u.GroupVersionKind()
+		if gvk.Kind == "ContainerStorageModule" {
+			if cr != nil {
+				return nil, nil, fmt.Errorf("%s holds more than one ContainerStorageModule", path)
+			}
+			cr = &csmv1.ContainerStorageModule{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, cr); err != nil {
+				return nil, nil, fmt.Errorf("converting ContainerStorageModule %s: %v", u.GetName(), err)
+			}
+			continue
+		}
+
+		if !scheme.Recognizes(gvk) {
+			objects = append(objects, u)
+			continue
+		}
+		typed, err := scheme.New(gvk)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, typed); err != nil {
+			return nil, nil, fmt.Errorf("converting %s %s: %v", gvk.Kind, u.GetName(), err)
+		}
+		objects = append(objects, typed.(crclient.Object))
+	}
+
+	if cr == nil {
+		return nil, nil, fmt.Errorf("%s does not hold a ContainerStorageModule", path)
+	}
+	return cr, objects, nil
+}
+
+// newContext - returns a context that sends the operator logs to w, or discards them
+func newContext(verbose bool, w io.Writer) context.Context {
+	l := zap.NewNop()
+	if verbose {
+		l = zap.New(zapcore.NewCore(zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()), zapcore.AddSync(w), zap.InfoLevel))
+	}
+	return logger.NewContextWithZapLogger(context.Background(), l)
+}
@@ -0,0 +1,302 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/controllers"
+	"github.com/dell/csm-operator/k8s"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/yaml"
+)
+
+// installOrder - rank of the kinds in the rendering, the kinds that others depend on come first
+var installOrder = map[string]int{
+	"Namespace":                1,
+	"CustomResourceDefinition": 2,
+	"ServiceAccount":           3,
+	"Secret":                   4,
+	"ConfigMap":                5,
+	"ClusterRole":              6,
+	"ClusterRoleBinding":       7,
+	"Role":                     8,
+	"RoleBinding":              9,
+	"Service":                  10,
+	"CSIDriver":                11,
+	"StorageClass":             12,
+	"VolumeSnapshotClass":      13,
+	"Deployment":               14,
+	"DaemonSet":                15,
+	"StatefulSet":              16,
+	"Job":                      17,
+}
+
+// objectKey - identifies an object written by the operator
+type objectKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+// writeRecorder - records the objects the operator writes through the controller-runtime client
+type writeRecorder struct {
+	scheme  *runtime.Scheme
+	lock    sync.Mutex
+	written map[objectKey]bool
+}
+
+func (w *writeRecorder) record(obj crclient.Object, exists bool) {
+	gvk, err := apiutil.GVKForObject(obj, w.scheme)
+	if err != nil {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.written[objectKey{gvk: gvk, namespace: obj.GetNamespace(), name: obj.GetName()}] = exists
+}
+
+func (w *writeRecorder) funcs() interceptor.Funcs {
+	return interceptor.Funcs{
+		Create: func(ctx context.Context, c crclient.WithWatch, obj crclient.Object, opts ...crclient.CreateOption) error {
+			if err := c.Create(ctx, obj, opts...); err != nil {
+				return err
+			}
+			w.record(obj, true)
+			return nil
+		},
+		Update: func(ctx context.Context, c crclient.WithWatch, obj crclient.Object, opts ...crclient.UpdateOption) error {
+			if err := c.Update(ctx, obj, opts...); err != nil {
+				return err
+			}
+			w.record(obj, true)
+			return nil
+		},
+		Patch: func(ctx context.Context, c crclient.WithWatch, obj crclient.Object, patch crclient.Patch, opts ...crclient.PatchOption) error {
+			if err := c.Patch(ctx, obj, patch, opts...); err != nil {
+				return err
+			}
+			w.record(obj, true)
+			return nil
+		},
+		Delete: func(ctx context.Context, c crclient.WithWatch, obj crclient.Object, opts ...crclient.DeleteOption) error {
+			if err := c.Delete(ctx, obj, opts...); err != nil {
+				return err
+			}
+			w.record(obj, false)
+			return nil
+		},
+	}
+}
+
+// runRender - runs the render command
+func runRender(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("f", "", "YAML file holding the ContainerStorageModule, and the secrets and configmaps it reads")
+	configDir := fs.String("config-dir", defaultConfigDir, "operatorconfig directory holding the driver and module templates")
+	kubeVersion := fs.String("kube-version", defaultKubeVersion, "kubernetes version to render the sidecar images for")
+	isOpenShift := fs.Bool("openshift", false, "render for an OpenShift cluster")
+	verbose := fs.Bool("v", false, "print the operator logs to stderr")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("the ContainerStorageModule file is required (-f)")
+	}
+
+	scheme := newScheme()
+	cr, objects, err := loadInput(*file, scheme)
+	if err != nil {
+		return err
+	}
+	op, err := getOperatorConfig(*configDir, *kubeVersion, *isOpenShift)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := render(newContext(*verbose, stderr), scheme, *cr, objects, op)
+	if err != nil {
+		return err
+	}
+	return writeObjects(stdout, rendered)
+}
+
+// render - runs SyncCSM against fake clients and returns every object it wrote
+func render(ctx context.Context, scheme *runtime.Scheme, cr csmv1.ContainerStorageModule, objects []crclient.Object, op operatorutils.OperatorConfig) ([]*unstructured.Unstructured, error) {
+	if err := controllers.SetCSMDefaults(ctx, &cr, op); err != nil {
+		return nil, err
+	}
+
+	recorder := &writeRecorder{scheme: scheme, written: map[objectKey]bool{}}
+	ctrlClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(objects, &cr)...).
+		WithStatusSubresource(&csmv1.ContainerStorageModule{}).
+		WithInterceptorFuncs(recorder.funcs()).
+		Build()
+	k8sClient := k8sfake.NewClientset()
+
+	// SyncCSM looks up the cluster flavor through the discovery API
+	k8s.GetClientSetWrapper = func() (kubernetes.Interface, error) {
+		return k8sClient, nil
+	}
+
+	// read back the CR so that it carries the resource version of the fake client
+	if err := ctrlClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, &cr); err != nil {
+		return nil, err
+	}
+	// SyncCSM records the applied spec in the annotations of the CR
+	if cr.Annotations == nil {
+		cr.Annotations = map[string]string{}
+	}
+
+	r := &controllers.ContainerStorageModuleReconciler{
+		Client:        ctrlClient,
+		K8sClient:     k8sClient,
+		Scheme:        scheme,
+		Config:        op,
+		EventRecorder: record.NewFakeRecorder(100),
+	}
+	if err := r.SyncCSM(ctx, cr, op, ctrlClient); err != nil {
+		return nil, fmt.Errorf("rendering %s: %v", cr.Name, err)
+	}
+
+	return collectObjects(ctx, recorder, ctrlClient, k8sClient)
+}
+
+// collectObjects - returns the objects written to the fake clients, in install order
+func collectObjects(ctx context.Context, recorder *writeRecorder, ctrlClient crclient.Client, k8sClient kubernetes.Interface) ([]*unstructured.Unstructured, error) {
+	var rendered []*unstructured.Unstructured
+	for key, exists := range recorder.written {
+		if !exists || key.gvk.Kind == "ContainerStorageModule" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(key.gvk)
+		err := ctrlClient.Get(ctx, types.NamespacedName{Namespace: key.namespace, Name: key.name}, obj)
+		if k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("getting %s %s: %v", key.gvk.Kind, key.name, err)
+		}
+		rendered = append(rendered, obj)
+	}
+
+	// the driver and module workloads are applied through the kubernetes client
+	deployments, err := k8sClient.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing deployments: %v", err)
+	}
+	for i := range deployments.Items {
+		obj, err := toUnstructured(&deployments.Items[i], appsv1.SchemeGroupVersion.WithKind("Deployment"))
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, obj)
+	}
+	daemonsets, err := k8sClient.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing daemonsets: %v", err)
+	}
+	for i := range daemonsets.Items {
+		obj, err := toUnstructured(&daemonsets.Items[i], appsv1.SchemeGroupVersion.WithKind("DaemonSet"))
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, obj)
+	}
+
+	for _, obj := range rendered {
+		cleanObject(obj)
+	}
+	sortObjects(rendered)
+	return rendered, nil
+}
+
+func toUnstructured(obj runtime.Object, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s: %v", gvk.Kind, err)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+// cleanObject - removes the fields set by the API server, which the operator does not render
+func cleanObject(obj *unstructured.Unstructured) {
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetGeneration(0)
+	obj.SetManagedFields(nil)
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(obj.Object, "status")
+}
+
+// sortObjects - sorts the objects by install order, then namespace and name
+func sortObjects(objects []*unstructured.Unstructured) {
+	rank := func(kind string) int {
+		if r, ok := installOrder[kind]; ok {
+			return r
+		}
+		return len(installOrder) + 1
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		a, b := objects[i], objects[j]
+		if rank(a.GetKind()) != rank(b.GetKind()) {
+			return rank(a.GetKind()) < rank(b.GetKind())
+		}
+		if a.GetKind() != b.GetKind() {
+			return a.GetKind() < b.GetKind()
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+}
+
+// writeObjects - writes the objects as a multi-document YAML
+func writeObjects(w io.Writer, objects []*unstructured.Unstructured) error {
+	for _, obj := range objects {
+		out, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	testConfigDir = "../../operatorconfig"
+	testSample    = "../../samples/v2.17.0/storage_csm_powerstore_v2170.yaml"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantErr  string
+	}{
+		{"no command", nil, 2, "Usage:"},
+		{"help", []string{"help"}, 0, ""},
+		{"unknown command", []string{"apply"}, 2, `unknown command "apply"`},
+		{"render help", []string{"render", "-h"}, 0, "-kube-version"},
+		{"render without file", []string{"render"}, 1, "the ContainerStorageModule file is required"},
+		{"render missing file", []string{"render", "-f", "testdata/missing.yaml"}, 1, "missing.yaml"},
+		{"render unknown kubernetes version", []string{"render", "-f", testSample, "--config-dir", testConfigDir, "--kube-version", "1.2"}, 1, "reading the config of kubernetes 1.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			assert.Equal(t, tt.wantCode, run(tt.args, stdout, stderr))
+			assert.Contains(t, stderr.String(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunRender(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run([]string{"render", "-f", testSample, "--config-dir", testConfigDir}, stdout, stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	kinds := map[string][]string{}
+	for _, doc := range strings.Split(stdout.String(), "---\n") {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &obj.Object))
+		assert.Empty(t, obj.GetResourceVersion())
+		assert.Empty(t, obj.GetUID())
+		_, hasStatus := obj.Object["status"]
+		assert.False(t, hasStatus)
+		kinds[obj.GetKind()] = append(kinds[obj.GetKind()], obj.GetName())
+	}
+
+	assert.Contains(t, kinds["Deployment"], "powerstore-controller")
+	assert.Contains(t, kinds["DaemonSet"], "powerstore-node")
+	assert.Contains(t, kinds["CSIDriver"], "csi-powerstore.dellemc.com")
+	assert.Contains(t, kinds["ServiceAccount"], "powerstore-controller")
+	assert.NotContains(t, kinds, "ContainerStorageModule")
+
+	// the objects come in install order
+	out := stdout.String()
+	assert.Less(t, strings.Index(out, "kind: ServiceAccount"), strings.Index(out, "kind: Deployment"))
+	assert.Less(t, strings.Index(out, "kind: Deployment"), strings.Index(out, "kind: DaemonSet"))
+}
+
+func TestLoadInput(t *testing.T) {
+	scheme := newScheme()
+
+	cr, objects, err := loadInput("testdata/powerstore_with_secret.yaml", scheme)
+	require.NoError(t, err)
+	assert.Equal(t, "powerstore", cr.Name)
+	require.Len(t, objects, 1)
+	secret, ok := objects[0].(*corev1.Secret)
+	require.True(t, ok)
+	assert.Equal(t, "powerstore-config", secret.Name)
+	assert.Empty(t, secret.ResourceVersion)
+
+	_, _, err = loadInput("testdata/no_csm.yaml", scheme)
+	assert.ErrorContains(t, err, "does not hold a ContainerStorageModule")
+
+	_, _, err = loadInput("testdata/two_csms.yaml", scheme)
+	assert.ErrorContains(t, err, "more than one ContainerStorageModule")
+}
+
+func TestGetOperatorConfig(t *testing.T) {
+	op, err := getOperatorConfig(testConfigDir, defaultKubeVersion, true)
+	require.NoError(t, err)
+	assert.True(t, op.IsOpenShift)
+	assert.NotEqual(t, operatorutils.K8sImagesConfig{}, op.K8sVersion)
+
+	_, err = getOperatorConfig(testConfigDir, "0.1", false)
+	assert.Error(t, err)
+}
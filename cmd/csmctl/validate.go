@@ -0,0 +1,301 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/drivers"
+	"github.com/dell/csm-operator/pkg/modules"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// status of a validation finding
+const (
+	statusPass = "pass"
+	statusFail = "fail"
+	statusSkip = "skip"
+)
+
+// errInvalid - returned when at least one check fails, so that the exit code blocks CI
+var errInvalid = errors.New("the ContainerStorageModule is not valid")
+
+// finding - result of one validation check
+type finding struct {
+	Check   string `json:"check"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// report - results of the validation of a ContainerStorageModule
+type report struct {
+	File      string    `json:"file"`
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Valid     bool      `json:"valid"`
+	Findings  []finding `json:"findings"`
+}
+
+func (r *report) add(check string, err error) {
+	if err != nil {
+		r.Findings = append(r.Findings, finding{Check: check, Status: statusFail, Message: err.Error()})
+		return
+	}
+	r.Findings = append(r.Findings, finding{Check: check, Status: statusPass})
+}
+
+func (r *report) skip(check, reason string) {
+	r.Findings = append(r.Findings, finding{Check: check, Status: statusSkip, Message: reason})
+}
+
+// runValidate - runs the validate command
+func runValidate(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("f", "", "YAML file holding the ContainerStorageModule, and optionally its secrets")
+	configDir := fs.String("config-dir", defaultConfigDir, "operatorconfig directory holding the driver and module templates")
+	arrayConfig := fs.String("array-config", "", "array config file (JSON or YAML) of the driver secret, checked as the <name>-config secret")
+	installedVersion := fs.String("installed-version", "", "config version currently installed, to check the upgrade path from it")
+	output := fs.String("o", "json", "report format: json or junit")
+	verbose := fs.Bool("v", false, "print the operator logs to stderr")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("the ContainerStorageModule file is required (-f)")
+	}
+	if *output != "json" && *output != "junit" {
+		return fmt.Errorf("unknown report format %q, use json or junit", *output)
+	}
+
+	scheme := newScheme()
+	cr, objects, err := loadInput(*file, scheme)
+	if err != nil {
+		return err
+	}
+	if *arrayConfig != "" {
+		buf, err := os.ReadFile(filepath.Clean(*arrayConfig))
+		if err != nil {
+			return err
+		}
+		objects = append(objects, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: cr.Name + "-config", Namespace: cr.Namespace},
+			Data:       map[string][]byte{"config": buf},
+		})
+	}
+	op := operatorutils.OperatorConfig{ConfigDirectory: filepath.Clean(*configDir)}
+
+	r := validate(newContext(*verbose, stderr), scheme, *cr, objects, op, *installedVersion)
+	r.File = *file
+
+	if *output == "junit" {
+		err = writeJUnit(stdout, r)
+	} else {
+		err = writeJSON(stdout, r)
+	}
+	if err != nil {
+		return err
+	}
+	if !r.Valid {
+		return errInvalid
+	}
+	return nil
+}
+
+// validate - runs the checks of PreChecks that do not need a cluster
+func validate(ctx context.Context, scheme *runtime.Scheme, cr csmv1.ContainerStorageModule, objects []crclient.Object, op operatorutils.OperatorConfig, installedVersion string) *report {
+	r := &report{Name: cr.Name, Namespace: cr.Namespace}
+	isAuthServer := cr.HasModule(csmv1.AuthorizationServer)
+
+	// driver type
+	driverType := cr.Spec.Driver.CSIDriverType
+	switch driverType {
+	case csmv1.PowerScale, csmv1.PowerFlex, csmv1.PowerStore, csmv1.Unity, csmv1.PowerMax, csmv1.Cosi:
+		r.add("driverType", nil)
+	default:
+		if isAuthServer {
+			r.skip("driverType", "standalone authorization proxy server")
+		} else {
+			r.add("driverType", fmt.Errorf("unsupported driver type %s", driverType))
+		}
+	}
+
+	// spec.version to config version mapping
+	configVersion, err := operatorutils.GetVersion(ctx, &cr, op)
+	r.add("version", err)
+
+	// driver version support, through the upgrade path of the version
+	switch {
+	case isAuthServer:
+		r.skip("driverVersion", "standalone authorization proxy server")
+	case configVersion == "":
+		r.skip("driverVersion", "the config version is unknown")
+	default:
+		dirType := driverType
+		if dirType == csmv1.PowerScale {
+			dirType = csmv1.PowerScaleName
+		}
+		if _, err := drivers.GetUpgradeInfo(ctx, op, dirType, configVersion); err != nil {
+			r.add("driverVersion", fmt.Errorf("%s %s is not supported: %v", driverType, configVersion, err))
+		} else {
+			r.add("driverVersion", nil)
+		}
+
+		if installedVersion == "" {
+			r.skip("upgradePath", "no installed version given")
+		} else {
+			_, err := operatorutils.IsValidUpgrade(ctx, installedVersion, configVersion, dirType, op)
+			if err != nil && cr.Spec.MultiHopUpgrade {
+				// with spec.multiHopUpgrade, the operator goes through intermediate versions
+				if _, planErr := operatorutils.PlanUpgrade(ctx, installedVersion, configVersion, dirType, operatorutils.ModulesFollowingDriverVersion(cr), op); planErr == nil {
+					err = nil
+				}
+			}
+			r.add("upgradePath", err)
+		}
+	}
+
+	r.add("customRegistry", operatorutils.ValidateCustomRegistry(ctx, cr.Spec.CustomRegistry))
+
+	for _, m := range cr.Spec.Modules {
+		check := "module/" + string(m.Name)
+		if !m.Enabled {
+			r.skip(check, "module is disabled")
+			continue
+		}
+		r.add(check, validateModule(ctx, cr, m, op, configVersion))
+	}
+
+	// array secret
+	if driverType == csmv1.PowerFlex {
+		secretName := cr.Name + "-config"
+		ctrlClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		if _, err := operatorutils.GetSecret(ctx, secretName, cr.Namespace, ctrlClient); err != nil {
+			r.skip("arraySecret", fmt.Sprintf("secret %s is not in the input, use -array-config to check it", secretName))
+		} else {
+			r.add("arraySecret", drivers.ValidateZonesInSecret(ctx, ctrlClient, cr.Namespace, secretName))
+		}
+	}
+
+	r.Valid = true
+	for _, f := range r.Findings {
+		if f.Status == statusFail {
+			r.Valid = false
+		}
+	}
+	return r
+}
+
+// validateModule - checks that the module supports the driver and that its config version is shipped
+func validateModule(ctx context.Context, cr csmv1.ContainerStorageModule, m csmv1.Module, op operatorutils.OperatorConfig, configVersion string) error {
+	if m.Name == csmv1.AuthorizationServer {
+		// the proxy server follows the version of the CR
+		if configVersion == "" {
+			return fmt.Errorf("the config version is unknown")
+		}
+		return modules.CheckVersion(csmv1.Authorization, configVersion, op.ConfigDirectory)
+	}
+
+	supported, ok := modules.GetSupportedDrivers(m.Name)
+	if !ok {
+		return fmt.Errorf("unsupported module type %s", m.Name)
+	}
+	if _, ok := supported[string(cr.Spec.Driver.CSIDriverType)]; !ok {
+		return fmt.Errorf("CSM %s does not support %s driver", m.Name, cr.Spec.Driver.CSIDriverType)
+	}
+
+	if m.Name == csmv1.Authorization {
+		if _, err := modules.ValidateAuthorizationEnvs(m); err != nil {
+			return err
+		}
+	}
+
+	if m.ConfigVersion != "" {
+		return modules.CheckVersion(m.Name, m.ConfigVersion, op.ConfigDirectory)
+	}
+	if configVersion == "" {
+		return fmt.Errorf("the config version is unknown")
+	}
+	if _, err := operatorutils.GetModuleDefaultVersion(configVersion, cr.Spec.Driver.CSIDriverType, m.Name, op.ConfigDirectory); err != nil {
+		return fmt.Errorf("no %s version for %s %s: %v", m.Name, cr.Spec.Driver.CSIDriverType, configVersion, err)
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, r *report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// junitSuite - JUnit test suite, one test case per finding
+type junitSuite struct {
+	XMLName  xml.Name    `xml:"testsuite"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+func writeJUnit(w io.Writer, r *report) error {
+	suite := junitSuite{Name: fmt.Sprintf("csmctl validate %s", r.File), Tests: len(r.Findings)}
+	for _, f := range r.Findings {
+		c := junitCase{Name: f.Check, ClassName: fmt.Sprintf("%s.%s", r.Namespace, r.Name)}
+		switch f.Status {
+		case statusFail:
+			suite.Failures++
+			c.Failure = &junitMessage{Message: f.Message}
+		case statusSkip:
+			suite.Skipped++
+			c.Skipped = &junitMessage{Message: f.Message}
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
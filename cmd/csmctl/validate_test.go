@@ -0,0 +1,247 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testPowerFlexSample = "../../samples/v2.17.0/storage_csm_powerflex_v2170.yaml"
+
+func findingOf(t *testing.T, r *report, check string) finding {
+	for _, f := range r.Findings {
+		if f.Check == check {
+			return f
+		}
+	}
+	t.Fatalf("no finding for %s in %+v", check, r.Findings)
+	return finding{}
+}
+
+func enableModule(cr *csmv1.ContainerStorageModule, name csmv1.ModuleType) {
+	for i := range cr.Spec.Modules {
+		if cr.Spec.Modules[i].Name == name {
+			cr.Spec.Modules[i].Enabled = true
+			return
+		}
+	}
+	cr.Spec.Modules = append(cr.Spec.Modules, csmv1.Module{Name: name, Enabled: true})
+}
+
+func arraySecret(cr csmv1.ContainerStorageModule, config string) crclient.Object {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: cr.Name + "-config", Namespace: cr.Namespace},
+		Data:       map[string][]byte{"config": []byte(config)},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	scheme := newScheme()
+	op := operatorutils.OperatorConfig{ConfigDirectory: testConfigDir}
+	sample, _, err := loadInput(testPowerFlexSample, scheme)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		modify           func(cr *csmv1.ContainerStorageModule) []crclient.Object
+		installedVersion string
+		check            string
+		wantStatus       string
+		wantMessage      string
+	}{
+		{
+			name:       "valid sample",
+			modify:     func(_ *csmv1.ContainerStorageModule) []crclient.Object { return nil },
+			check:      "driverVersion",
+			wantStatus: statusPass,
+		},
+		{
+			name: "unsupported driver type",
+			modify: func(cr *csmv1.ContainerStorageModule) []crclient.Object {
+				cr.Spec.Driver.CSIDriverType = "ecs"
+				return nil
+			},
+			check:       "driverType",
+			wantStatus:  statusFail,
+			wantMessage: "unsupported driver type ecs",
+		},
+		{
+			name: "unknown CSM version",
+			modify: func(cr *csmv1.ContainerStorageModule) []crclient.Object {
+				cr.Spec.Version = "v0.0.1"
+				return nil
+			},
+			check:       "version",
+			wantStatus:  statusFail,
+			wantMessage: "No custom resource configuration is available for CSM version v0.0.1",
+		},
+		{
+			name: "unsupported driver version",
+			modify: func(cr *csmv1.ContainerStorageModule) []crclient.Object {
+				cr.Spec.Version = ""
+				cr.Spec.Driver.ConfigVersion = "v0.0.1"
+				return nil
+			},
+			check:       "driverVersion",
+			wantStatus:  statusFail,
+			wantMessage: "powerflex v0.0.1 is not supported",
+		},
+		{
+			name:             "invalid upgrade path",
+			modify:           func(_ *csmv1.ContainerStorageModule) []crclient.Object { return nil },
+			installedVersion: "v2.0.0",
+			check:            "upgradePath",
+			wantStatus:       statusFail,
+			wantMessage:      "not valid",
+		},
+		{
+			name: "invalid custom registry",
+			modify: func(cr *csmv1.ContainerStorageModule) []crclient.Object {
+				cr.Spec.CustomRegistry = "http://[::1"
+				return nil
+			},
+			check:      "customRegistry",
+			wantStatus: statusFail,
+		},
+		{
+			name: "module not supported by the driver",
+			modify: func(cr *csmv1.ContainerStorageModule) []crclient.Object {
+				cr.Spec.Driver.CSIDriverType = csmv1.Unity
+				enableModule(cr, csmv1.VgSnapShotter)
+				return nil
+			},
+			check:       "module/vgsnapshotter",
+			wantStatus:  statusFail,
+			wantMessage: "does not support unity driver",
+		},
+		{
+			name: "module config version not shipped",
+			modify: func(cr *csmv1.ContainerStorageModule) []crclient.Object {
+				enableModule(cr, csmv1.Resiliency)
+				for i := range cr.Spec.Modules {
+					if cr.Spec.Modules[i].Name == csmv1.Resiliency {
+						cr.Spec.Modules[i].ConfigVersion = "v0.0.1"
+					}
+				}
+				return nil
+			},
+			check:       "module/resiliency",
+			wantStatus:  statusFail,
+			wantMessage: "does not have v0.0.1 version",
+		},
+		{
+			name: "supported module",
+			modify: func(cr *csmv1.ContainerStorageModule) []crclient.Object {
+				enableModule(cr, csmv1.Resiliency)
+				return nil
+			},
+			check:      "module/resiliency",
+			wantStatus: statusPass,
+		},
+		{
+			name: "array secret with consistent zones",
+			modify: func(cr *csmv1.ContainerStorageModule) []crclient.Object {
+				return []crclient.Object{arraySecret(*cr, `[{"systemID":"1a","zone":{"name":"zoneA","labelKey":"zone.csi-vxflexos.dellemc.com"}},{"systemID":"2b","zone":{"name":"zoneB","labelKey":"zone.csi-vxflexos.dellemc.com"}}]`)}
+			},
+			check:      "arraySecret",
+			wantStatus: statusPass,
+		},
+		{
+			name: "array secret with partial zones",
+			modify: func(cr *csmv1.ContainerStorageModule) []crclient.Object {
+				return []crclient.Object{arraySecret(*cr, "- systemID: 1a\n  zone:\n    name: zoneA\n    labelKey: zone.csi-vxflexos.dellemc.com\n- systemID: 2b\n")}
+			},
+			check:       "arraySecret",
+			wantStatus:  statusFail,
+			wantMessage: "not all arrays have zoning configured",
+		},
+		{
+			name:        "array secret missing",
+			modify:      func(_ *csmv1.ContainerStorageModule) []crclient.Object { return nil },
+			check:       "arraySecret",
+			wantStatus:  statusSkip,
+			wantMessage: "-array-config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := sample.DeepCopy()
+			objects := tt.modify(cr)
+			r := validate(context.Background(), scheme, *cr, objects, op, tt.installedVersion)
+
+			f := findingOf(t, r, tt.check)
+			assert.Equal(t, tt.wantStatus, f.Status, f.Message)
+			assert.Contains(t, f.Message, tt.wantMessage)
+			if tt.wantStatus == statusFail {
+				assert.False(t, r.Valid)
+			}
+		})
+	}
+}
+
+func TestRunValidate(t *testing.T) {
+	dir := t.TempDir()
+	badZones := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(badZones, []byte(`[{"systemID":"1a","zone":{"name":"zoneA"}}]`), 0o600))
+
+	t.Run("json report of a valid CR", func(t *testing.T) {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		code := run([]string{"validate", "-f", testPowerFlexSample, "--config-dir", testConfigDir}, stdout, stderr)
+		require.Equal(t, 0, code, stderr.String())
+
+		r := report{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &r))
+		assert.True(t, r.Valid)
+		assert.Equal(t, "vxflexos", r.Name)
+		assert.NotEmpty(t, r.Findings)
+	})
+
+	t.Run("junit report of an invalid array config", func(t *testing.T) {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		code := run([]string{"validate", "-f", testPowerFlexSample, "--config-dir", testConfigDir, "--array-config", badZones, "-o", "junit"}, stdout, stderr)
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr.String(), errInvalid.Error())
+
+		suite := junitSuite{}
+		require.NoError(t, xml.Unmarshal(stdout.Bytes(), &suite))
+		assert.Equal(t, 1, suite.Failures)
+		assert.Equal(t, len(suite.Cases), suite.Tests)
+		for _, c := range suite.Cases {
+			if c.Name == "arraySecret" {
+				require.NotNil(t, c.Failure)
+				assert.Contains(t, c.Failure.Message, "zone LabelKey is empty")
+			}
+		}
+	})
+
+	t.Run("unknown report format", func(t *testing.T) {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		assert.Equal(t, 1, run([]string{"validate", "-f", testPowerFlexSample, "-o", "sarif"}, stdout, stderr))
+		assert.Contains(t, stderr.String(), `unknown report format "sarif"`)
+	})
+}
@@ -17,7 +17,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -35,31 +35,44 @@ import (
 	"github.com/dell/csm-operator/pkg/constants"
 	"github.com/dell/csm-operator/pkg/logger"
 	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/dell/csm-operator/pkg/resources/adoption"
+	"github.com/dell/csm-operator/pkg/resources/clusterclass"
 	"github.com/dell/csm-operator/pkg/resources/configmap"
+	"github.com/dell/csm-operator/pkg/resources/contenthash"
 	"github.com/dell/csm-operator/pkg/resources/csidriver"
 	"github.com/dell/csm-operator/pkg/resources/daemonset"
 	"github.com/dell/csm-operator/pkg/resources/deployment"
 	"github.com/dell/csm-operator/pkg/resources/rbac"
 	"github.com/dell/csm-operator/pkg/resources/serviceaccount"
+	"github.com/dell/csm-operator/pkg/templatebundle"
 	"go.uber.org/zap"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	t1 "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	sinformer "k8s.io/client-go/informers"
+	acorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	// metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -69,23 +82,25 @@ type ContainerStorageModuleReconciler struct {
 	client.Client
 	// k8s client, implements client-go/kubernetes interface, responsible for apply, which
 	// client.Client does not provides
-	K8sClient            kubernetes.Interface
-	Scheme               *runtime.Scheme
-	Log                  *zap.SugaredLogger
-	Config               operatorutils.OperatorConfig
-	updateCount          int32
-	trcID                string
-	EventRecorder        record.EventRecorder
-	ContentWatchChannels map[string]chan struct{}
-	ContentWatchLock     sync.Mutex
+	K8sClient     kubernetes.Interface
+	Scheme        *runtime.Scheme
+	Log           *zap.SugaredLogger
+	Config        operatorutils.OperatorConfig
+	updateCount   int32
+	EventRecorder record.EventRecorder
+	// nodeRollouts holds the NodeRolloutStatus of the node DaemonSet synced on each cluster, until the reconcile reports it
+	nodeRollouts sync.Map
+	// TemplateBundleEvents receives the CSMs whose templates a template bundle changed, to reconcile them
+	TemplateBundleEvents chan event.GenericEvent
 }
 
 // DriverConfig  -
 type DriverConfig struct {
-	Driver     *storagev1.CSIDriver
-	ConfigMap  *corev1.ConfigMap
-	Node       *operatorutils.NodeYAML
-	Controller *operatorutils.ControllerYAML
+	Driver          *storagev1.CSIDriver
+	ConfigMap       *corev1.ConfigMap
+	Node            *operatorutils.NodeYAML
+	Controller      *operatorutils.ControllerYAML
+	SnapshotClasses []*unstructured.Unstructured
 }
 
 const (
@@ -103,11 +118,24 @@ const (
 
 	// RefreshEnvVar - environment variable name for watcher timed refreshes
 	RefreshEnvVar = "REFRESH_INTERVAL_MINUTES"
+
+	// maxRollbackDiffEntries - number of changed fields listed in the rollback event
+	maxRollbackDiffEntries = 10
+
+	// nodeRolloutPollInterval - how often the batches of a node rollout are checked
+	nodeRolloutPollInterval = 10 * time.Second
+
+	// remoteClusterStatusInterval - how often the status of the pods on the target clusters, which are not watched, is refreshed
+	remoteClusterStatusInterval = 5 * time.Minute
+
+	// upgradeHopPollInterval - how often a multi-hop upgrade checks whether the hop being installed has succeeded
+	upgradeHopPollInterval = 30 * time.Second
+
+	// statusControllerName - name of the controller that refreshes the status of a CSM on the events of its components
+	statusControllerName = "containerstoragemodule-status"
 )
 
 var (
-	dMutex                          sync.RWMutex
-	configVersionKey                = fmt.Sprintf("%s/%s", MetadataPrefix, "CSMOperatorConfigVersion")
 	previouslyAppliedCustomResource = fmt.Sprintf("%s/%s", MetadataPrefix, "PreviouslyAppliedConfiguration")
 
 	// CSMVersionKey -
@@ -127,7 +155,7 @@ var (
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims/status,verbs=update;patch;get
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=create;update;get;list;watch
 // +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch;create;delete;patch;update
-// +kubebuilder:rbac:groups="apps",resources=deployments;daemonsets;replicasets;statefulsets,verbs=get;list;watch;update;create;delete;patch
+// +kubebuilder:rbac:groups="apps",resources=deployments;daemonsets;replicasets;statefulsets;controllerrevisions,verbs=get;list;watch;update;create;delete;patch
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles;clusterrolebindings;replicasets;rolebindings,verbs=get;list;watch;update;create;delete;patch
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles/finalizers,verbs=get;list;watch;update;create;delete;patch
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=subjectaccessreviews,verbs=create
@@ -227,9 +255,11 @@ var (
 // +kubebuilder:rbac:groups=objectstorage.k8s.io,resources=buckets,verbs=create;get;update;delete;list;watch
 // +kubebuilder:rbac:groups=objectstorage.k8s.io,resources=buckets/status,verbs=create;get;update;delete;list;watch
 
-// +kubebuilder:rbac:groups="groupsnapshot.storage.k8s.io",resources=volumegroupsnapshotclasses,verbs=get;list;watch
-// +kubebuilder:rbac:groups="groupsnapshot.storage.k8s.io",resources=volumegroupsnapshotcontents,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="groupsnapshot.storage.k8s.io",resources=volumegroupsnapshotclasses,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="groupsnapshot.storage.k8s.io",resources=volumegroupsnapshotcontents,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="groupsnapshot.storage.k8s.io",resources=volumegroupsnapshotcontents/status,verbs=update;patch
+// +kubebuilder:rbac:groups="groupsnapshot.storage.k8s.io",resources=volumegroupsnapshots,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="groupsnapshot.storage.k8s.io",resources=volumegroupsnapshots/status,verbs=update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -244,8 +274,8 @@ var (
 // Reconcile - main loop
 func (r *ContainerStorageModuleReconciler) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.IncrUpdateCount()
-	r.trcID = fmt.Sprintf("%d", r.GetUpdateCount())
-	name := req.Name + "-" + r.trcID
+	trcID := fmt.Sprintf("%d", r.GetUpdateCount())
+	name := req.Name + "-" + trcID
 	ctx, log := logger.GetNewContextWithLogger(name)
 	unitTestRun := operatorutils.DetermineUnitTestRun(ctx)
 
@@ -270,39 +300,47 @@ func (r *ContainerStorageModuleReconciler) Reconcile(_ context.Context, req ctrl
 		IsOpenShift:     r.Config.IsOpenShift,
 		K8sVersion:      r.Config.K8sVersion,
 		ConfigDirectory: r.Config.ConfigDirectory,
+		OperatorVersion: r.Config.OperatorVersion,
 	}
 
-	// Set default value for forceRemoveDriver to true if not specified by the user
-	if csm.Spec.Driver.ForceRemoveDriver == nil {
-		truebool := true
-		csm.Spec.Driver.ForceRemoveDriver = &truebool
+	// a paused CSM keeps reporting status, but nothing is applied or deleted
+	if csm.IsPaused() {
+		return r.handlePaused(ctx, csm, *operatorConfig)
+	}
+	if meta.IsStatusConditionTrue(csm.Status.Conditions, csmv1.ConditionPaused) {
+		// resuming is reported right away, whether or not the prechecks that follow pass
+		operatorutils.SetCondition(csm, csmv1.ConditionPaused, metav1.ConditionFalse, csmv1.ReasonReconcileResumed, "")
+		r.EventRecorder.Event(csm, corev1.EventTypeNormal, csmv1.EventResumed, "Reconcile is resumed")
+		if err := operatorutils.UpdateCSMStatus(ctx, csm, r.GetClient()); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
-	// Set default components if using miminal manifest (without components)
-	err = operatorutils.LoadDefaultComponents(ctx, csm, *operatorConfig)
-	if err != nil {
-		return ctrl.Result{}, err
+	// spec.rollbackTo replaces the spec, which is applied by the reconcile that follows the update
+	if csm.Spec.RollbackTo != nil && !csm.IsBeingDeleted() {
+		return ctrl.Result{}, r.handleRollbackTo(ctx, csm)
 	}
 
-	for i, m := range csm.Spec.Modules {
-		if m.Name == csmv1.AuthorizationServer {
-			authVersion, err := operatorutils.GetVersion(ctx, csm, *operatorConfig)
-			if err != nil {
-				return ctrl.Result{}, err
-			}
-			csm.Spec.Modules[i].ConfigVersion = authVersion
-			break
-		}
+	err = SetCSMDefaults(ctx, csm, *operatorConfig)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
 	// perform prechecks
+	upgradePath := csm.Status.UpgradePath
 	err = r.PreChecks(ctx, csm, *operatorConfig)
 	if err != nil {
 		csm.GetCSMStatus().State = constants.InvalidConfig
 		r.EventRecorder.Event(csm, corev1.EventTypeWarning, csmv1.EventUpdated, fmt.Sprintf("Failed Prechecks: %s", err))
 		return operatorutils.HandleValidationError(ctx, csm, r, err)
 	}
-
+	operatorutils.SetCondition(csm, csmv1.ConditionPrecheckPassed, metav1.ConditionTrue, csmv1.ReasonPrecheckSucceeded, "")
+	if !slices.Equal(upgradePath, csm.Status.UpgradePath) {
+		// the hops of a multi-hop upgrade are only moved on from once recorded
+		if err := operatorutils.UpdateCSMStatus(ctx, csm, r.GetClient()); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 	if csm.IsBeingDeleted() {
 		log.Infow("Delete request", "csm", req.Namespace, "Name", req.Name)
 
@@ -338,14 +376,8 @@ func (r *ContainerStorageModuleReconciler) Reconcile(_ context.Context, req ctrl
 			log.Errorw("remove driver finalizer", "error", err.Error())
 			return ctrl.Result{}, fmt.Errorf("error when handling finalizer: %v", err)
 		}
-
-		// stop this CSM's informers
-		r.ContentWatchLock.Lock()
-		if stopCh, ok := r.ContentWatchChannels[csm.Name]; ok {
-			close(stopCh)
-			delete(r.ContentWatchChannels, csm.Name)
-		}
-		r.ContentWatchLock.Unlock()
+		// the objects owned by the CSM are garbage collected
+		contenthash.ForgetOwned(csm.UID)
 
 		r.EventRecorder.Event(csm, corev1.EventTypeNormal, csmv1.EventDeleted, "Object finalizer is deleted")
 		return ctrl.Result{}, nil
@@ -374,11 +406,52 @@ func (r *ContainerStorageModuleReconciler) Reconcile(_ context.Context, req ctrl
 		}
 	}
 
+	// with spec.rollback, a change that does not succeed in time is replaced by the last successful configuration
+	csm, err = r.handleRollback(ctx, csm)
+	if err != nil {
+		log.Error(err, "Failed to handle the rollback policy")
+		return reconcile.Result{}, err
+	}
+	if csm.IsRolledBack() {
+		// the last successful configuration may no longer be valid, e.g. when a secret it uses was removed since
+		if err = SetCSMDefaults(ctx, csm, *operatorConfig); err == nil {
+			err = r.PreChecks(ctx, csm, *operatorConfig)
+		}
+		if err != nil {
+			csm.GetCSMStatus().State = constants.InvalidConfig
+			r.EventRecorder.Event(csm, corev1.EventTypeWarning, csmv1.EventRolledBack, fmt.Sprintf("Failed Prechecks of the last successful configuration: %s", err))
+			return operatorutils.HandleValidationError(ctx, csm, r, err)
+		}
+	}
+
 	newStatus := csm.GetCSMStatus()
 	requeue := operatorutils.HandleSuccess(ctx, csm, r, newStatus, oldStatus, *operatorConfig)
 
 	// Update the driver
 	syncErr := r.SyncCSM(ctx, *csm, *operatorConfig, r.Client)
+	if syncErr == nil {
+		if err := r.syncAppliedClusters(ctx, csm, *operatorConfig); err != nil {
+			log.Errorw("Failed to clean up the target clusters removed from the spec", "error", err.Error())
+		}
+	}
+	if syncErr == nil && !csm.IsRolledBack() && getUpgradeHop(csm) == "" {
+		if err := operatorutils.RecordRevision(ctx, csm, operatorConfig.OperatorVersion, r.Client); err != nil {
+			log.Errorw("Failed to record the revision", "error", err.Error())
+		}
+	}
+	var requeueAfter time.Duration
+	if syncErr == nil {
+		if requeueAfter, err = r.handleNodeRollout(ctx, csm); err != nil {
+			log.Errorw("Failed to report the node rollout", "error", err.Error())
+		}
+	}
+	if syncErr == nil && len(csm.Spec.TargetClusters) > 0 && (requeueAfter == 0 || requeueAfter > remoteClusterStatusInterval) {
+		requeueAfter = remoteClusterStatusInterval
+	}
+	// the component events only refresh the status, the next hop is installed by a later reconcile
+	if syncErr == nil && getUpgradeHop(csm) != "" && (requeueAfter == 0 || requeueAfter > upgradeHopPollInterval) {
+		requeueAfter = upgradeHopPollInterval
+	}
 	if syncErr == nil && !requeue.Requeue {
 		err = operatorutils.UpdateStatus(ctx, csm, r, newStatus, *operatorConfig)
 		if err != nil && !unitTestRun {
@@ -387,23 +460,22 @@ func (r *ContainerStorageModuleReconciler) Reconcile(_ context.Context, req ctrl
 			return reconcile.Result{Requeue: true}, err
 		}
 
-		// start content watch for this CSM
-		stop, err := r.ContentWatch(csm)
-		if err != nil {
-			log.Errorf("starting content watch for %s: %v", csm.Name, err)
-			return reconcile.Result{Requeue: true}, err
-		}
-
-		r.ContentWatchLock.Lock()
-		if stopCh, ok := r.ContentWatchChannels[csm.Name]; ok {
-			close(stopCh)
+		if err == nil && !isUpdated {
+			advanced, err := r.advanceUpgradeHop(ctx, csm)
+			if err != nil {
+				log.Error(err, "Failed to move on to the next hop of the upgrade")
+				operatorutils.LogEndReconcile()
+				return reconcile.Result{Requeue: true}, err
+			}
+			if advanced {
+				operatorutils.LogEndReconcile()
+				return reconcile.Result{Requeue: true}, nil
+			}
 		}
-		r.ContentWatchChannels[csm.Name] = stop
-		r.ContentWatchLock.Unlock()
 
 		r.EventRecorder.Eventf(csm, corev1.EventTypeNormal, csmv1.EventCompleted, "install/update storage component: %s completed OK", csm.Name)
 		operatorutils.LogEndReconcile()
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	// syncErr can be nil, even if CSM state = failed
@@ -421,8 +493,10 @@ func (r *ContainerStorageModuleReconciler) Reconcile(_ context.Context, req ctrl
 func (r *ContainerStorageModuleReconciler) ignoreUpdatePredicate() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			// Ignore updates to status in which case metadata.Generation does not change
-			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+			// Ignore updates to status in which case metadata.Generation does not change,
+			// but not to the paused annotation which does not change it either
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+				e.ObjectOld.GetAnnotations()[csmv1.PausedAnnotation] != e.ObjectNew.GetAnnotations()[csmv1.PausedAnnotation]
 		},
 
 		DeleteFunc: func(e event.DeleteEvent) bool {
@@ -432,258 +506,371 @@ func (r *ContainerStorageModuleReconciler) ignoreUpdatePredicate() predicate.Pre
 	}
 }
 
-func (r *ContainerStorageModuleReconciler) handleDeploymentUpdate(oldObj interface{}, obj interface{}) {
-	dMutex.Lock()
-	defer dMutex.Unlock()
-
-	old, _ := oldObj.(*appsv1.Deployment)
-	d, _ := obj.(*appsv1.Deployment)
-	name := d.Spec.Template.Labels[constants.CsmLabel]
-	key := name + "-" + fmt.Sprintf("%d", r.GetUpdateCount())
-	ctx, log := logger.GetNewContextWithLogger(key)
+// SetCSMDefaults - sets the defaults that are applied to a CSM before it is checked and synced
+func SetCSMDefaults(ctx context.Context, csm *csmv1.ContainerStorageModule, operatorConfig operatorutils.OperatorConfig) error {
+	// Set default value for forceRemoveDriver to true if not specified by the user
+	if csm.Spec.Driver.ForceRemoveDriver == nil {
+		truebool := true
+		csm.Spec.Driver.ForceRemoveDriver = &truebool
+	}
 
-	log.Debugw("deployment modified generation", d.Name, d.Generation, old.Generation)
+	// Set default components if using miminal manifest (without components)
+	err := operatorutils.LoadDefaultComponents(ctx, csm, operatorConfig)
+	if err != nil {
+		return err
+	}
 
-	desired := d.Status.Replicas
-	available := d.Status.AvailableReplicas
-	ready := d.Status.ReadyReplicas
-	numberUnavailable := d.Status.UnavailableReplicas
+	for i, m := range csm.Spec.Modules {
+		if m.Name == csmv1.AuthorizationServer {
+			authVersion, err := operatorutils.GetVersion(ctx, csm, operatorConfig)
+			if err != nil {
+				return err
+			}
+			csm.Spec.Modules[i].ConfigVersion = authVersion
+			break
+		}
+	}
+	return nil
+}
 
-	// Replicas:               2 desired | 2 updated | 2 total | 2 available | 0 unavailable
+// handlePaused - reports the status of a paused CSM without applying or deleting anything
+func (r *ContainerStorageModuleReconciler) handlePaused(ctx context.Context, csm *csmv1.ContainerStorageModule, operatorConfig operatorutils.OperatorConfig) (reconcile.Result, error) {
+	log := logger.GetLogger(ctx)
+	log.Infow("Reconcile is paused", "Namespace", csm.Namespace, "Name", csm.Name)
 
-	log.Infow("deployment", "deployment name", d.Name, "desired", desired)
-	log.Infow("deployment", "deployment name", d.Name, "numberReady", ready)
-	log.Infow("deployment", "deployment name", d.Name, "available", available)
-	log.Infow("deployment", "deployment name", d.Name, "numberUnavailable", numberUnavailable)
+	paused := meta.FindStatusCondition(csm.Status.Conditions, csmv1.ConditionPaused)
+	if paused == nil || paused.Status != metav1.ConditionTrue {
+		r.EventRecorder.Event(csm, corev1.EventTypeNormal, csmv1.EventPaused, "Reconcile is paused, changes are not applied until it is resumed")
+	}
+	message := fmt.Sprintf("reconcile is paused by spec.paused or the %s annotation", csmv1.PausedAnnotation)
+	if csm.IsBeingDeleted() {
+		// the finalizer is only removed by the deletion path, so the CSM is not deleted until reconcile is resumed
+		message += ", deletion waits until it is resumed"
+		if paused == nil || paused.Message != message {
+			r.EventRecorder.Event(csm, corev1.EventTypeWarning, csmv1.EventDeleted, "Reconcile is paused, the CSM is deleted once it is resumed")
+		}
+	}
+	operatorutils.SetCondition(csm, csmv1.ConditionPaused, metav1.ConditionTrue, csmv1.ReasonReconcilePaused, message)
 
-	ns := d.Spec.Template.Labels[constants.CsmNamespaceLabel]
+	// an error only means the pods are not running, which the status already reports
+	if err := operatorutils.UpdateStatus(ctx, csm, r, csm.GetCSMStatus(), operatorConfig); err != nil {
+		log.Infow("Paused CSM status", "error", err.Error())
+	}
 
-	if ns != "" {
-		log.Debugw("csm being modified in handledeployment", "namespace", ns, "name", name)
-		namespacedName := t1.NamespacedName{
-			Name:      name,
-			Namespace: ns,
-		}
+	operatorutils.LogEndReconcile()
+	return reconcile.Result{}, nil
+}
 
-		csm := new(csmv1.ContainerStorageModule)
-		err := r.Client.Get(ctx, namespacedName, csm)
+// handleRollback - tracks the rollout of each generation of the spec when spec.rollback is enabled, and returns a copy of
+// the CSM with the last successful spec once a rollout did not succeed within the timeout
+func (r *ContainerStorageModuleReconciler) handleRollback(ctx context.Context, csm *csmv1.ContainerStorageModule) (*csmv1.ContainerStorageModule, error) {
+	log := logger.GetLogger(ctx)
+	if csm.Spec.Rollback == nil || !csm.Spec.Rollback.Enabled {
+		return csm, nil
+	}
+
+	status := csm.GetCSMStatus()
+	timeout := csm.Spec.Rollback.GetTimeout()
+	switch {
+	case status.RolloutGeneration != csm.Generation:
+		// a new generation of the spec starts a new rollout
+		now := metav1.Now()
+		status.RolloutGeneration = csm.Generation
+		status.RolloutStartTime = &now
+		if meta.FindStatusCondition(status.Conditions, csmv1.ConditionRolledBack) != nil {
+			operatorutils.SetCondition(csm, csmv1.ConditionRolledBack, metav1.ConditionFalse, csmv1.ReasonRolloutStarted, fmt.Sprintf("applying generation %d", csm.Generation))
+		}
+		return csm, operatorutils.UpdateCSMStatus(ctx, csm, r.GetClient())
+	case csm.IsRolledBack():
+		// the last successful configuration is applied until the spec changes again
+	case status.RolloutStartTime == nil:
+		return csm, nil
+	case status.State == constants.Succeeded && status.ObservedGeneration == csm.Generation:
+		status.RolloutStartTime = nil
+		return csm, operatorutils.UpdateCSMStatus(ctx, csm, r.GetClient())
+	case status.LastSuccessfulConfiguration == "" || time.Since(status.RolloutStartTime.Time) < timeout:
+		return csm, nil
+	}
+
+	lastCR := new(csmv1.ContainerStorageModule)
+	if err := json.Unmarshal([]byte(status.LastSuccessfulConfiguration), lastCR); err != nil {
+		return csm, fmt.Errorf("error unmarshalling the last successful configuration: %v", err)
+	}
+
+	if !csm.IsRolledBack() {
+		diff, err := operatorutils.DiffSpecs(lastCR.Spec, csm.Spec)
 		if err != nil {
-			log.Error("deployment get csm", "error", err.Error())
+			return csm, err
+		}
+		if len(diff) == 0 {
+			log.Infow("Rollout timed out, but the spec is the last successful configuration", "generation", csm.Generation)
+			return csm, nil
 		}
 
-		newStatus := csm.GetCSMStatus()
+		message := fmt.Sprintf("generation %d did not succeed within %s, the last successful configuration is applied instead", csm.Generation, timeout)
+		status.RolledBackGeneration = csm.Generation
+		status.RolloutStartTime = nil
+		operatorutils.SetCondition(csm, csmv1.ConditionRolledBack, metav1.ConditionTrue, csmv1.ReasonRolloutTimedOut, message)
+		if err := operatorutils.UpdateCSMStatus(ctx, csm, r.GetClient()); err != nil {
+			return csm, err
+		}
 
-		// Pass deployment status from the event object directly to avoid stale informer cache reads
-		deploymentStatus := csmv1.PodStatus{
-			Available: strconv.Itoa(int(available)),
-			Desired:   strconv.Itoa(int(desired)),
-			Failed:    strconv.Itoa(int(numberUnavailable)),
+		if len(diff) > maxRollbackDiffEntries {
+			diff = append(diff[:maxRollbackDiffEntries], fmt.Sprintf("and %d more", len(diff)-maxRollbackDiffEntries))
 		}
+		r.EventRecorder.Eventf(csm, corev1.EventTypeWarning, csmv1.EventRolledBack, "%s, reverted: %s", message, strings.Join(diff, "; "))
+		log.Infow("Rolled back to the last successful configuration", "generation", csm.Generation, "diff", diff)
+	}
 
-		err = operatorutils.UpdateStatus(ctx, csm, r, newStatus, r.Config, deploymentStatus)
-		if err != nil {
-			log.Debugw("deployment status ", "pods", err.Error())
-		} else {
-			r.EventRecorder.Eventf(csm, corev1.EventTypeNormal, csmv1.EventCompleted, "Driver deployment running OK")
+	rollbackCR := csm.DeepCopy()
+	rollbackCR.Spec = lastCR.Spec
+	// the prechecks of the rollback compare its version with the one of the last successful configuration
+	if lastVersion, ok := lastCR.GetAnnotations()[operatorutils.ConfigVersionAnnotation]; ok {
+		annotations := rollbackCR.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
 		}
+		annotations[operatorutils.ConfigVersionAnnotation] = lastVersion
+		rollbackCR.SetAnnotations(annotations)
 	}
+	return rollbackCR, nil
 }
 
-func (r *ContainerStorageModuleReconciler) handlePodsUpdate(_ interface{}, obj interface{}) {
-	dMutex.Lock()
-	defer dMutex.Unlock()
+// handleRollbackTo - replaces the spec of the CSM with the one of the revision requested by spec.rollbackTo
+func (r *ContainerStorageModuleReconciler) handleRollbackTo(ctx context.Context, csm *csmv1.ContainerStorageModule) error {
+	log := logger.GetLogger(ctx)
+	revision := *csm.Spec.RollbackTo
+
+	spec, err := operatorutils.GetRevisionSpec(ctx, *csm, revision, r.GetClient())
+	if err == nil {
+		current := csm.Spec
+		csm.Spec = *spec
+		csm.Spec.RollbackTo = nil
+		if err = r.GetClient().Update(ctx, csm); err == nil {
+			log.Infow("Restored the spec", "revision", revision)
+			r.EventRecorder.Eventf(csm, corev1.EventTypeNormal, csmv1.EventRevisionRestored, "Spec restored from revision %d", revision)
+			return nil
+		}
+		csm.Spec = current
+		if k8serror.IsConflict(err) {
+			return err
+		}
+	}
 
-	p, _ := obj.(*corev1.Pod)
-	name := p.GetLabels()[constants.CsmLabel]
-	// if this pod is an obs. pod, namespace might not match csm namespace
-	ns := p.GetLabels()[constants.CsmNamespaceLabel]
+	// the request is dropped, so that it is not retried on every reconcile
+	r.EventRecorder.Eventf(csm, corev1.EventTypeWarning, csmv1.EventRevisionRestored, "Failed to restore revision %d: %s", revision, err)
+	csm.Spec.RollbackTo = nil
+	return r.GetClient().Update(ctx, csm)
+}
 
-	if ns != "" {
-		key := name + "-" + fmt.Sprintf("%d", r.GetUpdateCount())
-		ctx, log := logger.GetNewContextWithLogger(key)
+// nodeRolloutKey - key of the node rollout of the CSM on a cluster in nodeRollouts
+func nodeRolloutKey(cr csmv1.ContainerStorageModule, clusterID string) string {
+	return fmt.Sprintf("%s/%s/%s", cr.Namespace, cr.Name, clusterID)
+}
 
-		if !p.ObjectMeta.DeletionTimestamp.IsZero() {
-			log.Debugw("driver delete invoked", "stopping pod with name", p.Name)
-			return
+// handleNodeRollout - reports the node rollouts of the last sync in the NodeRolloutPaused condition, and returns
+// the interval after which the CSM is reconciled again to move on to the next batch of nodes
+func (r *ContainerStorageModuleReconciler) handleNodeRollout(ctx context.Context, csm *csmv1.ContainerStorageModule) (time.Duration, error) {
+	previous := meta.FindStatusCondition(csm.Status.Conditions, csmv1.ConditionNodeRolloutPaused)
+	if !csm.IsNodeRolloutEnabled() {
+		if previous == nil {
+			return 0, nil
 		}
-		log.Infow("pod modified for driver", "name", p.Name)
+		meta.RemoveStatusCondition(&csm.Status.Conditions, csmv1.ConditionNodeRolloutPaused)
+		return 0, operatorutils.UpdateCSMStatus(ctx, csm, r.GetClient())
+	}
+	if previous != nil {
+		previous = previous.DeepCopy()
+	}
 
-		namespacedName := t1.NamespacedName{
-			Name:      name,
-			Namespace: ns,
+	paused, progressing := []string{}, []string{}
+	for _, clusterID := range append([]string{operatorutils.DefaultSourceClusterID}, csm.Spec.TargetClusters...) {
+		value, ok := r.nodeRollouts.LoadAndDelete(nodeRolloutKey(*csm, clusterID))
+		if !ok {
+			continue
 		}
-		csm := new(csmv1.ContainerStorageModule)
-		err := r.Client.Get(ctx, namespacedName, csm)
-		if err != nil {
-			r.Log.Errorw("daemonset get csm", "error", err.Error())
+		rollout := value.(daemonset.NodeRolloutStatus)
+		message := rollout.Message()
+		if len(csm.Spec.TargetClusters) > 0 {
+			message = clusterID + ": " + message
 		}
-		log.Infow("csm prev status ", "state", csm.Status)
-		newStatus := csm.GetCSMStatus()
-
-		err = operatorutils.UpdateStatus(ctx, csm, r, newStatus, r.Config)
-		state := csm.GetCSMStatus().State
-		stamp := fmt.Sprintf("at %d", time.Now().UnixNano())
-		if state != "0" && err != nil {
-			log.Infow("pod status ", "state", err.Error())
-			r.EventRecorder.Eventf(csm, corev1.EventTypeWarning, csmv1.EventUpdated, "%s Pod error details %s", stamp, err.Error())
-		} else {
-			r.EventRecorder.Eventf(csm, corev1.EventTypeNormal, csmv1.EventCompleted, "%s Driver pods running OK", stamp)
+		if rollout.Paused {
+			paused = append(paused, message)
+		} else if rollout.InProgress {
+			progressing = append(progressing, message)
 		}
 	}
-}
 
-func (r *ContainerStorageModuleReconciler) handleDaemonsetUpdate(oldObj interface{}, obj interface{}) {
-	dMutex.Lock()
-	defer dMutex.Unlock()
+	var requeueAfter time.Duration
+	switch {
+	case len(paused) > 0:
+		message := strings.Join(paused, "; ")
+		operatorutils.SetCondition(csm, csmv1.ConditionNodeRolloutPaused, metav1.ConditionTrue, csmv1.ReasonNodeBatchFailed, message)
+		if previous == nil || previous.Status != metav1.ConditionTrue {
+			r.EventRecorder.Eventf(csm, corev1.EventTypeWarning, csmv1.EventNodeRolloutPaused, "Node rollout paused, a batch was not updated within %s: %s", csm.Spec.Driver.NodeRollout.GetBatchTimeout(), message)
+		}
+	case len(progressing) > 0:
+		operatorutils.SetCondition(csm, csmv1.ConditionNodeRolloutPaused, metav1.ConditionFalse, csmv1.ReasonNodeRolloutInProgress, strings.Join(progressing, "; "))
+		requeueAfter = nodeRolloutPollInterval
+	default:
+		operatorutils.SetCondition(csm, csmv1.ConditionNodeRolloutPaused, metav1.ConditionFalse, csmv1.ReasonNodeRolloutComplete, "")
+	}
 
-	old, _ := oldObj.(*appsv1.DaemonSet)
-	d, _ := obj.(*appsv1.DaemonSet)
-	name := d.Spec.Template.Labels[constants.CsmLabel]
+	current := meta.FindStatusCondition(csm.Status.Conditions, csmv1.ConditionNodeRolloutPaused)
+	if previous != nil && previous.Status == current.Status && previous.Reason == current.Reason && previous.Message == current.Message {
+		return requeueAfter, nil
+	}
+	return requeueAfter, operatorutils.UpdateCSMStatus(ctx, csm, r.GetClient())
+}
 
-	key := name + "-" + fmt.Sprintf("%d", r.GetUpdateCount())
-	ctx, log := logger.GetNewContextWithLogger(key)
+// SetupWithManager sets up the controller with the Manager.
+// The Deployments, DaemonSets and Pods of a CSM enqueue it in a second controller when their status changes, which
+// only updates the status of the CSM
+func (r *ContainerStorageModuleReconciler) SetupWithManager(mgr ctrl.Manager, limiter workqueue.TypedRateLimiter[reconcile.Request], maxReconcilers int) error {
+	csmController := ctrl.NewControllerManagedBy(mgr).
+		For(&csmv1.ContainerStorageModule{}, builder.WithPredicates(r.ignoreUpdatePredicate()))
+	if r.TemplateBundleEvents != nil {
+		csmController = csmController.WatchesRawSource(source.Channel(r.TemplateBundleEvents, &handler.EnqueueRequestForObject{}))
+	}
+	err := csmController.WithOptions(controller.Options{
+		RateLimiter:             limiter,
+		MaxConcurrentReconciles: maxReconcilers,
+	}).Complete(r)
+	if err != nil {
+		return err
+	}
 
-	log.Debugw("daemonset modified generation", "new", d.Generation, "old", old.Generation)
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(statusControllerName).
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(csmRequestsForObject), builder.WithPredicates(componentStatusPredicate())).
+		Watches(&appsv1.DaemonSet{}, handler.EnqueueRequestsFromMapFunc(csmRequestsForObject), builder.WithPredicates(componentStatusPredicate())).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(csmRequestsForObject), builder.WithPredicates(componentStatusPredicate())).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxReconcilers,
+		}).Complete(&csmStatusReconciler{r})
+}
 
-	desired := d.Status.DesiredNumberScheduled
-	available := d.Status.NumberAvailable
-	ready := d.Status.NumberReady
-	numberUnavailable := d.Status.NumberUnavailable
+// csmStatusReconciler - refreshes the status of a CSM from the status of its components, without applying its spec
+type csmStatusReconciler struct {
+	*ContainerStorageModuleReconciler
+}
 
-	log.Infow("daemonset ", "name", d.Name, "namespace", d.Namespace)
-	log.Infow("daemonset ", "desired", desired)
-	log.Infow("daemonset ", "numberReady", ready)
-	log.Infow("daemonset ", "available", available)
-	log.Infow("daemonset ", "numberUnavailable", numberUnavailable)
+// Reconcile - updates the status of the CSM
+func (r *csmStatusReconciler) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.IncrUpdateCount()
+	ctx, log := logger.GetNewContextWithLogger(req.Name + "-" + fmt.Sprintf("%d", r.GetUpdateCount()))
 
-	ns := d.Spec.Template.Labels[constants.CsmNamespaceLabel]
+	csm := new(csmv1.ContainerStorageModule)
+	if err := r.Client.Get(ctx, req.NamespacedName, csm); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if csm.IsBeingDeleted() {
+		return reconcile.Result{}, nil
+	}
 
-	if ns != "" {
-		r.Log.Debugw("daemonset ", "ns", ns, "name", name)
-		namespacedName := t1.NamespacedName{
-			Name:      name,
-			Namespace: ns,
-		}
+	// an error only means the pods are not running, which the status reports
+	if err := operatorutils.UpdateStatus(ctx, csm, r, csm.GetCSMStatus(), r.Config); err != nil {
+		log.Infow("Component status", "csm", req.NamespacedName, "error", err.Error())
+	}
+	return reconcile.Result{}, nil
+}
 
-		csm := new(csmv1.ContainerStorageModule)
-		err := r.Client.Get(ctx, namespacedName, csm)
-		if err != nil {
-			r.Log.Error("daemonset get csm", "error", err.Error())
-		}
+// CacheOptions - returns the cache options of the manager. Only the Deployments, DaemonSets and Pods labeled with the
+// CSM they belong to and the template bundle ConfigMaps of the bundle namespace are cached, and the cache is resynced
+// every REFRESH_INTERVAL_MINUTES
+func CacheOptions(bundleNamespace string) cache.Options {
+	componentSelector := labels.NewSelector()
+	for _, key := range []string{constants.CsmLabel, constants.CsmNamespaceLabel} {
+		requirement, _ := labels.NewRequirement(key, selection.Exists, nil)
+		componentSelector = componentSelector.Add(*requirement)
+	}
+
+	bundles := cache.ByObject{Label: labels.SelectorFromSet(labels.Set{templatebundle.Label: "true"})}
+	if bundleNamespace != "" {
+		bundles.Namespaces = map[string]cache.Config{bundleNamespace: {}}
+	}
+
+	refreshInterval := getRefreshInterval()
+	return cache.Options{
+		SyncPeriod: &refreshInterval,
+		ByObject: map[client.Object]cache.ByObject{
+			&appsv1.Deployment{}: {Label: componentSelector},
+			&appsv1.DaemonSet{}:  {Label: componentSelector},
+			&corev1.Pod{}:        {Label: componentSelector},
+			&corev1.ConfigMap{}:  bundles,
+		},
+	}
+}
 
-		log.Infow("csm prev status ", "state", csm.Status)
-		newStatus := csm.GetCSMStatus()
-		err = operatorutils.UpdateStatus(ctx, csm, r, newStatus, r.Config)
-		if err != nil {
-			log.Debugw("daemonset status ", "pods", err.Error())
-		} else {
-			r.EventRecorder.Eventf(csm, corev1.EventTypeNormal, csmv1.EventCompleted, "Driver daemonset running OK")
-		}
+// ClientOptions - returns the client options of the manager. Deployments, DaemonSets and ConfigMaps are read from the
+// API server, since those of cert-manager, of some modules and of the drivers are not cached.
+// PVCs are only listed to check if a StorageClass is in use, which does not justify a cluster wide informer.
+// ControllerRevisions are numbered from the latest one, so the revision history must not be read from a stale cache.
+func ClientOptions() client.Options {
+	return client.Options{
+		Cache: &client.CacheOptions{
+			DisableFor: []client.Object{
+				&appsv1.Deployment{}, &appsv1.DaemonSet{}, &corev1.ConfigMap{}, &corev1.PersistentVolumeClaim{},
+				&appsv1.ControllerRevision{},
+			},
+		},
 	}
 }
 
-// ContentWatch - watch updates on deployments, deamonsets, and pods
-func (r *ContainerStorageModuleReconciler) ContentWatch(csm *csmv1.ContainerStorageModule) (chan struct{}, error) {
-	_, log := logger.GetNewContextWithLogger("ContentWatch")
+// getRefreshInterval - returns the interval set in REFRESH_INTERVAL_MINUTES, 60 minutes by default
+func getRefreshInterval() time.Duration {
+	_, log := logger.GetNewContextWithLogger("refresh")
 	refreshMinutes, err := operatorutils.GetEnvironmentVariable(RefreshEnvVar)
 	if err != nil {
 		log.Info("Refresh time environment variable not set, defaulting to 60 minutes")
 		refreshMinutes = "60"
 	}
 	refreshMinutesInt, err := strconv.Atoi(refreshMinutes)
-	if err != nil {
+	if err != nil || refreshMinutesInt <= 0 {
 		log.Error("Refresh time environment variable not a valid number, defaulting to 60 minutes")
 		refreshMinutesInt = 60
-	} else {
-		log.Info("Refresh time for ContentWatch set to ", refreshMinutesInt, " minutes")
-	}
-	refreshTime := time.Duration(refreshMinutesInt) * time.Minute
-	sharedInformerFactory := sinformer.NewSharedInformerFactoryWithOptions(r.K8sClient, time.Duration(refreshTime))
-
-	updateFn := func(oldObj interface{}, newObj interface{}) {
-		r.informerUpdate(csm, oldObj, newObj, r.handleDaemonsetUpdate, r.handleDeploymentUpdate, r.handlePodsUpdate)
-	}
-
-	daemonsetInformer := sharedInformerFactory.Apps().V1().DaemonSets().Informer()
-	_, err = daemonsetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		UpdateFunc: updateFn,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("ContentWatch failed adding event handler to daemonsetInformer: %v", err)
-	}
-
-	deploymentInformer := sharedInformerFactory.Apps().V1().Deployments().Informer()
-	_, err = deploymentInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		UpdateFunc: updateFn,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("ContentWatch failed adding event handler to deploymentInformer: %v", err)
 	}
+	return time.Duration(refreshMinutesInt) * time.Minute
+}
 
-	podsInformer := sharedInformerFactory.Core().V1().Pods().Informer()
-	_, err = podsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		UpdateFunc: updateFn,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("ContentWatch failed adding event handler to podsInformer: %v", err)
+// csmRequestsForObject - returns the request of the CSM named in the csm and csmNamespace labels of a component
+func csmRequestsForObject(_ context.Context, obj client.Object) []reconcile.Request {
+	name, namespace := obj.GetLabels()[constants.CsmLabel], obj.GetLabels()[constants.CsmNamespaceLabel]
+	if name == "" || namespace == "" {
+		return nil
 	}
+	return []reconcile.Request{{NamespacedName: t1.NamespacedName{Name: name, Namespace: namespace}}}
+}
 
-	stopCh := make(chan struct{})
-	sharedInformerFactory.Start(stopCh)
-
-	// UT only: don't block on sync to avoid bookmark requirement
-	if os.Getenv("UNIT_TEST") == "true" {
-		return stopCh, nil
+// componentStatusPredicate - passes the updates of a component that change its status, and the periodic resyncs.
+// Creations and deletions are followed by status updates, and terminating components are ignored
+func componentStatusPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if !e.ObjectNew.GetDeletionTimestamp().IsZero() {
+				return false
+			}
+			return e.ObjectOld.GetResourceVersion() == e.ObjectNew.GetResourceVersion() ||
+				!equality.Semantic.DeepEqual(componentStatus(e.ObjectOld), componentStatus(e.ObjectNew))
+		},
 	}
-	sharedInformerFactory.WaitForCacheSync(stopCh)
-
-	return stopCh, nil
 }
 
-func (r *ContainerStorageModuleReconciler) informerUpdate(csm *csmv1.ContainerStorageModule, oldObj interface{}, newObj interface{},
-	handleDaemonsetUpdate func(oldObj interface{}, obj interface{}),
-	handleDeploymentUpdate func(oldObj interface{}, obj interface{}),
-	handlePodsUpdate func(oldObj interface{}, obj interface{}),
-) {
-	// extract csm labels from object
-	// if labels are not present, don't specify a CSM, or CSM name does not match passed in CSM, do nothing
-	// else, proceed to update CSM state
-	var csmName, csmNamespace string
-	var nameOk, namespaceOk bool
-	var fn func(oldObj interface{}, newObj interface{})
-	switch v := oldObj.(type) {
-	case *appsv1.DaemonSet:
-		csmName, nameOk = v.Spec.Template.Labels[constants.CsmLabel]
-		csmNamespace, namespaceOk = v.Spec.Template.Labels[constants.CsmNamespaceLabel]
-		fn = handleDaemonsetUpdate
+// componentStatus - returns the status of a Deployment, DaemonSet or Pod
+func componentStatus(obj client.Object) any {
+	switch o := obj.(type) {
 	case *appsv1.Deployment:
-		csmName, nameOk = v.Spec.Template.Labels[constants.CsmLabel]
-		csmNamespace, namespaceOk = v.Spec.Template.Labels[constants.CsmNamespaceLabel]
-		fn = handleDeploymentUpdate
+		return o.Status
+	case *appsv1.DaemonSet:
+		return o.Status
 	case *corev1.Pod:
-		csmName, nameOk = v.GetLabels()[constants.CsmLabel]
-		csmNamespace, namespaceOk = v.GetLabels()[constants.CsmNamespaceLabel]
-		fn = handlePodsUpdate
+		return o.Status
 	default:
-		return
-	}
-
-	if (!nameOk || !namespaceOk) || (csmName == "" || csmNamespace == "") || (csmName != csm.Name) {
-		return
+		return nil
 	}
-
-	fn(oldObj, newObj)
-}
-
-// SetupWithManager sets up the controller with the Manager.
-func (r *ContainerStorageModuleReconciler) SetupWithManager(mgr ctrl.Manager, limiter workqueue.TypedRateLimiter[reconcile.Request], maxReconcilers int) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&csmv1.ContainerStorageModule{}).
-		WithEventFilter(r.ignoreUpdatePredicate()).
-		WithOptions(controller.Options{
-			RateLimiter:             limiter,
-			MaxConcurrentReconciles: maxReconcilers,
-		}).Complete(r)
 }
 
 func (r *ContainerStorageModuleReconciler) removeFinalizer(ctx context.Context, instance *csmv1.ContainerStorageModule) error {
@@ -763,6 +950,11 @@ func (r *ContainerStorageModuleReconciler) oldStandAloneModuleCleanup(ctx contex
 		}
 	}
 
+	// a rolled back CSM is applying the last successful configuration, not the spec of the CR
+	if newCR.IsRolledBack() {
+		return nil
+	}
+
 	copyCR := newCR.DeepCopy()
 	delete(copyCR.Annotations, previouslyAppliedCustomResource)
 	delete(copyCR.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
@@ -781,6 +973,12 @@ func (r *ContainerStorageModuleReconciler) oldStandAloneModuleCleanup(ctx contex
 func (r *ContainerStorageModuleReconciler) SyncCSM(ctx context.Context, cr csmv1.ContainerStorageModule, operatorConfig operatorutils.OperatorConfig, ctrlClient client.Client) error {
 	log := logger.GetLogger(ctx)
 
+	// typed driver configuration is applied as env vars for the driver and module templates, and with
+	// spec.multiHopUpgrade the intermediate versions of status.upgradePath are installed one at a time. Only the
+	// rendered copy gets them, the CR is recorded and updated as it is stored
+	stored := cr
+	cr = drivers.ApplyDriverSettings(*withUpgradeHop(&cr))
+
 	// Install/update via configmap
 	var matched operatorutils.VersionSpec
 	if cr.Spec.Version != "" {
@@ -832,12 +1030,11 @@ func (r *ContainerStorageModuleReconciler) SyncCSM(ctx context.Context, cr csmv1
 		return nil
 	}
 
-	err = r.oldStandAloneModuleCleanup(ctx, &cr, operatorConfig, driverConfig)
+	err = r.oldStandAloneModuleCleanup(ctx, &stored, operatorConfig, driverConfig)
 	if err != nil {
 		return err
 	}
 
-	driver := driverConfig.Driver
 	configMap := driverConfig.ConfigMap
 	node := driverConfig.Node
 	controller := driverConfig.Controller
@@ -900,9 +1097,6 @@ func (r *ContainerStorageModuleReconciler) SyncCSM(ctx context.Context, cr csmv1
 		}
 	}
 
-	clusterClient := operatorutils.GetCluster(ctx, r)
-	replicationEnabled, _ := operatorutils.IsModuleEnabled(ctx, cr, csmv1.Replication)
-
 	for _, m := range cr.Spec.Modules {
 		if m.Enabled {
 			switch m.Name {
@@ -985,11 +1179,79 @@ func (r *ContainerStorageModuleReconciler) SyncCSM(ctx context.Context, cr csmv1
 				}
 
 				controller.Rbac.ClusterRole = *clusterRole
+			case csmv1.VgSnapShotter:
+				log.Info("Injecting CSM VG Snapshotter")
+				dp, err := modules.VgSnapshotterInjectDeployment(ctx, controller.Deployment, cr, operatorConfig, matched)
+				if err != nil {
+					return fmt.Errorf("injecting vgsnapshotter into deployment: %v", err)
+				}
+				controller.Deployment = *dp
+
+				clusterRole, err := modules.VgSnapshotterInjectClusterRole(ctx, controller.Rbac.ClusterRole, cr, operatorConfig)
+				if err != nil {
+					return fmt.Errorf("injecting vgsnapshotter into controller cluster role: %v", err)
+				}
+
+				controller.Rbac.ClusterRole = *clusterRole
+			}
+		}
+	}
+
+	clusters, err := operatorutils.GetClusters(ctx, cr, r)
+	if err != nil {
+		return err
+	}
+	for _, clusterClient := range clusters {
+		if err = r.syncCluster(ctx, cr, operatorConfig, driverConfig, matched, clusterClient, ctrlClient); err != nil {
+			if clusterClient.ClusterID != operatorutils.DefaultSourceClusterID {
+				return fmt.Errorf("syncing cluster %s: %v", clusterClient.ClusterID, err)
 			}
+			return err
 		}
 	}
 
+	return nil
+}
+
+// syncCluster - creates or updates the driver and module objects on one cluster
+func (r *ContainerStorageModuleReconciler) syncCluster(ctx context.Context, cr csmv1.ContainerStorageModule, operatorConfig operatorutils.OperatorConfig, driverConfig *DriverConfig, matched operatorutils.VersionSpec, clusterClient operatorutils.ClusterConfig, ctrlClient client.Client) error {
+	log := logger.GetLogger(ctx)
+	var err error
+
+	driver := driverConfig.Driver
+	configMap := driverConfig.ConfigMap
+	node := driverConfig.Node
+	controller := driverConfig.Controller
+	authorizationEnabled, _ := operatorutils.IsModuleEnabled(ctx, cr, csmv1.AuthorizationServer)
+	replicationEnabled, _ := operatorutils.IsModuleEnabled(ctx, cr, csmv1.Replication)
+
+	controllerDeployment := controller.Deployment
+	if clusterClient.ClusterID != operatorutils.DefaultSourceClusterID && controllerDeployment.ObjectMetaApplyConfiguration != nil {
+		// the CSM does not exist on a remote cluster, an owner reference to it would get the deployment garbage collected
+		objectMeta := *controllerDeployment.ObjectMetaApplyConfiguration
+		objectMeta.OwnerReferences = nil
+		controllerDeployment.ObjectMetaApplyConfiguration = &objectMeta
+	}
+
 	log.Infof("Starting SYNC for %s cluster", clusterClient.ClusterID)
+
+	if clusterClient.ClusterID != operatorutils.DefaultSourceClusterID {
+		if err = prepareRemoteCluster(ctx, cr, driverConfig, ctrlClient, clusterClient); err != nil {
+			return err
+		}
+	}
+
+	// Take over the objects of a driver installed with Helm, so that they are updated in place
+	if cr.Spec.AdoptExisting {
+		adopted, err := adoption.AdoptHelmObjects(ctx, getAdoptionTargets(driverConfig), operatorutils.GetOwnerLabels(cr), clusterClient.ClusterCTRLClient)
+		if err != nil {
+			return fmt.Errorf("adopting the Helm-managed objects: %v", err)
+		}
+		if adopted > 0 {
+			r.EventRecorder.Eventf(&cr, corev1.EventTypeNormal, csmv1.EventAdopted, "Adopted %d Helm-managed objects", adopted)
+		}
+	}
+
 	if cr.GetDriverType() == csmv1.Cosi {
 		if err = serviceaccount.SyncServiceAccount(ctx, controller.Rbac.ServiceAccount, clusterClient.ClusterCTRLClient); err != nil {
 			return err
@@ -1003,7 +1265,7 @@ func (r *ContainerStorageModuleReconciler) SyncCSM(ctx context.Context, cr csmv1
 		if err = configmap.SyncConfigMap(ctx, *configMap, clusterClient.ClusterCTRLClient); err != nil {
 			return err
 		}
-		if err = deployment.SyncDeployment(ctx, controller.Deployment, clusterClient.ClusterK8sClient, cr.Name); err != nil {
+		if err = deployment.SyncDeployment(ctx, controllerDeployment, clusterClient.ClusterK8sClient, cr.Name); err != nil {
 			return err
 		}
 		return nil
@@ -1059,13 +1321,24 @@ func (r *ContainerStorageModuleReconciler) SyncCSM(ctx context.Context, cr csmv1
 		return err
 	}
 
+	// Create/Update/Prune VolumeSnapshotClasses
+	if err = clusterclass.Sync(ctx, clusterclass.VolumeSnapshotClass, driverConfig.SnapshotClasses, operatorutils.GetOwnerLabels(cr), clusterClient.ClusterCTRLClient); err != nil {
+		return err
+	}
+
+	// Create/Update/Prune VolumeGroupSnapshotClasses of the vgsnapshotter module
+	groupSnapshotClasses := modules.GetVolumeGroupSnapshotClasses(ctx, cr, driver.Name)
+	if err = clusterclass.Sync(ctx, clusterclass.VolumeGroupSnapshotClass, groupSnapshotClasses, operatorutils.GetOwnerLabels(cr), clusterClient.ClusterCTRLClient); err != nil {
+		return err
+	}
+
 	// Create/Update ConfigMap
 	if err = configmap.SyncConfigMap(ctx, *configMap, clusterClient.ClusterCTRLClient); err != nil {
 		return err
 	}
 
 	// Create/Update Deployment
-	if err = deployment.SyncDeployment(ctx, controller.Deployment, clusterClient.ClusterK8sClient, cr.Name); err != nil {
+	if err = deployment.SyncDeployment(ctx, controllerDeployment, clusterClient.ClusterK8sClient, cr.Name); err != nil {
 		return err
 	}
 
@@ -1084,6 +1357,24 @@ func (r *ContainerStorageModuleReconciler) SyncCSM(ctx context.Context, cr csmv1
 		if err = daemonset.SyncDaemonset(ctx, node.DaemonSetApplyConfig, clusterClient.ClusterK8sClient, cr.Name); err != nil {
 			return err
 		}
+
+		// with spec.driver.nodeRollout, the node pods are replaced batch by batch
+		if cr.IsNodeRolloutEnabled() {
+			rollout, err := daemonset.RolloutNodes(ctx, *node.DaemonSetApplyConfig.Name, *node.DaemonSetApplyConfig.Namespace, driver.Name, *cr.Spec.Driver.NodeRollout, clusterClient.ClusterK8sClient)
+			if err != nil {
+				return fmt.Errorf("rolling out the node plugin: %v", err)
+			}
+			r.nodeRollouts.Store(nodeRolloutKey(cr, clusterClient.ClusterID), rollout)
+		}
+	}
+
+	// Create/Update/Prune StorageClasses
+	storageClasses, err := drivers.GetStorageClasses(ctx, cr, driver.Name, ctrlClient)
+	if err != nil {
+		return err
+	}
+	if err = clusterclass.Sync(ctx, clusterclass.StorageClass, storageClasses, operatorutils.GetOwnerLabels(cr), clusterClient.ClusterCTRLClient); err != nil {
+		return err
 	}
 
 	if replicationEnabled {
@@ -1094,7 +1385,7 @@ func (r *ContainerStorageModuleReconciler) SyncCSM(ctx context.Context, cr csmv1
 
 		// Create ConfigMap if it does not already exist.
 		// ConfigMap requires namespace to be created.
-		_, err = modules.CreateReplicationConfigmap(ctx, cr, operatorConfig, ctrlClient)
+		_, err = modules.CreateReplicationConfigmap(ctx, cr, operatorConfig, clusterClient.ClusterCTRLClient)
 		if err != nil {
 			return fmt.Errorf("injecting replication into replication configmap: %v", err)
 		}
@@ -1314,12 +1605,13 @@ func getDriverConfig(ctx context.Context,
 	matched operatorutils.VersionSpec,
 ) (*DriverConfig, error) {
 	var (
-		err        error
-		driver     *storagev1.CSIDriver
-		configMap  *corev1.ConfigMap
-		node       *operatorutils.NodeYAML
-		controller *operatorutils.ControllerYAML
-		log        = logger.GetLogger(ctx)
+		err             error
+		driver          *storagev1.CSIDriver
+		configMap       *corev1.ConfigMap
+		node            *operatorutils.NodeYAML
+		controller      *operatorutils.ControllerYAML
+		snapshotClasses []*unstructured.Unstructured
+		log             = logger.GetLogger(ctx)
 	)
 
 	// if no driver is specified, return nil
@@ -1341,6 +1633,7 @@ func getDriverConfig(ctx context.Context,
 		if err != nil {
 			return nil, fmt.Errorf("getting %s CSIDriver: %v", driverType, err)
 		}
+		snapshotClasses = drivers.GetSnapshotClasses(cr, driver.Name)
 
 		node, err = drivers.GetNode(ctx, cr, operatorConfig, driverType, NodeYaml, ctrlClient, matched)
 		if err != nil {
@@ -1358,10 +1651,11 @@ func getDriverConfig(ctx context.Context,
 	}
 
 	return &DriverConfig{
-		Driver:     driver,
-		ConfigMap:  configMap,
-		Node:       node,
-		Controller: controller,
+		Driver:          driver,
+		ConfigMap:       configMap,
+		Node:            node,
+		Controller:      controller,
+		SnapshotClasses: snapshotClasses,
 	}, nil
 }
 
@@ -1454,6 +1748,7 @@ func removeDriverFromCluster(ctx context.Context, cluster operatorutils.ClusterC
 				log.Errorw("error delete daemonset", "Error", err.Error())
 				return err
 			}
+			contenthash.Forget(daemonsetObj)
 		} else {
 			log.Infow("error getting daemonset", "daemonsetKey", daemonsetKey)
 		}
@@ -1471,6 +1766,7 @@ func removeDriverFromCluster(ctx context.Context, cluster operatorutils.ClusterC
 				log.Errorw("error delete deployment", "Error", err.Error())
 				return err
 			}
+			contenthash.Forget(deploymentObj)
 		} else {
 			log.Infow("error getting deployment", "deploymentKey", deploymentKey)
 		}
@@ -1516,6 +1812,8 @@ func (r *ContainerStorageModuleReconciler) removeDeploymentOwnerRef(ctx context.
 	return r.GetClient().Update(ctx, deploy)
 }
 
+// removeDriver - removes the driver from the local cluster and from the target clusters it was applied to. A target cluster
+// the driver cannot be removed from does not block the deletion of the CSM and is reported in a warning event.
 func (r *ContainerStorageModuleReconciler) removeDriver(ctx context.Context, instance csmv1.ContainerStorageModule, operatorConfig operatorutils.OperatorConfig) error {
 	log := logger.GetLogger(ctx)
 
@@ -1530,10 +1828,160 @@ func (r *ContainerStorageModuleReconciler) removeDriver(ctx context.Context, ins
 		return nil
 	}
 
-	clusterClient := operatorutils.GetCluster(ctx, r)
+	if err = r.removeDriverObjects(ctx, instance, operatorConfig, driverConfig, operatorutils.GetCluster(ctx, r)); err != nil {
+		return err
+	}
+
+	targetClusters := slices.Clone(instance.Spec.TargetClusters)
+	for _, clusterID := range instance.Status.AppliedClusters {
+		if !slices.Contains(targetClusters, clusterID) {
+			targetClusters = append(targetClusters, clusterID)
+		}
+	}
+	r.removeDriverFromTargetClusters(ctx, instance, operatorConfig, driverConfig, targetClusters)
+
+	return nil
+}
+
+// removeDriverFromTargetClusters - removes the driver from the target clusters and returns the ones the removal failed on.
+// A cluster whose clients cannot be created, e.g. because its kubeconfig secret was deleted, cannot be cleaned up by a
+// retry either, so it is only reported in a warning event.
+func (r *ContainerStorageModuleReconciler) removeDriverFromTargetClusters(ctx context.Context, instance csmv1.ContainerStorageModule, operatorConfig operatorutils.OperatorConfig, driverConfig *DriverConfig, clusterIDs []string) []string {
+	log := logger.GetLogger(ctx)
+
+	var failed []string
+	for _, clusterID := range clusterIDs {
+		clusterClient, err := operatorutils.GetRemoteClusterWrapper(ctx, clusterID, r.GetClient())
+		if err != nil {
+			log.Warnw("Cannot remove the driver from cluster", "cluster", clusterID, "error", err.Error())
+			r.EventRecorder.Eventf(&instance, corev1.EventTypeWarning, csmv1.EventDeleted, "Cannot remove the driver from cluster %s: %v", clusterID, err)
+			continue
+		}
+		if err = r.removeDriverObjects(ctx, instance, operatorConfig, driverConfig, clusterClient); err != nil {
+			log.Warnw("Failed to remove the driver from cluster", "cluster", clusterID, "error", err.Error())
+			r.EventRecorder.Eventf(&instance, corev1.EventTypeWarning, csmv1.EventDeleted, "Failed to remove the driver from cluster %s: %v", clusterID, err)
+			failed = append(failed, clusterID)
+		}
+	}
+	return failed
+}
+
+// syncAppliedClusters - removes the driver from the target clusters dropped from spec.targetClusters and records the
+// target clusters the driver is applied to in status.appliedClusters. A cluster the removal failed on stays recorded, so
+// that it is retried by the next reconcile.
+func (r *ContainerStorageModuleReconciler) syncAppliedClusters(ctx context.Context, cr *csmv1.ContainerStorageModule, operatorConfig operatorutils.OperatorConfig) error {
+	var dropped []string
+	for _, clusterID := range cr.Status.AppliedClusters {
+		if !slices.Contains(cr.Spec.TargetClusters, clusterID) {
+			dropped = append(dropped, clusterID)
+		}
+	}
+
+	applied := slices.Clone(cr.Spec.TargetClusters)
+	if len(dropped) > 0 {
+		driverConfig, err := getDriverConfig(ctx, *cr, operatorConfig, r.Client, operatorutils.VersionSpec{})
+		if err != nil {
+			return err
+		}
+		if driverConfig != nil {
+			applied = append(applied, r.removeDriverFromTargetClusters(ctx, *cr, operatorConfig, driverConfig, dropped)...)
+		}
+	}
+
+	if slices.Equal(applied, cr.Status.AppliedClusters) {
+		return nil
+	}
+	cr.Status.AppliedClusters = applied
+	return operatorutils.UpdateCSMStatus(ctx, cr, r.GetClient())
+}
+
+// prepareRemoteCluster - creates the namespace of the CSM on a remote cluster and copies the secrets the driver pods use
+// into it. A secret that already exists on the remote cluster is kept, as each cluster may point the driver to its own arrays.
+func prepareRemoteCluster(ctx context.Context, cr csmv1.ContainerStorageModule, driverConfig *DriverConfig, ctrlClient client.Client, clusterClient operatorutils.ClusterConfig) error {
+	log := logger.GetLogger(ctx)
+	remote := clusterClient.ClusterCTRLClient
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cr.Namespace}}
+	if err := remote.Create(ctx, namespace); err != nil && !k8serror.IsAlreadyExists(err) {
+		return fmt.Errorf("creating namespace %s: %v", cr.Namespace, err)
+	}
+
+	for _, name := range getDriverSecretNames(driverConfig) {
+		key := t1.NamespacedName{Name: name, Namespace: cr.Namespace}
+		err := remote.Get(ctx, key, &corev1.Secret{})
+		if err == nil {
+			continue
+		} else if !k8serror.IsNotFound(err) {
+			return fmt.Errorf("reading secret %s: %v", name, err)
+		}
+
+		found := &corev1.Secret{}
+		err = ctrlClient.Get(ctx, key, found)
+		if k8serror.IsNotFound(err) {
+			// optional secrets, e.g. certificates, are not required to exist
+			log.Infow("Secret used by the driver not found, not copying it", "name", name, "cluster", clusterClient.ClusterID)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("reading secret %s: %v", name, err)
+		}
+
+		log.Infow("Copying secret to cluster", "name", name, "cluster", clusterClient.ClusterID)
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace, Labels: operatorutils.GetOwnerLabels(cr)},
+			Data:       found.Data,
+			Type:       found.Type,
+		}
+		if err := remote.Create(ctx, secret); err != nil && !k8serror.IsAlreadyExists(err) {
+			return fmt.Errorf("copying secret %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// getDriverSecretNames - returns the secrets mounted by or pulling the images of the driver controller and node pods
+func getDriverSecretNames(driverConfig *DriverConfig) []string {
+	var names []string
+	add := func(spec *acorev1.PodSpecApplyConfiguration) {
+		if spec == nil {
+			return
+		}
+		for _, v := range spec.Volumes {
+			if v.Secret != nil && v.Secret.SecretName != nil && !slices.Contains(names, *v.Secret.SecretName) {
+				names = append(names, *v.Secret.SecretName)
+			}
+		}
+		for _, s := range spec.ImagePullSecrets {
+			if s.Name != nil && !slices.Contains(names, *s.Name) {
+				names = append(names, *s.Name)
+			}
+		}
+	}
+	if driverConfig.Controller != nil && driverConfig.Controller.Deployment.Spec != nil && driverConfig.Controller.Deployment.Spec.Template != nil {
+		add(driverConfig.Controller.Deployment.Spec.Template.Spec)
+	}
+	if driverConfig.Node != nil && driverConfig.Node.DaemonSetApplyConfig.Spec != nil && driverConfig.Node.DaemonSetApplyConfig.Spec.Template != nil {
+		add(driverConfig.Node.DaemonSetApplyConfig.Spec.Template.Spec)
+	}
+	return names
+}
+
+// removeDriverObjects - removes the driver and module objects from one cluster
+func (r *ContainerStorageModuleReconciler) removeDriverObjects(ctx context.Context, instance csmv1.ContainerStorageModule, operatorConfig operatorutils.OperatorConfig, driverConfig *DriverConfig, clusterClient operatorutils.ClusterConfig) error {
+	log := logger.GetLogger(ctx)
+	var err error
+
 	if err = removeDriverFromCluster(ctx, clusterClient, driverConfig); err != nil {
 		return err
 	}
+	if err = clusterclass.Delete(ctx, clusterclass.VolumeSnapshotClass, operatorutils.GetOwnerLabels(instance), clusterClient.ClusterCTRLClient); err != nil {
+		return err
+	}
+	if err = clusterclass.Delete(ctx, clusterclass.StorageClass, operatorutils.GetOwnerLabels(instance), clusterClient.ClusterCTRLClient); err != nil {
+		return err
+	}
+	if err = clusterclass.Delete(ctx, clusterclass.VolumeGroupSnapshotClass, operatorutils.GetOwnerLabels(instance), clusterClient.ClusterCTRLClient); err != nil {
+		return err
+	}
 	replicationEnabled, _ := operatorutils.IsModuleEnabled(ctx, instance, csmv1.Replication)
 	if replicationEnabled {
 		log.Infow("Deleting Replication controller")
@@ -1782,12 +2230,16 @@ func (r *ContainerStorageModuleReconciler) PreChecks(ctx context.Context, cr *cs
 	}
 
 	upgradeValid, err := r.checkUpgrade(ctx, cr, operatorConfig)
-	if err != nil {
+	if errors.Is(err, operatorutils.ErrUpgradeBlocked) {
+		return err
+	} else if err != nil {
 		return fmt.Errorf("failed upgrade check: %v", err)
 	} else if !upgradeValid {
 		log.Infof("upgrade is not valid")
+		operatorutils.SetCondition(cr, csmv1.ConditionUpgradeBlocked, metav1.ConditionTrue, csmv1.ReasonUpgradePathInvalid, "cannot switch between Authorization v1 and v2")
 		return nil
 	}
+	operatorutils.SetCondition(cr, csmv1.ConditionUpgradeBlocked, metav1.ConditionFalse, csmv1.ReasonUpgradePathValid, "")
 
 	// Check if valid custom registry is mentioned
 	err = operatorutils.ValidateCustomRegistry(ctx, cr.Spec.CustomRegistry)
@@ -1801,7 +2253,9 @@ func (r *ContainerStorageModuleReconciler) PreChecks(ctx context.Context, cr *cs
 	if err != nil {
 		log.Infow("Driver not installed yet")
 	} else {
-		if driver.GetOwnerReferences() != nil {
+		if cr.Spec.AdoptExisting && adoption.IsHelmManaged(driver) {
+			log.Infow("Driver installed with Helm will be adopted", "deployment", driver.Name)
+		} else if driver.GetOwnerReferences() != nil {
 			found := false
 			cred := driver.GetOwnerReferences()
 			for _, m := range cred {
@@ -1851,6 +2305,10 @@ func (r *ContainerStorageModuleReconciler) PreChecks(ctx context.Context, cr *cs
 				if err := modules.ReverseProxyPrecheck(ctx, operatorConfig, m, *cr, r); err != nil {
 					return fmt.Errorf("failed reverseproxy validation: %v", err)
 				}
+			case csmv1.VgSnapShotter:
+				if err := modules.VgSnapshotterPrecheck(ctx, operatorConfig, m, *cr, r); err != nil {
+					return fmt.Errorf("failed vgsnapshotter validation: %v", err)
+				}
 			default:
 				return fmt.Errorf("unsupported module type %s", m.Name)
 			}
@@ -1869,7 +2327,7 @@ func (r *ContainerStorageModuleReconciler) checkUpgrade(ctx context.Context, cr
 	// for the desired upgrade. If the upgrade path is not valid fail
 	// Existing version
 	annotations := cr.GetAnnotations()
-	oldVersion, configVersionExists := annotations[configVersionKey]
+	oldVersion, configVersionExists := annotations[operatorutils.ConfigVersionAnnotation]
 	// If annotation exists, we are doing an upgrade or modify
 	if configVersionExists {
 		if cr.HasModule(csmv1.AuthorizationServer) {
@@ -1889,7 +2347,7 @@ func (r *ContainerStorageModuleReconciler) checkUpgrade(ctx context.Context, cr
 				log.Error("Cannot switch between Authorization v1 and v2")
 				return false, nil
 			}
-			return operatorutils.IsValidUpgrade(ctx, oldVersion, newVersion, csmv1.Authorization, operatorConfig)
+			return checkUpgradePath(ctx, cr, oldVersion, newVersion, csmv1.Authorization, operatorConfig)
 		}
 		driverType := cr.Spec.Driver.CSIDriverType
 		if driverType == csmv1.PowerScale {
@@ -1900,13 +2358,130 @@ func (r *ContainerStorageModuleReconciler) checkUpgrade(ctx context.Context, cr
 		if err != nil {
 			return false, err
 		}
-		return operatorutils.IsValidUpgrade(ctx, oldVersion, newVersion, driverType, operatorConfig)
+		return checkUpgradePath(ctx, cr, oldVersion, newVersion, driverType, operatorConfig)
 
 	}
 	log.Infow("proceeding with fresh driver install")
 	return true, nil
 }
 
+// checkUpgradePath - checks the change from the installed oldVersion to newVersion, and records its upgrade path in status.
+// A change that needs intermediate versions is valid with spec.multiHopUpgrade, the hop being installed stays first in
+// the path until advanceUpgradeHop moves on from it
+func checkUpgradePath[T operatorutils.CSMComponentType](ctx context.Context, cr *csmv1.ContainerStorageModule, oldVersion, newVersion string, csmComponentType T, operatorConfig operatorutils.OperatorConfig) (bool, error) {
+	log := logger.GetLogger(ctx)
+	status := cr.GetCSMStatus()
+	if oldVersion == newVersion {
+		status.UpgradePath = nil
+		return true, nil
+	}
+
+	path := status.UpgradePath
+	if len(path) == 0 || path[len(path)-1] != newVersion {
+		planned, err := operatorutils.PlanUpgrade(ctx, oldVersion, newVersion, csmComponentType, operatorutils.ModulesFollowingDriverVersion(*cr), operatorConfig)
+		if err != nil {
+			log.Infow("Upgrade cannot be planned", "error", err.Error())
+		}
+		path = planned
+	}
+	status.UpgradePath = path
+
+	valid, err := operatorutils.IsValidUpgrade(ctx, oldVersion, newVersion, csmComponentType, operatorConfig)
+	if err != nil {
+		err = fmt.Errorf("%w: %v", operatorutils.ErrUpgradeBlocked, err)
+	}
+	if valid || len(path) == 0 {
+		return valid, err
+	}
+	if !cr.Spec.MultiHopUpgrade {
+		return false, fmt.Errorf("%w, it can be reached through %s with spec.multiHopUpgrade", err, strings.Join(path, ", "))
+	}
+	log.Infow("Upgrading through intermediate versions", "from", oldVersion, "path", path)
+	return true, nil
+}
+
+// getUpgradeHop - returns the config version installed instead of the requested one during a multi-hop upgrade, or ""
+func getUpgradeHop(cr *csmv1.ContainerStorageModule) string {
+	if !cr.Spec.MultiHopUpgrade || len(cr.Status.UpgradePath) < 2 {
+		return ""
+	}
+	return cr.Status.UpgradePath[0]
+}
+
+// advanceUpgradeHop - moves a multi-hop upgrade on to its next version once the status calculated after installing the
+// hop is Succeeded. It is not called by the reconcile that starts the hop, whose status is still the one of the version
+// installed before
+func (r *ContainerStorageModuleReconciler) advanceUpgradeHop(ctx context.Context, csm *csmv1.ContainerStorageModule) (bool, error) {
+	log := logger.GetLogger(ctx)
+	hop := getUpgradeHop(csm)
+	if hop == "" || csm.IsRolledBack() || csm.GetAnnotations()[operatorutils.ConfigVersionAnnotation] != hop || csm.Status.State != constants.Succeeded {
+		return false, nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current := new(csmv1.ContainerStorageModule)
+		if err := r.GetClient().Get(ctx, client.ObjectKeyFromObject(csm), current); err != nil {
+			return err
+		}
+		current.Status.UpgradePath = csm.Status.UpgradePath[1:]
+		if err := r.GetClient().Status().Update(ctx, current); err != nil {
+			return err
+		}
+		csm.Status.UpgradePath = current.Status.UpgradePath
+		csm.ResourceVersion = current.ResourceVersion
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	log.Infow("Upgrade hop installed", "version", hop, "path", csm.Status.UpgradePath)
+	return true, nil
+}
+
+// withUpgradeHop - returns a copy of the CSM that installs the hop of a multi-hop upgrade, with the default images of
+// its config version and of the module versions that go with it. Modules pinned to a config version are left as they are
+func withUpgradeHop(cr *csmv1.ContainerStorageModule) *csmv1.ContainerStorageModule {
+	hop := getUpgradeHop(cr)
+	if hop == "" || cr.IsRolledBack() {
+		return cr
+	}
+
+	hopCR := cr.DeepCopy()
+	hopCR.Spec.Version = ""
+	clearImages := func(containers []csmv1.ContainerTemplate) {
+		for i := range containers {
+			containers[i].Image = ""
+		}
+	}
+	if hopCR.Spec.Driver.Common != nil {
+		hopCR.Spec.Driver.Common.Image = ""
+	}
+	for _, c := range []*csmv1.PodTemplate{hopCR.Spec.Driver.Controller, hopCR.Spec.Driver.Node} {
+		if c != nil {
+			c.Image = ""
+		}
+	}
+	clearImages(hopCR.Spec.Driver.SideCars)
+	clearImages(hopCR.Spec.Driver.InitContainers)
+	for i := range hopCR.Spec.Modules {
+		m := &hopCR.Spec.Modules[i]
+		if m.Name == csmv1.AuthorizationServer {
+			m.ConfigVersion = hop
+		} else if m.ConfigVersion != "" {
+			// a module pinned to a config version keeps it and its images, since it does not follow the driver version
+			continue
+		}
+		for j := range m.Components {
+			m.Components[j].Image = ""
+		}
+		clearImages(m.InitContainer)
+	}
+	if !hopCR.HasModule(csmv1.AuthorizationServer) {
+		hopCR.Spec.Driver.ConfigVersion = hop
+	}
+	return hopCR
+}
+
 // applyConfigVersionAnnotations - applies the config version annotation to the instance.
 func applyConfigVersionAnnotations(ctx context.Context, instance *csmv1.ContainerStorageModule, op operatorutils.OperatorConfig) bool {
 	log := logger.GetLogger(ctx)
@@ -1925,9 +2500,13 @@ func applyConfigVersionAnnotations(ctx context.Context, instance *csmv1.Containe
 	if err != nil {
 		return false
 	}
+	// a multi-hop upgrade records the hop being installed
+	if hop := getUpgradeHop(instance); hop != "" {
+		configVersion = hop
+	}
 
-	if annotations[configVersionKey] != configVersion {
-		annotations[configVersionKey] = configVersion
+	if annotations[operatorutils.ConfigVersionAnnotation] != configVersion {
+		annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 		log.Infof("Installing csm component %s with config Version %s. Updating Annotations with Config Version",
 			instance.GetName(), configVersion)
 		instance.SetAnnotations(annotations)
@@ -1949,7 +2528,7 @@ func (r *ContainerStorageModuleReconciler) IncrUpdateCount() {
 
 // GetUpdateCount - Returns the current update count
 func (r *ContainerStorageModuleReconciler) GetUpdateCount() int32 {
-	return r.updateCount
+	return atomic.LoadInt32(&r.updateCount)
 }
 
 // GetK8sClient - Returns the current update count
@@ -1957,6 +2536,53 @@ func (r *ContainerStorageModuleReconciler) GetK8sClient() kubernetes.Interface {
 	return r.K8sClient
 }
 
+// getAdoptionTargets - returns the objects of the driver that can be adopted from a Helm release
+func getAdoptionTargets(driverConfig *DriverConfig) []adoption.Target {
+	var targets []adoption.Target
+	add := func(kind string, obj client.Object, selector map[string]string) {
+		if obj.GetName() != "" {
+			targets = append(targets, adoption.Target{Kind: kind, Object: obj, Selector: selector})
+		}
+	}
+	addRbac := func(rbacYAML operatorutils.RbacYAML) {
+		add("ServiceAccount", &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: rbacYAML.ServiceAccount.Name, Namespace: rbacYAML.ServiceAccount.Namespace}}, nil)
+		add("ClusterRole", &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: rbacYAML.ClusterRole.Name}}, nil)
+		add("ClusterRoleBinding", &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: rbacYAML.ClusterRoleBinding.Name}}, nil)
+		add("Role", &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: rbacYAML.Role.Name, Namespace: rbacYAML.Role.Namespace}}, nil)
+		add("RoleBinding", &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: rbacYAML.RoleBinding.Name, Namespace: rbacYAML.RoleBinding.Namespace}}, nil)
+	}
+
+	if driverConfig.Controller != nil {
+		addRbac(driverConfig.Controller.Rbac)
+		dp := driverConfig.Controller.Deployment
+		if dp.ObjectMetaApplyConfiguration != nil && dp.Name != nil && dp.Namespace != nil {
+			var selector map[string]string
+			if dp.Spec != nil && dp.Spec.Selector != nil {
+				selector = dp.Spec.Selector.MatchLabels
+			}
+			add("Deployment", &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: *dp.Name, Namespace: *dp.Namespace}}, selector)
+		}
+	}
+	if driverConfig.Node != nil {
+		addRbac(driverConfig.Node.Rbac)
+		ds := driverConfig.Node.DaemonSetApplyConfig
+		if ds.ObjectMetaApplyConfiguration != nil && ds.Name != nil && ds.Namespace != nil {
+			var selector map[string]string
+			if ds.Spec != nil && ds.Spec.Selector != nil {
+				selector = ds.Spec.Selector.MatchLabels
+			}
+			add("DaemonSet", &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: *ds.Name, Namespace: *ds.Namespace}}, selector)
+		}
+	}
+	if driverConfig.Driver != nil {
+		add("CSIDriver", &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: driverConfig.Driver.Name}}, nil)
+	}
+	if driverConfig.ConfigMap != nil {
+		add("ConfigMap", &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: driverConfig.ConfigMap.Name, Namespace: driverConfig.ConfigMap.Namespace}}, nil)
+	}
+	return targets
+}
+
 // ZoneValidation - If zones are configured performs validation and returns an error if the zone validation fails
 func (r *ContainerStorageModuleReconciler) ZoneValidation(ctx context.Context, cr *csmv1.ContainerStorageModule) error {
 	err := drivers.ValidateZones(ctx, cr, r.Client)
@@ -21,7 +21,6 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"sync"
 	"testing"
 	"time"
 
@@ -31,6 +30,9 @@ import (
 	"github.com/dell/csm-operator/pkg/logger"
 	"github.com/dell/csm-operator/pkg/modules"
 	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/dell/csm-operator/pkg/resources/adoption"
+	"github.com/dell/csm-operator/pkg/resources/daemonset"
+	"github.com/dell/csm-operator/pkg/templatebundle"
 	shared "github.com/dell/csm-operator/tests/sharedutil"
 	"github.com/dell/csm-operator/tests/sharedutil/clientgoclient"
 	"github.com/dell/csm-operator/tests/sharedutil/crclient"
@@ -44,18 +46,22 @@ import (
 	storagev1 "k8s.io/api/storage/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	confv1 "k8s.io/client-go/applyconfigurations/apps/v1"
 	confmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -398,6 +404,407 @@ func (suite *CSMControllerTestSuite) TestReconcileError() {
 	suite.runFakeCSMManagerError("", false, false)
 }
 
+func (suite *CSMControllerTestSuite) TestReconcilePaused() {
+	suite.makeFakeCSM(csmName, suite.namespace, true, []csmv1.Module{})
+	csm := &csmv1.ContainerStorageModule{}
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	csm.Spec.Paused = true
+	assert.NoError(suite.T(), suite.fakeClient.Update(ctx, csm))
+
+	reconciler := suite.createReconciler()
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(suite.T(), err)
+
+	// nothing is applied while paused
+	dp := &appsv1.Deployment{}
+	err = suite.fakeClient.Get(ctx, client.ObjectKey{Name: csmName + "-controller", Namespace: suite.namespace}, dp)
+	assert.True(suite.T(), k8sErrors.IsNotFound(err))
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	assert.True(suite.T(), meta.IsStatusConditionTrue(csm.Status.Conditions, csmv1.ConditionPaused))
+
+	// resuming applies the CSM again
+	orig := k8s.GetClientSetWrapper
+	defer func() { k8s.GetClientSetWrapper = orig }()
+	k8s.GetClientSetWrapper = func() (kubernetes.Interface, error) {
+		return k8sfake.NewClientset(), nil
+	}
+	csm.Spec.Paused = false
+	assert.NoError(suite.T(), suite.fakeClient.Update(ctx, csm))
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, client.ObjectKey{Name: csmName + "-controller", Namespace: suite.namespace}, dp))
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	assert.True(suite.T(), meta.IsStatusConditionFalse(csm.Status.Conditions, csmv1.ConditionPaused))
+}
+
+func (suite *CSMControllerTestSuite) TestReconcilePausedDeletion() {
+	suite.makeFakeCSM(csmName, suite.namespace, true, []csmv1.Module{})
+	csm := &csmv1.ContainerStorageModule{}
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	csm.Spec.Paused = true
+	assert.NoError(suite.T(), suite.fakeClient.Update(ctx, csm))
+
+	reconciler := suite.createReconciler()
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(suite.T(), err)
+
+	recorder := reconciler.EventRecorder.(*record.FakeRecorder)
+	for len(recorder.Events) > 0 {
+		<-recorder.Events
+	}
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	assert.NoError(suite.T(), suite.fakeClient.(*crclient.Client).SetDeletionTimeStamp(ctx, csm))
+
+	// the finalizer is kept while paused, and the wait is reported once
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	assert.Contains(suite.T(), meta.FindStatusCondition(csm.Status.Conditions, csmv1.ConditionPaused).Message, "deletion waits until it is resumed")
+	assert.Len(suite.T(), recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(suite.T(), event, csmv1.EventDeleted)
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), recorder.Events)
+}
+
+func (suite *CSMControllerTestSuite) TestReconcileResumedFailedPrechecks() {
+	suite.makeFakeCSM(csmName, suite.namespace, true, []csmv1.Module{})
+	csm := &csmv1.ContainerStorageModule{}
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	csm.Spec.Paused = true
+	assert.NoError(suite.T(), suite.fakeClient.Update(ctx, csm))
+
+	reconciler := suite.createReconciler()
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(suite.T(), err)
+
+	// the paused condition is cleared even though the resumed spec fails the prechecks
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	csm.Spec.Paused = false
+	csm.Spec.Driver.ConfigVersion = "v0.0.1"
+	assert.NoError(suite.T(), suite.fakeClient.Update(ctx, csm))
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	assert.True(suite.T(), meta.IsStatusConditionFalse(csm.Status.Conditions, csmv1.ConditionPrecheckPassed))
+	assert.True(suite.T(), meta.IsStatusConditionFalse(csm.Status.Conditions, csmv1.ConditionPaused))
+}
+
+func (suite *CSMControllerTestSuite) TestReconcileRollbackFailedPrechecks() {
+	suite.makeFakeCSM(csmName, suite.namespace, true, []csmv1.Module{})
+	orig := k8s.GetClientSetWrapper
+	defer func() { k8s.GetClientSetWrapper = orig }()
+	k8s.GetClientSetWrapper = func() (kubernetes.Interface, error) {
+		return k8sfake.NewClientset(), nil
+	}
+	reconciler := suite.createReconciler()
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(suite.T(), err)
+
+	// the last successful configuration no longer passes the prechecks
+	csm := &csmv1.ContainerStorageModule{}
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	lastCSM := csm.DeepCopy()
+	lastCSM.Spec.Driver.ConfigVersion = "v0.0.1"
+	lastCSM.Status = csmv1.ContainerStorageModuleStatus{}
+	last, err := json.Marshal(lastCSM)
+	assert.NoError(suite.T(), err)
+
+	csm.Generation = 2
+	csm.Spec.Rollback = &csmv1.RollbackPolicy{Enabled: true}
+	assert.NoError(suite.T(), suite.fakeClient.Update(ctx, csm))
+	csm.Status.RolloutGeneration = 2
+	csm.Status.RolledBackGeneration = 2
+	csm.Status.LastSuccessfulConfiguration = string(last)
+	assert.NoError(suite.T(), operatorutils.UpdateCSMStatus(ctx, csm, suite.fakeClient))
+
+	recorder := reconciler.EventRecorder.(*record.FakeRecorder)
+	for len(recorder.Events) > 0 {
+		<-recorder.Events
+	}
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.Error(suite.T(), err)
+	event := <-recorder.Events
+	assert.Contains(suite.T(), event, "Failed Prechecks of the last successful configuration")
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	assert.True(suite.T(), meta.IsStatusConditionFalse(csm.Status.Conditions, csmv1.ConditionPrecheckPassed))
+}
+
+func (suite *CSMControllerTestSuite) TestHandleRollback() {
+	lastCSM := shared.MakeCSM(csmName, suite.namespace, configVersion)
+	lastCSM.Spec.Driver.CSIDriverType = csmv1.PowerScale
+	last, err := json.Marshal(lastCSM)
+	assert.NoError(suite.T(), err)
+
+	csm := lastCSM.DeepCopy()
+	csm.Generation = 2
+	csm.Spec.Driver.Common.Image = "quay.io/dell/container-storage-modules/csi-isilon:v0.0.0"
+	csm.Spec.Rollback = &csmv1.RollbackPolicy{Enabled: true, Timeout: &metav1.Duration{Duration: time.Minute}}
+	csm.Status.LastSuccessfulConfiguration = string(last)
+	csm.Status.State = constants.Failed
+	assert.NoError(suite.T(), suite.fakeClient.Create(ctx, csm))
+
+	reconciler := suite.createReconciler()
+	recorder := reconciler.EventRecorder.(*record.FakeRecorder)
+
+	// a new generation starts a rollout
+	got, err := reconciler.handleRollback(ctx, csm)
+	assert.NoError(suite.T(), err)
+	assert.Same(suite.T(), csm, got)
+	assert.Equal(suite.T(), int64(2), csm.Status.RolloutGeneration)
+	assert.NotNil(suite.T(), csm.Status.RolloutStartTime)
+
+	// the rollout has not timed out yet
+	got, err = reconciler.handleRollback(ctx, csm)
+	assert.NoError(suite.T(), err)
+	assert.Same(suite.T(), csm, got)
+
+	// the rollout timed out, the last successful spec is applied
+	started := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	csm.Status.RolloutStartTime = &started
+	got, err = reconciler.handleRollback(ctx, csm)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), lastCSM.Spec, got.Spec)
+	assert.Equal(suite.T(), "quay.io/dell/container-storage-modules/csi-isilon:v0.0.0", string(csm.Spec.Driver.Common.Image))
+	assert.True(suite.T(), csm.IsRolledBack())
+	assert.Nil(suite.T(), csm.Status.RolloutStartTime)
+	rolledBack := meta.FindStatusCondition(csm.Status.Conditions, csmv1.ConditionRolledBack)
+	assert.NotNil(suite.T(), rolledBack)
+	assert.Equal(suite.T(), metav1.ConditionTrue, rolledBack.Status)
+	assert.Equal(suite.T(), csmv1.ReasonRolloutTimedOut, rolledBack.Reason)
+	event := <-recorder.Events
+	assert.Contains(suite.T(), event, csmv1.EventRolledBack)
+	assert.Contains(suite.T(), event, "spec.driver.common.image")
+
+	// the last successful spec is applied until the spec changes again
+	got, err = reconciler.handleRollback(ctx, csm)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), lastCSM.Spec, got.Spec)
+	assert.Empty(suite.T(), recorder.Events)
+
+	csm.Generation = 3
+	got, err = reconciler.handleRollback(ctx, csm)
+	assert.NoError(suite.T(), err)
+	assert.Same(suite.T(), csm, got)
+	assert.False(suite.T(), csm.IsRolledBack())
+	assert.True(suite.T(), meta.IsStatusConditionFalse(csm.Status.Conditions, csmv1.ConditionRolledBack))
+
+	// a successful rollout is not rolled back later
+	csm.Status.State = constants.Succeeded
+	csm.Status.ObservedGeneration = 3
+	_, err = reconciler.handleRollback(ctx, csm)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), csm.Status.RolloutStartTime)
+	csm.Status.State = constants.Failed
+	got, err = reconciler.handleRollback(ctx, csm)
+	assert.NoError(suite.T(), err)
+	assert.Same(suite.T(), csm, got)
+
+	// without the policy nothing is tracked
+	csm.Spec.Rollback = nil
+	csm.Generation = 4
+	_, err = reconciler.handleRollback(ctx, csm)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), csm.Status.RolloutGeneration)
+}
+
+func (suite *CSMControllerTestSuite) TestHandleRollbackTo() {
+	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
+	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
+	csm.Status.State = constants.Succeeded
+	assert.NoError(suite.T(), suite.fakeClient.Create(ctx, &csm))
+	assert.NoError(suite.T(), operatorutils.RecordRevision(ctx, &csm, "v1.12.0", suite.fakeClient))
+	assert.Equal(suite.T(), int64(1), csm.Status.Revision)
+
+	reconciler := suite.createReconciler()
+	recorder := reconciler.EventRecorder.(*record.FakeRecorder)
+
+	// the spec of the revision replaces the current one
+	csm.Spec.Driver.Replicas = 5
+	revision := int64(1)
+	csm.Spec.RollbackTo = &revision
+	assert.NoError(suite.T(), suite.fakeClient.Update(ctx, &csm))
+	assert.NoError(suite.T(), reconciler.handleRollbackTo(ctx, &csm))
+	found := &csmv1.ContainerStorageModule{}
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, client.ObjectKeyFromObject(&csm), found))
+	assert.Nil(suite.T(), found.Spec.RollbackTo)
+	assert.NotEqual(suite.T(), int32(5), found.Spec.Driver.Replicas)
+	assert.Contains(suite.T(), <-recorder.Events, "Spec restored from revision 1")
+
+	// an unknown revision is reported and dropped
+	revision = 7
+	found.Spec.RollbackTo = &revision
+	assert.NoError(suite.T(), reconciler.handleRollbackTo(ctx, found))
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, client.ObjectKeyFromObject(&csm), found))
+	assert.Nil(suite.T(), found.Spec.RollbackTo)
+	assert.Contains(suite.T(), <-recorder.Events, "Failed to restore revision 7: revision 7 of "+csmName+" not found")
+}
+
+func (suite *CSMControllerTestSuite) TestHandleNodeRollout() {
+	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
+	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
+	csm.Spec.Driver.NodeRollout = &csmv1.NodeRolloutStrategy{Enabled: true}
+	assert.NoError(suite.T(), suite.fakeClient.Create(ctx, &csm))
+
+	reconciler := suite.createReconciler()
+	recorder := reconciler.EventRecorder.(*record.FakeRecorder)
+	key := nodeRolloutKey(csm, operatorutils.DefaultSourceClusterID)
+
+	// a rollout in progress is polled
+	reconciler.nodeRollouts.Store(key, daemonset.NodeRolloutStatus{TotalNodes: 2, Pending: []string{"node-a"}, InProgress: true})
+	requeueAfter, err := reconciler.handleNodeRollout(ctx, &csm)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), nodeRolloutPollInterval, requeueAfter)
+	condition := meta.FindStatusCondition(csm.Status.Conditions, csmv1.ConditionNodeRolloutPaused)
+	assert.Equal(suite.T(), metav1.ConditionFalse, condition.Status)
+	assert.Equal(suite.T(), csmv1.ReasonNodeRolloutInProgress, condition.Reason)
+	assert.Equal(suite.T(), "0/2 nodes updated, waiting for node-a", condition.Message)
+
+	// a paused rollout sets the condition and is reported once
+	for range 2 {
+		reconciler.nodeRollouts.Store(key, daemonset.NodeRolloutStatus{TotalNodes: 2, Pending: []string{"node-a"}, InProgress: true, Paused: true})
+		requeueAfter, err = reconciler.handleNodeRollout(ctx, &csm)
+		assert.NoError(suite.T(), err)
+		assert.Zero(suite.T(), requeueAfter)
+	}
+	assert.True(suite.T(), meta.IsStatusConditionTrue(csm.Status.Conditions, csmv1.ConditionNodeRolloutPaused))
+	assert.Contains(suite.T(), <-recorder.Events, "Node rollout paused, a batch was not updated within 10m0s: 0/2 nodes updated, waiting for node-a")
+	assert.Empty(suite.T(), recorder.Events)
+	found := &csmv1.ContainerStorageModule{}
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, client.ObjectKeyFromObject(&csm), found))
+	assert.True(suite.T(), meta.IsStatusConditionTrue(found.Status.Conditions, csmv1.ConditionNodeRolloutPaused))
+
+	// a complete rollout clears the condition
+	reconciler.nodeRollouts.Store(key, daemonset.NodeRolloutStatus{UpdatedNodes: 2, TotalNodes: 2})
+	requeueAfter, err = reconciler.handleNodeRollout(ctx, &csm)
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), requeueAfter)
+	condition = meta.FindStatusCondition(csm.Status.Conditions, csmv1.ConditionNodeRolloutPaused)
+	assert.Equal(suite.T(), csmv1.ReasonNodeRolloutComplete, condition.Reason)
+
+	// the condition is removed when the rollout is disabled
+	csm.Spec.Driver.NodeRollout = nil
+	_, err = reconciler.handleNodeRollout(ctx, &csm)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(csm.Status.Conditions, csmv1.ConditionNodeRolloutPaused))
+}
+
+func (suite *CSMControllerTestSuite) TestMultiHopUpgrade() {
+	csm := shared.MakeCSM(csmName, suite.namespace, "v2.17.1")
+	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
+	csm.Spec.Driver.Common = &csmv1.ContainerTemplate{Image: "quay.io/dell/container-storage-modules/csi-isilon:v2.17.1"}
+	csm.Annotations = map[string]string{operatorutils.ConfigVersionAnnotation: "v2.14.0"}
+
+	// the version cannot be reached directly, the error names the intermediate versions
+	valid, err := checkUpgradePath(ctx, &csm, "v2.14.0", "v2.17.1", csmv1.PowerScaleName, operatorConfig)
+	assert.False(suite.T(), valid)
+	assert.ErrorContains(suite.T(), err, "it can be reached through v2.16.0, v2.17.1 with spec.multiHopUpgrade")
+	assert.ErrorIs(suite.T(), err, operatorutils.ErrUpgradeBlocked)
+	assert.Equal(suite.T(), []string{"v2.16.0", "v2.17.1"}, csm.Status.UpgradePath)
+	assert.Empty(suite.T(), getUpgradeHop(&csm))
+
+	// with spec.multiHopUpgrade, the first hop is installed with its default images
+	csm.Spec.MultiHopUpgrade = true
+	valid, err = checkUpgradePath(ctx, &csm, "v2.14.0", "v2.17.1", csmv1.PowerScaleName, operatorConfig)
+	assert.True(suite.T(), valid)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "v2.16.0", getUpgradeHop(&csm))
+	csm.Spec.Modules = []csmv1.Module{
+		{Name: csmv1.Resiliency, Enabled: true, ConfigVersion: "v1.15.0", Components: []csmv1.PodTemplate{{ContainerTemplate: csmv1.ContainerTemplate{Name: "podmon", Image: "podmon:pinned"}}}},
+		{Name: csmv1.Observability, Enabled: true, Components: []csmv1.PodTemplate{{ContainerTemplate: csmv1.ContainerTemplate{Name: "topology", Image: "topology:latest"}}}},
+	}
+	hopCSM := withUpgradeHop(&csm)
+	assert.Equal(suite.T(), "v2.16.0", hopCSM.Spec.Driver.ConfigVersion)
+	assert.Empty(suite.T(), hopCSM.Spec.Driver.Common.Image)
+	assert.Equal(suite.T(), "v2.17.1", csm.Spec.Driver.ConfigVersion)
+	// a pinned module keeps its version and images, the others follow the hop
+	assert.Equal(suite.T(), "v1.15.0", hopCSM.Spec.Modules[0].ConfigVersion)
+	assert.Equal(suite.T(), csmv1.ImageType("podmon:pinned"), hopCSM.Spec.Modules[0].Components[0].Image)
+	assert.Empty(suite.T(), hopCSM.Spec.Modules[1].ConfigVersion)
+	assert.Empty(suite.T(), hopCSM.Spec.Modules[1].Components[0].Image)
+	csm.Spec.Modules = nil
+
+	// a Succeeded state does not move on from a hop that is not installed yet
+	csm.Status.State = constants.Succeeded
+	_, err = checkUpgradePath(ctx, &csm, "v2.14.0", "v2.17.1", csmv1.PowerScaleName, operatorConfig)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"v2.16.0", "v2.17.1"}, csm.Status.UpgradePath)
+	assert.Nil(suite.T(), suite.fakeClient.Create(ctx, &csm))
+	reconciler := suite.createReconciler()
+	advanced, err := reconciler.advanceUpgradeHop(ctx, &csm)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), advanced)
+
+	// the hop stays first until the status calculated after installing it is Succeeded
+	csm.Annotations[operatorutils.ConfigVersionAnnotation] = "v2.16.0"
+	csm.Status.State = constants.Failed
+	_, err = checkUpgradePath(ctx, &csm, "v2.16.0", "v2.17.1", csmv1.PowerScaleName, operatorConfig)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"v2.16.0", "v2.17.1"}, csm.Status.UpgradePath)
+	advanced, err = reconciler.advanceUpgradeHop(ctx, &csm)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), advanced)
+
+	// then the requested version is installed
+	csm.Status.State = constants.Succeeded
+	advanced, err = reconciler.advanceUpgradeHop(ctx, &csm)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), advanced)
+	assert.Equal(suite.T(), []string{"v2.17.1"}, csm.Status.UpgradePath)
+	stored := &csmv1.ContainerStorageModule{}
+	assert.Nil(suite.T(), suite.fakeClient.Get(ctx, types.NamespacedName{Name: csm.Name, Namespace: csm.Namespace}, stored))
+	assert.Equal(suite.T(), []string{"v2.17.1"}, stored.Status.UpgradePath)
+	valid, err = checkUpgradePath(ctx, &csm, "v2.16.0", "v2.17.1", csmv1.PowerScaleName, operatorConfig)
+	assert.True(suite.T(), valid)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"v2.17.1"}, csm.Status.UpgradePath)
+	assert.Empty(suite.T(), getUpgradeHop(&csm))
+	assert.Same(suite.T(), &csm, withUpgradeHop(&csm))
+
+	// the path is cleared once the requested version is installed
+	_, err = checkUpgradePath(ctx, &csm, "v2.17.1", "v2.17.1", csmv1.PowerScaleName, operatorConfig)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), csm.Status.UpgradePath)
+}
+
+func (suite *CSMControllerTestSuite) TestReconcileUpgradeHopKeepsSpec() {
+	suite.makeFakeCSM(csmName, suite.namespace, true, []csmv1.Module{})
+	orig := k8s.GetClientSetWrapper
+	defer func() { k8s.GetClientSetWrapper = orig }()
+	k8s.GetClientSetWrapper = func() (kubernetes.Interface, error) {
+		return k8sfake.NewClientset(), nil
+	}
+
+	csm := &csmv1.ContainerStorageModule{}
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	csm.Annotations = map[string]string{operatorutils.ConfigVersionAnnotation: "v2.14.0"}
+	csm.Spec.Driver.ConfigVersion = "v2.17.1"
+	csm.Spec.Driver.Common.Image = "quay.io/dell/container-storage-modules/csi-isilon:v2.17.1"
+	csm.Spec.MultiHopUpgrade = true
+	assert.NoError(suite.T(), suite.fakeClient.Update(ctx, csm))
+	spec := csm.Spec.DeepCopy()
+
+	reconciler := suite.createReconciler()
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(suite.T(), err)
+
+	// the hop is installed, but the stored spec is the requested one
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	assert.Equal(suite.T(), []string{"v2.16.0", "v2.17.1"}, csm.Status.UpgradePath)
+	assert.Equal(suite.T(), "v2.16.0", csm.Annotations[operatorutils.ConfigVersionAnnotation])
+	assert.Equal(suite.T(), "v2.17.1", csm.Spec.Driver.ConfigVersion)
+	assert.Equal(suite.T(), spec.Driver.Common.Image, csm.Spec.Driver.Common.Image)
+	assert.Equal(suite.T(), spec.Modules, csm.Spec.Modules)
+	controller := &appsv1.Deployment{}
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, types.NamespacedName{Name: csmName + "-controller", Namespace: suite.namespace}, controller))
+	for _, c := range controller.Spec.Template.Spec.Containers {
+		if c.Name == "driver" {
+			assert.Contains(suite.T(), c.Image, "v2.16.0")
+		}
+	}
+}
+
 func (suite *CSMControllerTestSuite) TestAuthorizationServerReconcile() {
 	suite.makeFakeAuthServerCSM(csmName, suite.namespace, getAuthProxyServer())
 	suite.runFakeAuthCSMManager("context deadline exceeded", false, false)
@@ -474,25 +881,41 @@ func (suite *CSMControllerTestSuite) TestResiliencyReconcileError() {
 	}
 }
 
-func (suite *CSMControllerTestSuite) TestContentWatch() {
-	// Arrange
-	csm := shared.MakeCSM(csmName, suite.namespace, shared.PmaxConfigVersion)
-	reconciler := suite.createReconciler()
-
+func (suite *CSMControllerTestSuite) TestCacheOptions() {
 	// test case: environment variable set to non-default val
 	os.Setenv(RefreshEnvVar, "3")
-	_, err := reconciler.ContentWatch(&csm)
-	assert.Nil(suite.T(), err)
+	options := CacheOptions("dell-csm-operator")
+	assert.Equal(suite.T(), 3*time.Minute, *options.SyncPeriod)
+	assert.Len(suite.T(), options.ByObject, 4)
+	for object, byObject := range options.ByObject {
+		if _, ok := object.(*corev1.ConfigMap); ok {
+			// only the template bundles of the bundle namespace are cached
+			assert.True(suite.T(), byObject.Label.Matches(labels.Set{templatebundle.Label: "true"}))
+			assert.False(suite.T(), byObject.Label.Matches(labels.Set{templatebundle.Label: "false"}))
+			assert.False(suite.T(), byObject.Label.Matches(labels.Set{constants.CsmLabel: csmName}))
+			assert.Equal(suite.T(), map[string]cache.Config{"dell-csm-operator": {}}, byObject.Namespaces)
+			continue
+		}
+		assert.True(suite.T(), byObject.Label.Matches(labels.Set{constants.CsmLabel: csmName, constants.CsmNamespaceLabel: suite.namespace}))
+		assert.False(suite.T(), byObject.Label.Matches(labels.Set{constants.CsmLabel: csmName}))
+		assert.False(suite.T(), byObject.Label.Matches(labels.Set{"app": "cert-manager"}))
+	}
 
 	// test case: environment variable set to non-number val
 	os.Setenv(RefreshEnvVar, "dummy")
-	_, err = reconciler.ContentWatch(&csm)
-	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 60*time.Minute, *CacheOptions("").SyncPeriod)
 
 	// test case: environment variable unset
 	os.Unsetenv(RefreshEnvVar)
-	_, err = reconciler.ContentWatch(&csm)
-	assert.Nil(suite.T(), err)
+	options = CacheOptions("")
+	assert.Equal(suite.T(), 60*time.Minute, *options.SyncPeriod)
+	for object, byObject := range options.ByObject {
+		if _, ok := object.(*corev1.ConfigMap); ok {
+			assert.Empty(suite.T(), byObject.Namespaces)
+		}
+	}
+
+	assert.Len(suite.T(), ClientOptions().Cache.DisableFor, 5)
 }
 
 func (suite *CSMControllerTestSuite) TestReverseProxyReconcile() {
@@ -740,8 +1163,8 @@ func (suite *CSMControllerTestSuite) TestCsmUpgrade() {
 		annotations = make(map[string]string)
 	}
 
-	if annotations[configVersionKey] != configVersion {
-		annotations[configVersionKey] = configVersion
+	if annotations[operatorutils.ConfigVersionAnnotation] != configVersion {
+		annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 		csm.SetAnnotations(annotations)
 	}
 
@@ -770,8 +1193,8 @@ func (suite *CSMControllerTestSuite) TestCsmUpgradeVersionTooOld() {
 		annotations = make(map[string]string)
 	}
 
-	if annotations[configVersionKey] != configVersion {
-		annotations[configVersionKey] = configVersion
+	if annotations[operatorutils.ConfigVersionAnnotation] != configVersion {
+		annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 		csm.SetAnnotations(annotations)
 	}
 
@@ -804,8 +1227,8 @@ func (suite *CSMControllerTestSuite) TestCsmUpgradeSkipVersion() {
 		annotations = make(map[string]string)
 	}
 
-	if annotations[configVersionKey] != configVersion {
-		annotations[configVersionKey] = configVersion
+	if annotations[operatorutils.ConfigVersionAnnotation] != configVersion {
+		annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 		csm.SetAnnotations(annotations)
 	}
 	csm.Spec.Driver.ConfigVersion = jumpUpgradeConfigVersion
@@ -837,8 +1260,8 @@ func (suite *CSMControllerTestSuite) TestCsmUpgradePathInvalid() {
 		annotations = make(map[string]string)
 	}
 
-	if annotations[configVersionKey] != configVersion {
-		annotations[configVersionKey] = configVersion
+	if annotations[operatorutils.ConfigVersionAnnotation] != configVersion {
+		annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 		csm.SetAnnotations(annotations)
 	}
 
@@ -871,8 +1294,8 @@ func (suite *CSMControllerTestSuite) TestCsmDowngrade() {
 		annotations = make(map[string]string)
 	}
 
-	if annotations[configVersionKey] != pFlexConfigVersion {
-		annotations[configVersionKey] = pFlexConfigVersion
+	if annotations[operatorutils.ConfigVersionAnnotation] != pFlexConfigVersion {
+		annotations[operatorutils.ConfigVersionAnnotation] = pFlexConfigVersion
 		csm.SetAnnotations(annotations)
 	}
 
@@ -883,6 +1306,43 @@ func (suite *CSMControllerTestSuite) TestCsmDowngrade() {
 	assert.Nil(suite.T(), err)
 }
 
+func (suite *CSMControllerTestSuite) TestReconcileBundleOnlyVersion() {
+	csm := shared.MakeCSM(csmName, suite.namespace, "v2.17.2")
+	csm.Spec.Driver.Common.Image = "image"
+	csm.Spec.Driver.CSIDriverType = csmv1.PowerFlex
+	csm.ObjectMeta.Finalizers = []string{CSMFinalizerName}
+	assert.NoError(suite.T(), suite.fakeClient.Create(ctx, &csm))
+	assert.NoError(suite.T(), suite.fakeClient.Create(ctx, shared.MakeSecretPowerFlex(csmName+"-config", suite.namespace, pFlexConfigVersion)))
+
+	orig := k8s.GetClientSetWrapper
+	defer func() { k8s.GetClientSetWrapper = orig }()
+	k8s.GetClientSetWrapper = func() (kubernetes.Interface, error) {
+		return k8sfake.NewClientset(), nil
+	}
+
+	// the version is not in the config directory
+	reconciler := suite.createReconciler()
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.ErrorContains(suite.T(), err, "v2.17.2 not supported")
+
+	// a template bundle adds it
+	files := map[string][]byte{}
+	entries, err := os.ReadDir(filepath.Join(operatorConfig.ConfigDirectory, "driverconfig", "powerflex", "v2.17.0"))
+	assert.NoError(suite.T(), err)
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(operatorConfig.ConfigDirectory, "driverconfig", "powerflex", "v2.17.0", entry.Name()))
+		assert.NoError(suite.T(), err)
+		files[filepath.Join("driverconfig", "powerflex", "v2.17.2", entry.Name())] = data
+	}
+	templates := operatorutils.Templates(operatorConfig.ConfigDirectory)
+	assert.NoError(suite.T(), templates.SetBundle("configmap/powerflex-v2.17.2", files))
+	defer templates.RemoveBundle("configmap/powerflex-v2.17.2")
+
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, types.NamespacedName{Name: csmName + "-controller", Namespace: suite.namespace}, &appsv1.Deployment{}))
+}
+
 func (suite *CSMControllerTestSuite) TestCsmDowngradeVersionTooOld() {
 	csm := shared.MakeCSM(csmName, suite.namespace, pFlexConfigVersion)
 	csm.Spec.Driver.Common.Image = "image"
@@ -905,8 +1365,8 @@ func (suite *CSMControllerTestSuite) TestCsmDowngradeVersionTooOld() {
 		annotations = make(map[string]string)
 	}
 
-	if annotations[configVersionKey] != pFlexConfigVersion {
-		annotations[configVersionKey] = pFlexConfigVersion
+	if annotations[operatorutils.ConfigVersionAnnotation] != pFlexConfigVersion {
+		annotations[operatorutils.ConfigVersionAnnotation] = pFlexConfigVersion
 		csm.SetAnnotations(annotations)
 	}
 
@@ -939,8 +1399,8 @@ func (suite *CSMControllerTestSuite) TestCsmDowngradeSkipVersion() {
 		annotations = make(map[string]string)
 	}
 
-	if annotations[configVersionKey] != pFlexConfigVersion {
-		annotations[configVersionKey] = pFlexConfigVersion
+	if annotations[operatorutils.ConfigVersionAnnotation] != pFlexConfigVersion {
+		annotations[operatorutils.ConfigVersionAnnotation] = pFlexConfigVersion
 		csm.SetAnnotations(annotations)
 	}
 
@@ -973,8 +1433,8 @@ func (suite *CSMControllerTestSuite) TestCsmDowngradePathInvalid() {
 		annotations = make(map[string]string)
 	}
 
-	if annotations[configVersionKey] != pFlexConfigVersion {
-		annotations[configVersionKey] = pFlexConfigVersion
+	if annotations[operatorutils.ConfigVersionAnnotation] != pFlexConfigVersion {
+		annotations[operatorutils.ConfigVersionAnnotation] = pFlexConfigVersion
 		csm.SetAnnotations(annotations)
 	}
 
@@ -1277,7 +1737,7 @@ func (suite *CSMControllerTestSuite) TestCsmPreCheckVersionError() {
 	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
 	csm.Spec.Driver.Common.Image = "image"
 	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
-	csm.Annotations[configVersionKey] = configVersion
+	csm.Annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 
 	sec := shared.MakeSecret(csmName+"-creds", suite.namespace, configVersion)
 	err := suite.fakeClient.Create(ctx, sec)
@@ -1307,7 +1767,7 @@ func (suite *CSMControllerTestSuite) TestCsmPreCheckTypeError() {
 	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
 	csm.Spec.Driver.CSIDriverType = csmv1.PowerStore
 	csm.Spec.Driver.Common.Image = "image"
-	csm.Annotations[configVersionKey] = configVersion
+	csm.Annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 
 	sec := shared.MakeSecret(csmName+"-creds", suite.namespace, configVersion)
 	err := suite.fakeClient.Create(ctx, sec)
@@ -1337,7 +1797,7 @@ func (suite *CSMControllerTestSuite) TestCsmPreCheckModuleError() {
 	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
 	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
 	csm.Spec.Driver.Common.Image = "image"
-	csm.Annotations[configVersionKey] = configVersion
+	csm.Annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 
 	sec := shared.MakeSecret(csmName+"-creds", suite.namespace, configVersion)
 	err := suite.fakeClient.Create(ctx, sec)
@@ -1416,7 +1876,7 @@ func (suite *CSMControllerTestSuite) TestCsmPreCheckModuleUnsupportedVersion() {
 	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
 	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
 	csm.Spec.Driver.Common.Image = "image"
-	csm.Annotations[configVersionKey] = configVersion
+	csm.Annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 
 	sec := shared.MakeSecret(csmName+"-creds", suite.namespace, configVersion)
 	err := suite.fakeClient.Create(ctx, sec)
@@ -1509,185 +1969,98 @@ func (suite *CSMControllerTestSuite) createReconciler() (reconciler *ContainerSt
 	log.Infof("Version : %s", logType)
 
 	reconciler = &ContainerStorageModuleReconciler{
-		Client:               suite.fakeClient,
-		K8sClient:            suite.k8sClient,
-		Scheme:               scheme.Scheme,
-		Log:                  log,
-		Config:               operatorConfig,
-		EventRecorder:        record.NewFakeRecorder(100),
-		ContentWatchChannels: map[string]chan struct{}{},
-		ContentWatchLock:     sync.Mutex{},
+		Client:        suite.fakeClient,
+		K8sClient:     suite.k8sClient,
+		Scheme:        scheme.Scheme,
+		Log:           log,
+		Config:        operatorConfig,
+		EventRecorder: record.NewFakeRecorder(100),
 	}
 
 	return reconciler
 }
 
-func (suite *CSMControllerTestSuite) TestInformerUpdate() {
+func (suite *CSMControllerTestSuite) TestCSMRequestsForObject() {
 	tests := []struct {
-		csm        *csmv1.ContainerStorageModule
-		oldObj     interface{}
-		objType    string
-		wantCalled bool
+		name string
+		obj  client.Object
+		want []reconcile.Request
 	}{
 		{
-			csm: &csmv1.ContainerStorageModule{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "powermax",
-					Namespace: "powermax",
-				},
-			},
-			objType: "deployment",
-			oldObj: &appsv1.Deployment{
-				Spec: appsv1.DeploymentSpec{
-					Template: corev1.PodTemplateSpec{
-						ObjectMeta: metav1.ObjectMeta{
-							Labels: map[string]string{
-								constants.CsmLabel:          "powermax",
-								constants.CsmNamespaceLabel: "powermax",
-							},
-						},
-					},
-				},
-			},
-			wantCalled: true,
+			name: "deployment",
+			obj: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+				constants.CsmLabel:          "powermax",
+				constants.CsmNamespaceLabel: "powermax",
+			}}},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "powermax", Namespace: "powermax"}}},
 		},
 		{
-			csm: &csmv1.ContainerStorageModule{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "powermax",
-					Namespace: "powermax",
-				},
-			},
-			objType: "deployment",
-			oldObj: &appsv1.Deployment{
-				Spec: appsv1.DeploymentSpec{
-					Template: corev1.PodTemplateSpec{
-						ObjectMeta: metav1.ObjectMeta{
-							Labels: map[string]string{
-								constants.CsmLabel:          "powerflex",
-								constants.CsmNamespaceLabel: "powerflex",
-							},
-						},
-					},
-				},
-			},
-			wantCalled: false,
+			name: "observability pod in another namespace",
+			obj: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "karavi", Labels: map[string]string{
+				constants.CsmLabel:          "powerflex",
+				constants.CsmNamespaceLabel: "vxflexos",
+			}}},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "powerflex", Namespace: "vxflexos"}}},
 		},
 		{
-			csm: &csmv1.ContainerStorageModule{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "powermax",
-					Namespace: "powermax",
-				},
-			},
-			objType: "daemonset",
-			oldObj: &appsv1.DaemonSet{
-				Spec: appsv1.DaemonSetSpec{
-					Template: corev1.PodTemplateSpec{
-						ObjectMeta: metav1.ObjectMeta{
-							Labels: map[string]string{
-								constants.CsmLabel:          "powermax",
-								constants.CsmNamespaceLabel: "powermax",
-							},
-						},
-					},
-				},
-			},
-			wantCalled: true,
+			name: "daemonset without namespace label",
+			obj:  &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{constants.CsmLabel: "powermax"}}},
 		},
 		{
-			csm: &csmv1.ContainerStorageModule{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "powermax",
-					Namespace: "powermax",
-				},
-			},
-			objType: "daemonset",
-			oldObj: &appsv1.DaemonSet{
-				Spec: appsv1.DaemonSetSpec{
-					Template: corev1.PodTemplateSpec{
-						ObjectMeta: metav1.ObjectMeta{
-							Labels: map[string]string{
-								constants.CsmLabel:          "powerflex",
-								constants.CsmNamespaceLabel: "powerflex",
-							},
-						},
-					},
-				},
-			},
-			wantCalled: false,
-		},
-		{
-			csm: &csmv1.ContainerStorageModule{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "powermax",
-					Namespace: "powermax",
-				},
-			},
-			objType: "pod",
-			oldObj: &corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						constants.CsmLabel:          "powermax",
-						constants.CsmNamespaceLabel: "powermax",
-					},
-				},
-			},
-			wantCalled: true,
-		},
-		{
-			csm: &csmv1.ContainerStorageModule{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "powermax",
-					Namespace: "powermax",
-				},
-			},
-			objType: "pod",
-			oldObj: &corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						constants.CsmLabel:          "powerflex",
-						constants.CsmNamespaceLabel: "powerflex",
-					},
-				},
-			},
-			wantCalled: false,
+			name: "pod without labels",
+			obj:  &corev1.Pod{},
 		},
 	}
-
-	handleDaemonsetFnCalled := false
-	handleDaemonsetFn := func(_ interface{}, _ interface{}) {
-		handleDaemonsetFnCalled = true
+	for _, test := range tests {
+		suite.T().Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, csmRequestsForObject(ctx, test.obj))
+		})
 	}
+}
 
-	handleDeploymentFnCalled := false
-	handleDeploymentFn := func(_ interface{}, _ interface{}) {
-		handleDeploymentFnCalled = true
+func (suite *CSMControllerTestSuite) TestComponentStatusPredicate() {
+	p := componentStatusPredicate()
+	old := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{ResourceVersion: "1"},
+		Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, NumberReady: 1},
 	}
 
-	handlePodFnCalled := false
-	handlePodFn := func(_ interface{}, _ interface{}) {
-		handlePodFnCalled = true
-	}
+	// a change of the spec or of the metadata only does not requeue the CSM
+	updated := old.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Annotations = map[string]string{"key": "value"}
+	assert.False(suite.T(), p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated}))
 
-	reset := func() {
-		handleDaemonsetFnCalled = false
-		handleDeploymentFnCalled = false
-		handlePodFnCalled = false
-	}
-	r := &ContainerStorageModuleReconciler{}
-	for _, test := range tests {
-		r.informerUpdate(test.csm, test.oldObj, nil, handleDaemonsetFn, handleDeploymentFn, handlePodFn)
-		switch test.objType {
-		case "deployment":
-			assert.Equal(suite.T(), test.wantCalled, handleDeploymentFnCalled)
-		case "daemonset":
-			assert.Equal(suite.T(), test.wantCalled, handleDaemonsetFnCalled)
-		case "pod":
-			assert.Equal(suite.T(), test.wantCalled, handlePodFnCalled)
-		}
-		reset()
-	}
+	// a change of the status does
+	updated.Status.NumberReady = 2
+	assert.True(suite.T(), p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated}))
+
+	// and so does a periodic resync
+	assert.True(suite.T(), p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: old}))
+
+	// terminating components, creations and deletions are ignored
+	terminating := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "1", DeletionTimestamp: &metav1.Time{Time: time.Now()}}}
+	assert.False(suite.T(), p.Update(event.UpdateEvent{ObjectOld: terminating, ObjectNew: terminating}))
+	assert.False(suite.T(), p.Create(event.CreateEvent{Object: old}))
+	assert.False(suite.T(), p.Delete(event.DeleteEvent{Object: old}))
+}
+
+func (suite *CSMControllerTestSuite) TestStatusReconcile() {
+	suite.makeFakeCSM(csmName, suite.namespace, true, []csmv1.Module{})
+	reconciler := &csmStatusReconciler{suite.createReconciler()}
+
+	// the status is refreshed, but the spec is not applied
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(suite.T(), err)
+	csm := &csmv1.ContainerStorageModule{}
+	assert.NoError(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, csm))
+	assert.Equal(suite.T(), constants.Failed, csm.Status.State)
+	err = suite.fakeClient.Get(ctx, types.NamespacedName{Name: csmName + "-controller", Namespace: suite.namespace}, &appsv1.Deployment{})
+	assert.True(suite.T(), k8sErrors.IsNotFound(err))
+
+	// a CSM that no longer exists is ignored
+	_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "missing", Namespace: suite.namespace}})
+	assert.NoError(suite.T(), err)
 }
 
 func (suite *CSMControllerTestSuite) runFakeCSMManager(expectedErr string, reconcileDelete bool) {
@@ -1709,15 +2082,14 @@ func (suite *CSMControllerTestSuite) runFakeCSMManager(expectedErr string, recon
 		assert.True(suite.T(), strings.Contains(err.Error(), expectedErr))
 	}
 
-	// after reconcile being run, we update deployment and daemonset
-	// then call handleDeployment/DaemonsetUpdate explicitly because
-	// in unit test listener does not get triggered
+	// after reconcile being run, we create the driver pods and reconcile
+	// again explicitly, since in unit test the watches do not enqueue the CSM
 	// If delete, we shouldn't call these methods since reconcile
 	// would return before this
 	if !reconcileDelete {
-		suite.handleDaemonsetTest(reconciler, "csm-node")
-		suite.handleDeploymentTest(reconciler, "csm-controller")
-		suite.handlePodTest(reconciler, "csm-pod")
+		suite.handleDaemonsetTest("csm-node")
+		suite.handleDeploymentTest("csm-controller")
+		suite.handlePodTest("csm-pod")
 		_, err = reconciler.Reconcile(ctx, req)
 		if expectedErr == "" {
 			assert.NoError(suite.T(), err)
@@ -1749,15 +2121,14 @@ func (suite *CSMControllerTestSuite) runFakeCSMManagerError(expectedErr string,
 		assert.True(suite.T(), strings.Contains(err.Error(), expectedErr))
 	}
 
-	// after reconcile being run, we update deployment and daemonset
-	// then call handleDeployment/DaemonsetUpdate explicitly because
-	// in unit test listener does not get triggered
+	// after reconcile being run, we create the driver pods and reconcile
+	// again explicitly, since in unit test the watches do not enqueue the CSM
 	// If delete, we shouldn't call these methods since reconcile
 	// would return before this
 	if !reconcileDelete {
-		suite.handleDaemonsetTestFake(reconciler, "csm-node")
-		suite.handleDeploymentTestFake(reconciler, "csm-controller")
-		suite.handlePodTest(reconciler, "")
+		suite.handleDaemonsetTestFake("csm-node")
+		suite.handleDeploymentTestFake("csm-controller")
+		suite.handlePodTest("")
 		_, err = reconciler.Reconcile(ctx, req)
 		assert.Nil(suite.T(), err)
 
@@ -1787,7 +2158,7 @@ func (suite *CSMControllerTestSuite) runFakeAuthCSMManager(expectedErr string, r
 	}
 
 	if !reconcileDelete {
-		suite.handlePodTest(reconciler, "csm-pod")
+		suite.handlePodTest("csm-pod")
 		_, err = reconciler.Reconcile(ctx, req)
 		if expectedErr == "" {
 			assert.NoError(suite.T(), err)
@@ -1916,14 +2287,12 @@ func (suite *CSMControllerTestSuite) reconcileWithErrorInjection(_, expectedErr
 	deleteSAError = false
 }
 
-func (suite *CSMControllerTestSuite) handleDaemonsetTest(r *ContainerStorageModuleReconciler, name string) {
+func (suite *CSMControllerTestSuite) handleDaemonsetTest(name string) {
 	daemonset := &appsv1.DaemonSet{}
 	err := suite.fakeClient.Get(ctx, client.ObjectKey{Namespace: suite.namespace, Name: name}, daemonset)
 	assert.Nil(suite.T(), err)
 	daemonset.Spec.Template.Labels = map[string]string{"csm": "csm", "csmNamespace": suite.namespace}
 
-	r.handleDaemonsetUpdate(daemonset, daemonset)
-
 	// Make Pod and set status
 	pod := shared.MakePod(name, suite.namespace)
 	pod.Labels["csm"] = csmName
@@ -1944,14 +2313,12 @@ func (suite *CSMControllerTestSuite) handleDaemonsetTest(r *ContainerStorageModu
 	assert.Nil(suite.T(), err)
 }
 
-func (suite *CSMControllerTestSuite) handleDeploymentTest(r *ContainerStorageModuleReconciler, name string) {
+func (suite *CSMControllerTestSuite) handleDeploymentTest(name string) {
 	deployment := &appsv1.Deployment{}
 	err := suite.fakeClient.Get(ctx, client.ObjectKey{Namespace: suite.namespace, Name: name}, deployment)
 	assert.Nil(suite.T(), err)
 	deployment.Spec.Template.Labels = map[string]string{"csm": "csm", "csmNamespace": suite.namespace}
 
-	r.handleDeploymentUpdate(deployment, deployment)
-
 	// Make Pod and set pod status
 	pod := shared.MakePod(name, suite.namespace)
 	pod.Labels["csm"] = csmName
@@ -1972,8 +2339,6 @@ func (suite *CSMControllerTestSuite) handleDeploymentTest(r *ContainerStorageMod
 	err = suite.fakeClient.List(ctx, podList, nil)
 	assert.Nil(suite.T(), err)
 
-	r.handlePodsUpdate(nil, &pod)
-
 	pod.Status.Phase = corev1.PodRunning
 	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
 		{
@@ -1982,23 +2347,16 @@ func (suite *CSMControllerTestSuite) handleDeploymentTest(r *ContainerStorageMod
 			},
 		},
 	}
-
-	pod.Labels["csm"] = "test"
-	pod.Labels[constants.CsmNamespaceLabel] = "test"
-	r.handlePodsUpdate(nil, &pod)
-
-	pod.ObjectMeta.DeletionTimestamp = &metav1.Time{Time: time.Now()}
-	r.handlePodsUpdate(nil, &pod)
+	err = suite.fakeClient.Update(ctx, &pod)
+	assert.Nil(suite.T(), err)
 }
 
-func (suite *CSMControllerTestSuite) handleDaemonsetTestFake(r *ContainerStorageModuleReconciler, name string) {
+func (suite *CSMControllerTestSuite) handleDaemonsetTestFake(name string) {
 	daemonset := &appsv1.DaemonSet{}
 	err := suite.fakeClient.Get(ctx, client.ObjectKey{Namespace: suite.namespace, Name: name}, daemonset)
 	assert.Error(suite.T(), err)
 	daemonset.Spec.Template.Labels = map[string]string{"csm": "csm", "csmNamespace": suite.namespace}
 
-	r.handleDaemonsetUpdate(daemonset, daemonset)
-
 	// Make Pod and set status
 	pod := shared.MakePod(name, suite.namespace)
 	pod.Labels["csm"] = csmName
@@ -2019,14 +2377,12 @@ func (suite *CSMControllerTestSuite) handleDaemonsetTestFake(r *ContainerStorage
 	assert.Nil(suite.T(), err)
 }
 
-func (suite *CSMControllerTestSuite) handleDeploymentTestFake(r *ContainerStorageModuleReconciler, name string) {
+func (suite *CSMControllerTestSuite) handleDeploymentTestFake(name string) {
 	deployment := &appsv1.Deployment{}
 	err := suite.fakeClient.Get(ctx, client.ObjectKey{Namespace: suite.namespace, Name: name}, deployment)
 	assert.Error(suite.T(), err)
 	deployment.Spec.Template.Labels = map[string]string{"csm": "csm", "csmNamespace": suite.namespace}
 
-	r.handleDeploymentUpdate(deployment, deployment)
-
 	// Make Pod and set pod status
 	pod := shared.MakePod(name, suite.namespace)
 	pod.Labels["csm"] = csmName
@@ -2047,15 +2403,13 @@ func (suite *CSMControllerTestSuite) handleDeploymentTestFake(r *ContainerStorag
 	assert.Nil(suite.T(), err)
 }
 
-func (suite *CSMControllerTestSuite) handlePodTest(r *ContainerStorageModuleReconciler, name string) {
+func (suite *CSMControllerTestSuite) handlePodTest(name string) {
+	// since deployments/daemonsets dont create pod in non-k8s env, we have to explicitely create pod
 	suite.makeFakePod(name, suite.namespace)
 	pod := &corev1.Pod{}
 
 	err := suite.fakeClient.Get(ctx, client.ObjectKey{Namespace: suite.namespace, Name: name}, pod)
 	assert.Nil(suite.T(), err)
-
-	// since deployments/daemonsets dont create pod in non-k8s env, we have to explicitely create pod
-	r.handlePodsUpdate(pod, pod)
 }
 
 // deleteCSM sets deletionTimeStamp on the csm object and deletes it
@@ -2082,8 +2436,8 @@ func getObservabilityModule() []csmv1.Module {
 			Name:          csmv1.Observability,
 			Enabled:       true,
 			ConfigVersion: "v1.13.0",
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    "topology",
 					Enabled: &[]bool{true}[0],
 					Envs: []corev1.EnvVar{
@@ -2092,8 +2446,8 @@ func getObservabilityModule() []csmv1.Module {
 							Value: "INFO",
 						},
 					},
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    "otel-collector",
 					Enabled: &[]bool{true}[0],
 					Envs: []corev1.EnvVar{
@@ -2102,8 +2456,8 @@ func getObservabilityModule() []csmv1.Module {
 							Value: "quay.io/nginx/nginx-unprivileged:1.27",
 						},
 					},
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    "metrics-powerscale",
 					Enabled: &[]bool{true}[0],
 					Envs: []corev1.EnvVar{
@@ -2112,8 +2466,8 @@ func getObservabilityModule() []csmv1.Module {
 							Value: "10",
 						},
 					},
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    "metrics-powerflex",
 					Enabled: &[]bool{true}[0],
 					Envs: []corev1.EnvVar{
@@ -2122,7 +2476,7 @@ func getObservabilityModule() []csmv1.Module {
 							Value: "10",
 						},
 					},
-				},
+				}},
 			},
 		},
 	}
@@ -2134,10 +2488,10 @@ func getReplicaModule() []csmv1.Module {
 			Name:          csmv1.Replication,
 			Enabled:       true,
 			ConfigVersion: "v1.15.0",
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name: operatorutils.ReplicationSideCarName,
-				},
+				}},
 			},
 		},
 	}
@@ -2149,10 +2503,10 @@ func getResiliencyModule() []csmv1.Module {
 			Name:          csmv1.Resiliency,
 			Enabled:       true,
 			ConfigVersion: "v1.16.0",
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name: operatorutils.ResiliencySideCarName,
-				},
+				}},
 			},
 		},
 	}
@@ -2164,8 +2518,8 @@ func getAuthModule() []csmv1.Module {
 			Name:          csmv1.Authorization,
 			Enabled:       true,
 			ConfigVersion: "v2.5.0",
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name: "karavi-authorization-proxy",
 					Envs: []corev1.EnvVar{
 						{
@@ -2173,7 +2527,7 @@ func getAuthModule() []csmv1.Module {
 							Value: "true",
 						},
 					},
-				},
+				}},
 			},
 		},
 	}
@@ -2186,8 +2540,8 @@ func getAuthProxyServer() []csmv1.Module {
 			Enabled:           true,
 			ConfigVersion:     shared.AuthServerConfigVersion,
 			ForceRemoveModule: true,
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:     "proxy-server",
 					Enabled:  &[]bool{true}[0],
 					Hostname: "csm-auth.com",
@@ -2198,26 +2552,26 @@ func getAuthProxyServer() []csmv1.Module {
 							Annotations:      map[string]string{"test": "test"},
 						},
 					},
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    "cert-manager",
 					Enabled: &[]bool{true}[0],
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    "nginx",
 					Enabled: &[]bool{true}[0],
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:          "redis",
 					RedisUsername: "test-user",
 					RedisPassword: "test-password",
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name: "storage-system-credentials",
 					SecretProviderClasses: &csmv1.StorageSystemSecretProviderClasses{
 						Vaults: []string{"secret-provider-class-1", "secret-provider-class-2"},
 					},
-				},
+				}},
 			},
 		},
 	}
@@ -2230,8 +2584,8 @@ func getAuthProxyServerOCP() []csmv1.Module {
 			Enabled:           true,
 			ConfigVersion:     shared.AuthServerConfigVersion,
 			ForceRemoveModule: true,
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:     "proxy-server",
 					Enabled:  &[]bool{true}[0],
 					Hostname: "csm-auth.com",
@@ -2242,26 +2596,26 @@ func getAuthProxyServerOCP() []csmv1.Module {
 							Annotations:      map[string]string{"test": "test"},
 						},
 					},
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    "cert-manager",
 					Enabled: &[]bool{true}[0],
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    "nginx-gateway-fabric",
 					Enabled: &[]bool{false}[0],
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:          "redis",
 					RedisUsername: "test-user",
 					RedisPassword: "test-password",
-				},
-				{
+				}},
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name: "storage-system-credentials",
 					SecretProviderClasses: &csmv1.StorageSystemSecretProviderClasses{
 						Vaults: []string{"secret-provider-class-1", "secret-provider-class-2"},
 					},
-				},
+				}},
 			},
 		},
 	}
@@ -2273,8 +2627,8 @@ func getReverseProxyModule() []csmv1.Module {
 			Name:          csmv1.ReverseProxy,
 			Enabled:       true,
 			ConfigVersion: "v2.16.0",
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    string(csmv1.ReverseProxyServer),
 					Enabled: &[]bool{true}[0],
 					Envs: []corev1.EnvVar{
@@ -2291,7 +2645,7 @@ func getReverseProxyModule() []csmv1.Module {
 							Value: "powermax-reverseproxy-config",
 						},
 					},
-				},
+				}},
 			},
 			ForceRemoveModule: true,
 		},
@@ -2304,8 +2658,8 @@ func getReverseProxyModuleWithSecret() []csmv1.Module {
 			Name:          csmv1.ReverseProxy,
 			Enabled:       true,
 			ConfigVersion: "v2.16.1",
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    string(csmv1.ReverseProxyServer),
 					Enabled: &[]bool{true}[0],
 					Envs: []corev1.EnvVar{
@@ -2330,7 +2684,7 @@ func getReverseProxyModuleWithSecret() []csmv1.Module {
 							Value: "true",
 						},
 					},
-				},
+				}},
 			},
 			ForceRemoveModule: true,
 		},
@@ -2381,8 +2735,8 @@ func (suite *CSMControllerTestSuite) TestReconcileObservabilityErrorBadComponent
 	csm.Spec.Modules = getObservabilityModule()
 	reconciler := suite.createReconciler()
 
-	badComponent := []csmv1.ContainerTemplate{
-		{
+	badComponent := []csmv1.PodTemplate{
+		{ContainerTemplate: csmv1.ContainerTemplate{
 			Name:    "fake-news",
 			Enabled: &[]bool{true}[0],
 			Envs: []corev1.EnvVar{
@@ -2391,7 +2745,7 @@ func (suite *CSMControllerTestSuite) TestReconcileObservabilityErrorBadComponent
 					Value: "INFO",
 				},
 			},
-		},
+		}},
 	}
 
 	goodModules := csm.Spec.Modules[0].Components
@@ -2510,19 +2864,6 @@ func (suite *CSMControllerTestSuite) TestReconcileAuthorizationNginxIngress() {
 	assert.Nil(suite.T(), err)
 }
 
-// TestInformerUpdateDefaultCase covers the default branch of informerUpdate (line 656-657)
-func (suite *CSMControllerTestSuite) TestInformerUpdateDefaultCase() {
-	r := suite.createReconciler()
-	csm := &csmv1.ContainerStorageModule{
-		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
-	}
-	called := false
-	fn := func(_ interface{}, _ interface{}) { called = true }
-	// Pass a string (unsupported type) as oldObj to trigger the default case
-	r.informerUpdate(csm, "unsupported-type", nil, fn, fn, fn)
-	assert.False(suite.T(), called)
-}
-
 // TestApplyConfigVersionAnnotationsGetVersionError covers line 1738-1740
 func (suite *CSMControllerTestSuite) TestApplyConfigVersionAnnotationsGetVersionError() {
 	csm := shared.MakeCSM(csmName, suite.namespace, "")
@@ -2611,46 +2952,6 @@ func (suite *CSMControllerTestSuite) TestReconcileDeleteModuleError() {
 	assert.Contains(suite.T(), err.Error(), "authorization")
 }
 
-// TestReconcileDeleteContentWatchCleanup covers lines 334-337
-func (suite *CSMControllerTestSuite) TestReconcileDeleteContentWatchCleanup() {
-	// Create a valid CSM with finalizer
-	sec := shared.MakeSecret(csmName+"-creds", suite.namespace, configVersion)
-	err := suite.fakeClient.Create(ctx, sec)
-	assert.Nil(suite.T(), err)
-
-	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
-	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
-	csm.Spec.Driver.Common.Image = "image"
-	truebool := true
-	csm.Spec.Driver.ForceRemoveDriver = &truebool
-	csm.ObjectMeta.Finalizers = []string{CSMFinalizerName}
-	err = suite.fakeClient.Create(ctx, &csm)
-	assert.Nil(suite.T(), err)
-
-	// First reconcile to set things up
-	reconciler := suite.createReconciler()
-	_, err = reconciler.Reconcile(ctx, req)
-	assert.Nil(suite.T(), err)
-
-	// Pre-populate ContentWatchChannels
-	stopCh := make(chan struct{})
-	reconciler.ContentWatchChannels[csmName] = stopCh
-
-	// Delete the CSM
-	csmObj := &csmv1.ContainerStorageModule{}
-	key := types.NamespacedName{Namespace: suite.namespace, Name: csmName}
-	err = suite.fakeClient.Get(ctx, key, csmObj)
-	assert.Nil(suite.T(), err)
-	err = suite.fakeClient.(*crclient.Client).SetDeletionTimeStamp(ctx, csmObj)
-	assert.Nil(suite.T(), err)
-
-	_, err = reconciler.Reconcile(ctx, req)
-	assert.Nil(suite.T(), err)
-	// Verify the channel was removed
-	_, exists := reconciler.ContentWatchChannels[csmName]
-	assert.False(suite.T(), exists)
-}
-
 // TestReconcileUpdateStatusErrorNonUT covers lines 374-378
 func (suite *CSMControllerTestSuite) TestReconcileUpdateStatusErrorNonUT() {
 	sec := shared.MakeSecret(csmName+"-creds", suite.namespace, configVersion)
@@ -3054,6 +3355,233 @@ func (suite *CSMControllerTestSuite) TestPreChecksOwnerRefNotFound() {
 	assert.Contains(suite.T(), err.Error(), "Owner reference not found")
 }
 
+func (suite *CSMControllerTestSuite) TestPreChecksAdoptExisting() {
+	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
+	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
+	csm.Spec.Driver.Common.Image = "image"
+	csm.Spec.AdoptExisting = true
+	csm.ObjectMeta.Finalizers = []string{CSMFinalizerName}
+
+	sec := shared.MakeSecret(csmName+"-creds", suite.namespace, configVersion)
+	err := suite.fakeClient.Create(ctx, sec)
+	assert.Nil(suite.T(), err)
+
+	// Create a controller deployment installed with Helm, owned by something else
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      csmName + "-controller",
+			Namespace: suite.namespace,
+			Labels:    map[string]string{adoption.ManagedByLabel: adoption.HelmManagedBy},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Name: "helm-owner",
+				},
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "test"},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "test"}}},
+			},
+		},
+	}
+	err = suite.fakeClient.Create(ctx, deployment)
+	assert.Nil(suite.T(), err)
+
+	err = suite.fakeClient.Create(ctx, &csm)
+	assert.Nil(suite.T(), err)
+
+	reconciler := suite.createReconciler()
+	err = reconciler.PreChecks(ctx, &csm, operatorConfig)
+	assert.Nil(suite.T(), err)
+
+	// without adoption the foreign owner is still refused
+	csm.Spec.AdoptExisting = false
+	err = reconciler.PreChecks(ctx, &csm, operatorConfig)
+	assert.ErrorContains(suite.T(), err, "Owner reference not found")
+}
+
+func (suite *CSMControllerTestSuite) TestSyncCSMAdoptExisting() {
+	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
+	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
+	csm.Spec.AdoptExisting = true
+
+	helmLabels := map[string]string{adoption.ManagedByLabel: adoption.HelmManagedBy, adoption.HelmChartLabel: "csi-isilon-2.17.0"}
+	helmAnnotations := map[string]string{adoption.HelmReleaseNameAnnotation: csmName, adoption.HelmReleaseNamespaceAnnotation: suite.namespace}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: csmName + "-controller", Namespace: suite.namespace, Labels: helmLabels, Annotations: helmAnnotations}}
+	assert.Nil(suite.T(), suite.fakeClient.Create(ctx, sa))
+	csiDriver := &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "csi-isilon.dellemc.com", Labels: helmLabels, Annotations: helmAnnotations}}
+	assert.Nil(suite.T(), suite.fakeClient.Create(ctx, csiDriver))
+	assert.Nil(suite.T(), suite.fakeClient.Create(ctx, &csm))
+
+	orig := k8s.GetClientSetWrapper
+	defer func() { k8s.GetClientSetWrapper = orig }()
+	k8s.GetClientSetWrapper = func() (kubernetes.Interface, error) {
+		return k8sfake.NewClientset(), nil
+	}
+
+	r := suite.createReconciler()
+	err := r.SyncCSM(ctx, csm, operatorConfig, r.Client)
+	assert.Nil(suite.T(), err)
+
+	gotSA := &corev1.ServiceAccount{}
+	assert.Nil(suite.T(), suite.fakeClient.Get(ctx, types.NamespacedName{Name: sa.Name, Namespace: sa.Namespace}, gotSA))
+	assert.False(suite.T(), adoption.IsHelmManaged(gotSA))
+	assert.Equal(suite.T(), csmName, gotSA.Labels[constants.CsmLabel])
+	assert.Equal(suite.T(), csmName, gotSA.Annotations[adoption.AdoptedFromAnnotation])
+
+	gotDriver := &storagev1.CSIDriver{}
+	assert.Nil(suite.T(), suite.fakeClient.Get(ctx, types.NamespacedName{Name: csiDriver.Name}, gotDriver))
+	assert.False(suite.T(), adoption.IsHelmManaged(gotDriver))
+}
+
+func (suite *CSMControllerTestSuite) TestSyncCSMDriverSettings() {
+	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
+	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
+	truebool := true
+	csm.Spec.Driver.PowerScale = &csmv1.PowerScaleConfig{Debug: &truebool}
+	assert.Nil(suite.T(), suite.fakeClient.Create(ctx, &csm))
+
+	orig := k8s.GetClientSetWrapper
+	defer func() { k8s.GetClientSetWrapper = orig }()
+	k8s.GetClientSetWrapper = func() (kubernetes.Interface, error) {
+		return k8sfake.NewClientset(), nil
+	}
+
+	r := suite.createReconciler()
+	assert.Nil(suite.T(), r.SyncCSM(ctx, csm, operatorConfig, r.Client))
+
+	// the typed setting is rendered into the driver
+	dp := &appsv1.Deployment{}
+	assert.Nil(suite.T(), suite.fakeClient.Get(ctx, types.NamespacedName{Name: csmName + "-controller", Namespace: suite.namespace}, dp))
+	var envs []corev1.EnvVar
+	for _, c := range dp.Spec.Template.Spec.Containers {
+		if c.Name == "driver" {
+			envs = c.Env
+		}
+	}
+	assert.Contains(suite.T(), envs, corev1.EnvVar{Name: "GOISILON_DEBUG", Value: "true"})
+
+	// but not into the stored CR, which only gets the applied configuration annotation
+	stored := &csmv1.ContainerStorageModule{}
+	assert.Nil(suite.T(), suite.fakeClient.Get(ctx, req.NamespacedName, stored))
+	assert.NotEmpty(suite.T(), stored.Annotations[previouslyAppliedCustomResource])
+	assert.NotContains(suite.T(), stored.Annotations[previouslyAppliedCustomResource], "GOISILON_DEBUG")
+	for _, c := range []*csmv1.ContainerTemplate{stored.Spec.Driver.Common, &stored.Spec.Driver.Controller.ContainerTemplate, &stored.Spec.Driver.Node.ContainerTemplate} {
+		if c != nil {
+			for _, env := range c.Envs {
+				assert.NotEqual(suite.T(), "GOISILON_DEBUG", env.Name)
+			}
+		}
+	}
+}
+
+func (suite *CSMControllerTestSuite) TestSyncCSMTargetClusters() {
+	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
+	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
+	csm.Spec.TargetClusters = []string{"target-1"}
+	assert.Nil(suite.T(), suite.fakeClient.Create(ctx, &csm))
+
+	creds := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: csmName + "-creds", Namespace: suite.namespace},
+		Data:       map[string][]byte{"config": []byte("local")},
+	}
+	assert.Nil(suite.T(), suite.fakeClient.Create(ctx, creds))
+	certs := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: csmName + "-certs-0", Namespace: suite.namespace},
+		Data:       map[string][]byte{"cert-0": []byte("local")},
+	}
+	assert.Nil(suite.T(), suite.fakeClient.Create(ctx, certs))
+
+	remoteClient := crclient.NewFakeClientNoInjector(map[shared.StorageKey]runtime.Object{})
+	remoteCerts := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: csmName + "-certs-0", Namespace: suite.namespace},
+		Data:       map[string][]byte{"cert-0": []byte("remote")},
+	}
+	assert.Nil(suite.T(), remoteClient.Create(ctx, remoteCerts))
+	origRemote := operatorutils.GetRemoteClusterWrapper
+	defer func() { operatorutils.GetRemoteClusterWrapper = origRemote }()
+	operatorutils.GetRemoteClusterWrapper = func(_ context.Context, clusterID string, _ client.Client) (operatorutils.ClusterConfig, error) {
+		return operatorutils.ClusterConfig{
+			ClusterID:         clusterID,
+			ClusterCTRLClient: remoteClient,
+			ClusterK8sClient:  clientgoclient.NewFakeClient(remoteClient),
+		}, nil
+	}
+
+	orig := k8s.GetClientSetWrapper
+	defer func() { k8s.GetClientSetWrapper = orig }()
+	k8s.GetClientSetWrapper = func() (kubernetes.Interface, error) {
+		return k8sfake.NewClientset(), nil
+	}
+
+	r := suite.createReconciler()
+	err := r.SyncCSM(ctx, csm, operatorConfig, r.Client)
+	assert.Nil(suite.T(), err)
+
+	local := &appsv1.Deployment{}
+	assert.Nil(suite.T(), suite.fakeClient.Get(ctx, types.NamespacedName{Name: csmName + "-controller", Namespace: suite.namespace}, local))
+	assert.NotEmpty(suite.T(), local.OwnerReferences)
+
+	remote := &appsv1.Deployment{}
+	assert.Nil(suite.T(), remoteClient.Get(ctx, types.NamespacedName{Name: csmName + "-controller", Namespace: suite.namespace}, remote))
+	assert.Empty(suite.T(), remote.OwnerReferences)
+	assert.Nil(suite.T(), remoteClient.Get(ctx, types.NamespacedName{Name: csmName + "-node", Namespace: suite.namespace}, &appsv1.DaemonSet{}))
+
+	// the namespace and the missing secrets are copied, existing ones are left alone
+	assert.Nil(suite.T(), remoteClient.Get(ctx, types.NamespacedName{Name: suite.namespace}, &corev1.Namespace{}))
+	remoteCreds := &corev1.Secret{}
+	assert.Nil(suite.T(), remoteClient.Get(ctx, types.NamespacedName{Name: csmName + "-creds", Namespace: suite.namespace}, remoteCreds))
+	assert.Equal(suite.T(), []byte("local"), remoteCreds.Data["config"])
+	assert.Equal(suite.T(), csmName, remoteCreds.Labels[constants.CsmLabel])
+	assert.Nil(suite.T(), remoteClient.Get(ctx, types.NamespacedName{Name: csmName + "-certs-0", Namespace: suite.namespace}, remoteCerts))
+	assert.Equal(suite.T(), []byte("remote"), remoteCerts.Data["cert-0"])
+}
+
+func (suite *CSMControllerTestSuite) TestSyncAppliedClusters() {
+	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
+	csm.Spec.Driver.CSIDriverType = csmv1.PowerScale
+	csm.Spec.TargetClusters = []string{"target-1"}
+	assert.Nil(suite.T(), suite.fakeClient.Create(ctx, &csm))
+	csm.Status.AppliedClusters = []string{"target-1", "target-2", "gone"}
+
+	remoteClient := crclient.NewFakeClientNoInjector(map[shared.StorageKey]runtime.Object{})
+	remoteController := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: csmName + "-controller", Namespace: suite.namespace}}
+	assert.Nil(suite.T(), remoteClient.Create(ctx, remoteController))
+	origRemote := operatorutils.GetRemoteClusterWrapper
+	defer func() { operatorutils.GetRemoteClusterWrapper = origRemote }()
+	operatorutils.GetRemoteClusterWrapper = func(_ context.Context, clusterID string, _ client.Client) (operatorutils.ClusterConfig, error) {
+		if clusterID == "gone" {
+			return operatorutils.ClusterConfig{}, errors.New("secret gone not found")
+		}
+		return operatorutils.ClusterConfig{
+			ClusterID:         clusterID,
+			ClusterCTRLClient: remoteClient,
+			ClusterK8sClient:  clientgoclient.NewFakeClient(remoteClient),
+		}, nil
+	}
+
+	// the driver is removed from the dropped cluster, and the unreachable one is forgotten
+	r := suite.createReconciler()
+	assert.Nil(suite.T(), r.syncAppliedClusters(ctx, &csm, operatorConfig))
+	assert.Equal(suite.T(), []string{"target-1"}, csm.Status.AppliedClusters)
+	err := remoteClient.Get(ctx, types.NamespacedName{Name: csmName + "-controller", Namespace: suite.namespace}, &appsv1.Deployment{})
+	assert.True(suite.T(), k8sErrors.IsNotFound(err))
+
+	stored := &csmv1.ContainerStorageModule{}
+	assert.Nil(suite.T(), suite.fakeClient.Get(ctx, types.NamespacedName{Name: csmName, Namespace: suite.namespace}, stored))
+	assert.Equal(suite.T(), []string{"target-1"}, stored.Status.AppliedClusters)
+
+	// an unreachable target cluster does not block the removal of the driver
+	csm.Spec.TargetClusters = []string{"gone"}
+	assert.Nil(suite.T(), r.removeDriver(ctx, csm, operatorConfig))
+}
+
 // TestCheckUpgradeGetVersionErrors covers lines 1695, 1713-1715
 func (suite *CSMControllerTestSuite) TestCheckUpgradeGetVersionErrors() {
 	reconciler := suite.createReconciler()
@@ -3063,20 +3591,22 @@ func (suite *CSMControllerTestSuite) TestCheckUpgradeGetVersionErrors() {
 	csm.Spec.Driver.CSIDriverType = ""
 	csm.Spec.Version = shared.InvalidCSMVersion
 	csm.Spec.Modules = getAuthProxyServer()
-	csm.Annotations = map[string]string{configVersionKey: shared.AuthServerConfigVersion}
+	csm.Annotations = map[string]string{operatorutils.ConfigVersionAnnotation: shared.AuthServerConfigVersion}
 
 	valid, err := reconciler.checkUpgrade(ctx, &csm, operatorConfig)
 	assert.NotNil(suite.T(), err)
+	assert.NotErrorIs(suite.T(), err, operatorutils.ErrUpgradeBlocked)
 	assert.False(suite.T(), valid)
 
 	// Test driver with invalid version
 	csm2 := shared.MakeCSM(csmName, suite.namespace, "")
 	csm2.Spec.Driver.CSIDriverType = csmv1.PowerScale
 	csm2.Spec.Version = shared.InvalidCSMVersion
-	csm2.Annotations = map[string]string{configVersionKey: configVersion}
+	csm2.Annotations = map[string]string{operatorutils.ConfigVersionAnnotation: configVersion}
 
 	valid, err = reconciler.checkUpgrade(ctx, &csm2, operatorConfig)
 	assert.NotNil(suite.T(), err)
+	assert.NotErrorIs(suite.T(), err, operatorutils.ErrUpgradeBlocked)
 	assert.False(suite.T(), valid)
 }
 
@@ -3256,77 +3786,6 @@ func (suite *CSMControllerTestSuite) TestSyncCSMIsHarvesterError() {
 	assert.Nil(suite.T(), err)
 }
 
-// TestHandleDeploymentUpdateSuccess covers line 475-477 (else branch - success event)
-func (suite *CSMControllerTestSuite) TestHandleDeploymentUpdateSuccess() {
-	// Create a COSI CSM (skips daemonset check in calculateState)
-	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
-	csm.Spec.Driver.CSIDriverType = csmv1.Cosi
-	err := suite.fakeClient.Create(ctx, &csm)
-	assert.Nil(suite.T(), err)
-
-	reconciler := suite.createReconciler()
-
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      csmName + "-controller",
-			Namespace: suite.namespace,
-		},
-		Spec: appsv1.DeploymentSpec{
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						constants.CsmLabel:          csmName,
-						constants.CsmNamespaceLabel: suite.namespace,
-					},
-				},
-			},
-		},
-		Status: appsv1.DeploymentStatus{
-			Replicas:            1,
-			AvailableReplicas:   1,
-			ReadyReplicas:       1,
-			UnavailableReplicas: 0,
-		},
-	}
-
-	// This should take the success path (UpdateStatus returns nil)
-	reconciler.handleDeploymentUpdate(deployment, deployment)
-}
-
-// TestHandlePodsUpdateSuccess covers line 518-520 (else branch - success event)
-func (suite *CSMControllerTestSuite) TestHandlePodsUpdateSuccess() {
-	// Create a COSI CSM (skips daemonset check in calculateState)
-	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
-	csm.Spec.Driver.CSIDriverType = csmv1.Cosi
-	err := suite.fakeClient.Create(ctx, &csm)
-	assert.Nil(suite.T(), err)
-
-	reconciler := suite.createReconciler()
-
-	pod := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-pod",
-			Namespace: suite.namespace,
-			Labels: map[string]string{
-				constants.CsmLabel:          csmName,
-				constants.CsmNamespaceLabel: suite.namespace,
-			},
-		},
-		Status: corev1.PodStatus{
-			Phase: corev1.PodRunning,
-			ContainerStatuses: []corev1.ContainerStatus{
-				{
-					State: corev1.ContainerState{
-						Running: &corev1.ContainerStateRunning{StartedAt: metav1.Time{Time: time.Now()}},
-					},
-				},
-			},
-		},
-	}
-
-	reconciler.handlePodsUpdate(nil, pod)
-}
-
 // TestSyncCSMResourceSyncErrorsWithApiFailFunc covers non-COSI resource sync error paths
 // using apiFailFunc for precise error injection
 func (suite *CSMControllerTestSuite) TestSyncCSMResourceSyncErrorsWithApiFailFunc() {
@@ -3959,7 +4418,7 @@ func (suite *CSMControllerTestSuite) TestCheckUpgradeAuthServerVersion() {
 	csm.Spec.Driver.CSIDriverType = ""
 	// Set annotation to simulate existing install
 	csm.ObjectMeta.Annotations = map[string]string{
-		configVersionKey: "v2.4.0",
+		operatorutils.ConfigVersionAnnotation: "v2.4.0",
 	}
 
 	ok, err := r.checkUpgrade(ctx, &csm, operatorConfig)
@@ -4102,11 +4561,11 @@ func (suite *CSMControllerTestSuite) TestSyncCSMResiliencyInjectionErrors() {
 			Name:          csmv1.Resiliency,
 			Enabled:       true,
 			ConfigVersion: "v1.15.0",
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    "podmon",
 					Enabled: &[]bool{true}[0],
-				},
+				}},
 			},
 		},
 	}
@@ -4230,12 +4689,12 @@ func (suite *CSMControllerTestSuite) TestSyncCSMAuthInjectionDaemonsetError() {
 			Name:          csmv1.Authorization,
 			Enabled:       true,
 			ConfigVersion: "v2.4.0",
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    "karavi-authorization-proxy",
 					Enabled: &[]bool{true}[0],
 					Image:   "auth-proxy:latest",
-				},
+				}},
 			},
 		},
 	}
@@ -4544,7 +5003,7 @@ func (suite *CSMControllerTestSuite) makeFakeCSM(name, ns string, withFinalizer
 	}
 	// remove driver when deleting csm
 	csm.Spec.Driver.ForceRemoveDriver = &truebool
-	csm.Annotations[configVersionKey] = configVersion
+	csm.Annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 
 	csm.Spec.Modules = modules
 	out, _ := json.Marshal(&csm)
@@ -4578,7 +5037,7 @@ func (suite *CSMControllerTestSuite) makeFakeResiliencyCSM(name, ns string, with
 	}
 	// remove driver when deleting csm
 	csm.Spec.Driver.ForceRemoveDriver = &truebool
-	csm.Annotations[configVersionKey] = configVersion
+	csm.Annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 
 	csm.Spec.Modules = modules
 	out, _ := json.Marshal(&csm)
@@ -4633,7 +5092,7 @@ func (suite *CSMControllerTestSuite) makeFakeAuthServerCSMWithoutPreRequisite(na
 
 	csm.Spec.Modules = getAuthProxyServer()
 	csm.Spec.Modules[0].ForceRemoveModule = true
-	csm.Annotations[configVersionKey] = shared.AuthServerConfigVersion
+	csm.Annotations[operatorutils.ConfigVersionAnnotation] = shared.AuthServerConfigVersion
 
 	err := suite.fakeClient.Create(ctx, &csm)
 	assert.Nil(suite.T(), err)
@@ -4817,7 +5276,7 @@ func (suite *CSMControllerTestSuite) TestZoneValidation() {
 	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
 	csm.Spec.Driver.CSIDriverType = csmv1.PowerFlex
 	csm.Spec.Driver.Common.Image = "image"
-	csm.Annotations[configVersionKey] = configVersion
+	csm.Annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 
 	csm.ObjectMeta.Finalizers = []string{CSMFinalizerName}
 	err := suite.fakeClient.Create(ctx, &csm)
@@ -4838,7 +5297,7 @@ func (suite *CSMControllerTestSuite) TestZoneValidation2() {
 	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
 	csm.Spec.Driver.CSIDriverType = csmv1.PowerFlex
 	csm.Spec.Driver.Common.Image = "image"
-	csm.Annotations[configVersionKey] = configVersion
+	csm.Annotations[operatorutils.ConfigVersionAnnotation] = configVersion
 
 	csm.ObjectMeta.Finalizers = []string{CSMFinalizerName}
 	err := suite.fakeClient.Create(ctx, &csm)
@@ -5344,14 +5803,12 @@ func (suite *CSMControllerTestSuite) TestSyncCSMConfigMapMissingNoError() {
 
 	_, log := logger.GetNewContextWithLogger("0")
 	reconciler := &ContainerStorageModuleReconciler{
-		Client:               fakeClient,
-		K8sClient:            suite.k8sClient,
-		Scheme:               scheme,
-		Log:                  log,
-		Config:               operatorConfig,
-		EventRecorder:        record.NewFakeRecorder(100),
-		ContentWatchChannels: map[string]chan struct{}{},
-		ContentWatchLock:     sync.Mutex{},
+		Client:        fakeClient,
+		K8sClient:     suite.k8sClient,
+		Scheme:        scheme,
+		Log:           log,
+		Config:        operatorConfig,
+		EventRecorder: record.NewFakeRecorder(100),
 	}
 
 	csm := shared.MakeCSM(csmName, "test-namespace", configVersion)
@@ -5404,14 +5861,12 @@ func (suite *CSMControllerTestSuite) TestSyncCSMConfigMapPresentNoMatchError() {
 
 	_, log := logger.GetNewContextWithLogger("0")
 	reconciler := &ContainerStorageModuleReconciler{
-		Client:               fakeClient,
-		K8sClient:            suite.k8sClient,
-		Scheme:               scheme,
-		Log:                  log,
-		Config:               operatorConfig,
-		EventRecorder:        record.NewFakeRecorder(100),
-		ContentWatchChannels: map[string]chan struct{}{},
-		ContentWatchLock:     sync.Mutex{},
+		Client:        fakeClient,
+		K8sClient:     suite.k8sClient,
+		Scheme:        scheme,
+		Log:           log,
+		Config:        operatorConfig,
+		EventRecorder: record.NewFakeRecorder(100),
 	}
 
 	csm := shared.MakeCSM(csmName, "test-namespace", configVersion)
@@ -5438,14 +5893,12 @@ func TestSetupWithManager(t *testing.T) {
 
 	_, log := logger.GetNewContextWithLogger("0")
 	reconciler := &ContainerStorageModuleReconciler{
-		Client:               fakeClient,
-		K8sClient:            nil,
-		Scheme:               scheme,
-		Log:                  log,
-		Config:               operatorutils.OperatorConfig{},
-		EventRecorder:        record.NewFakeRecorder(100),
-		ContentWatchChannels: map[string]chan struct{}{},
-		ContentWatchLock:     sync.Mutex{},
+		Client:        fakeClient,
+		K8sClient:     nil,
+		Scheme:        scheme,
+		Log:           log,
+		Config:        operatorutils.OperatorConfig{},
+		EventRecorder: record.NewFakeRecorder(100),
 	}
 
 	// Create a fake manager
@@ -5582,10 +6035,10 @@ func (suite *CSMControllerTestSuite) TestReconcileObservabilityWebhookNotFound()
 	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
 	obs := getObservabilityModule()
 	// Add cert-manager as an enabled component
-	obs[0].Components = append(obs[0].Components, csmv1.ContainerTemplate{
+	obs[0].Components = append(obs[0].Components, csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 		Name:    modules.ObservabilityCertManagerComponent,
 		Enabled: &[]bool{true}[0],
-	})
+	}})
 	csm.Spec.Modules = obs
 	reconciler := suite.createReconciler()
 
@@ -5603,10 +6056,10 @@ func (suite *CSMControllerTestSuite) TestReconcileObservabilityWebhookNotFound()
 func (suite *CSMControllerTestSuite) TestReconcileObservabilityWebhookNotReady() {
 	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
 	obs := getObservabilityModule()
-	obs[0].Components = append(obs[0].Components, csmv1.ContainerTemplate{
+	obs[0].Components = append(obs[0].Components, csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 		Name:    modules.ObservabilityCertManagerComponent,
 		Enabled: &[]bool{true}[0],
-	})
+	}})
 	csm.Spec.Modules = obs
 	reconciler := suite.createReconciler()
 
@@ -5634,10 +6087,10 @@ func (suite *CSMControllerTestSuite) TestReconcileObservabilityWebhookNotReady()
 func (suite *CSMControllerTestSuite) TestReconcileObservabilityWebhookReady() {
 	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
 	obs := getObservabilityModule()
-	obs[0].Components = append(obs[0].Components, csmv1.ContainerTemplate{
+	obs[0].Components = append(obs[0].Components, csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 		Name:    modules.ObservabilityCertManagerComponent,
 		Enabled: &[]bool{true}[0],
-	})
+	}})
 	csm.Spec.Modules = obs
 	reconciler := suite.createReconciler()
 
@@ -5669,10 +6122,10 @@ func (suite *CSMControllerTestSuite) TestReconcileObservabilityWebhookReady() {
 func (suite *CSMControllerTestSuite) TestReconcileObservabilityDeletion() {
 	csm := shared.MakeCSM(csmName, suite.namespace, configVersion)
 	obs := getObservabilityModule()
-	obs[0].Components = append(obs[0].Components, csmv1.ContainerTemplate{
+	obs[0].Components = append(obs[0].Components, csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 		Name:    modules.ObservabilityCertManagerComponent,
 		Enabled: &[]bool{true}[0],
-	})
+	}})
 	csm.Spec.Modules = obs
 	reconciler := suite.createReconciler()
 
@@ -5716,63 +6169,6 @@ func (suite *CSMControllerTestSuite) TestReconcileDeleteForceRemoveDriverFalse()
 	assert.Nil(suite.T(), err)
 }
 
-// ─── Reconcile: ContentWatch channel exists on re-reconcile ─────────────────
-
-func (suite *CSMControllerTestSuite) TestReconcileContentWatchChannelReplace() {
-	suite.makeFakeCSM(csmName, suite.namespace, true, []csmv1.Module{})
-
-	reconciler := suite.createReconciler()
-	// Pre-populate ContentWatchChannels with existing channel
-	existingChan := make(chan struct{})
-	reconciler.ContentWatchChannels[csmName] = existingChan
-
-	_, err := reconciler.Reconcile(ctx, req)
-	assert.Nil(suite.T(), err)
-
-	// The old channel should have been closed and replaced
-	select {
-	case <-existingChan:
-		// channel was closed, good
-	default:
-		suite.T().Error("expected existing ContentWatch channel to be closed")
-	}
-	// New channel should exist
-	_, ok := reconciler.ContentWatchChannels[csmName]
-	assert.True(suite.T(), ok)
-}
-
-// ─── Reconcile: ContentWatch channel close on delete ────────────────────────
-
-func (suite *CSMControllerTestSuite) TestReconcileDeleteClosesContentWatchChannel() {
-	suite.makeFakeCSM(csmName, suite.namespace, true, []csmv1.Module{})
-
-	// First reconcile to populate the channel
-	reconciler := suite.createReconciler()
-	_, err := reconciler.Reconcile(ctx, req)
-	assert.Nil(suite.T(), err)
-
-	_, channelExists := reconciler.ContentWatchChannels[csmName]
-	assert.True(suite.T(), channelExists)
-
-	// Mark for deletion
-	csm := &csmv1.ContainerStorageModule{}
-	key := types.NamespacedName{Namespace: suite.namespace, Name: csmName}
-	err = suite.fakeClient.Get(ctx, key, csm)
-	assert.Nil(suite.T(), err)
-	err = suite.fakeClient.(*crclient.Client).SetDeletionTimeStamp(ctx, csm)
-	assert.Nil(suite.T(), err)
-	err = suite.fakeClient.Delete(ctx, csm)
-	assert.Nil(suite.T(), err)
-
-	// Reconcile the delete
-	_, err = reconciler.Reconcile(ctx, req)
-	assert.Nil(suite.T(), err)
-
-	// Channel should be removed
-	_, channelExists = reconciler.ContentWatchChannels[csmName]
-	assert.False(suite.T(), channelExists)
-}
-
 // ─── removeDriver: observability-enabled and PowerStore paths ───────────────
 
 func (suite *CSMControllerTestSuite) TestRemoveDriverWithObservability() {
@@ -5895,14 +6291,14 @@ func (suite *CSMControllerTestSuite) TestSyncCSMPowerFlexSFTPDisabled() {
 	csm.Spec.Driver.CSIDriverType = csmv1.PowerFlex
 	csm.Spec.Driver.ConfigVersion = shared.PFlexConfigVersion
 	csm.Spec.Driver.Common.Image = "image"
-	csm.Spec.Driver.Node = &csmv1.ContainerTemplate{
+	csm.Spec.Driver.Node = &csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 		Envs: []corev1.EnvVar{
 			{
 				Name:  "X_CSI_SDC_SFTP_REPO_ENABLED",
 				Value: "false",
 			},
 		},
-	}
+	}}
 	suite.makeFakeCSM(csmName, suite.namespace, false, []csmv1.Module{})
 
 	err := r.SyncCSM(ctx, csm, operatorConfig, r.Client)
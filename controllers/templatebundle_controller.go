@@ -0,0 +1,227 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/logger"
+	"github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/dell/csm-operator/pkg/templatebundle"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// TemplateBundleReconciler - merges the template bundles of the labeled ConfigMaps of a namespace into the templates
+type TemplateBundleReconciler struct {
+	// Client - reads the ConfigMaps and CSMs from the cache, which only holds the labeled ConfigMaps of the namespace
+	Client    client.Reader
+	Config    operatorutils.OperatorConfig
+	Namespace string
+	Verifier  templatebundle.Verifier
+	// Events - receives the CSMs whose templates a bundle changed, for the CSM controller to reconcile them
+	Events chan<- event.GenericEvent
+	// Elected - closed once this replica is the leader, the CSM controller only takes the events from then on
+	Elected <-chan struct{}
+}
+
+// Reconcile - merges the bundle of the ConfigMap, or drops it when the ConfigMap is deleted, unlabeled or not valid
+func (r *TemplateBundleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_, log := logger.GetNewContextWithLogger("templatebundle")
+	templates := operatorutils.Templates(r.Config.ConfigDirectory)
+	bundle := configMapBundleName(req.Name)
+	previous := templates.BundlePaths(bundle)
+
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, req.NamespacedName, cm)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	if k8serrors.IsNotFound(err) || cm.DeletionTimestamp != nil || cm.Labels[templatebundle.Label] != "true" {
+		templates.RemoveBundle(bundle)
+		return ctrl.Result{}, requeueCSMs(ctx, r.Client, r.Events, r.Elected, previous)
+	}
+
+	files, err := r.Verifier.FromConfigMap(cm)
+	if err == nil {
+		err = templates.SetBundle(bundle, files)
+	}
+	if err != nil {
+		// a bundle is only used once it is verified, there is nothing to retry until the ConfigMap changes
+		templates.RemoveBundle(bundle)
+		log.Errorw("Template bundle rejected", "ConfigMap", req.NamespacedName, "Error", err.Error())
+		return ctrl.Result{}, requeueCSMs(ctx, r.Client, r.Events, r.Elected, previous)
+	}
+	log.Infow("Template bundle merged", "ConfigMap", req.NamespacedName, "files", len(files))
+	return ctrl.Result{}, requeueCSMs(ctx, r.Client, r.Events, r.Elected, append(previous, templates.BundlePaths(bundle)...))
+}
+
+// requeueCSMs - sends the CSMs that use any of the bundle files to the CSM controller. A replica that is not the leader
+// sends nothing, since the CSM controller reconciles every CSM once it becomes the leader
+func requeueCSMs(ctx context.Context, csmReader client.Reader, events chan<- event.GenericEvent, elected <-chan struct{}, paths []string) error {
+	if len(paths) == 0 || events == nil {
+		return nil
+	}
+	if elected != nil {
+		select {
+		case <-elected:
+		default:
+			return nil
+		}
+	}
+
+	csms := &csmv1.ContainerStorageModuleList{}
+	if err := csmReader.List(ctx, csms); err != nil {
+		return err
+	}
+	for i := range csms.Items {
+		if !usesBundleFiles(&csms.Items[i], paths) {
+			continue
+		}
+		select {
+		case events <- event.GenericEvent{Object: &csms.Items[i]}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// usesBundleFiles - returns whether the templates of the CSM include any of the files, by path relative to the config directory
+func usesBundleFiles(csm *csmv1.ContainerStorageModule, paths []string) bool {
+	driverDir := csm.Spec.Driver.CSIDriverType
+	if driverDir == csmv1.PowerScale {
+		driverDir = csmv1.PowerScaleName
+	}
+	for _, path := range paths {
+		// bundle paths always have a directory within driverconfig, moduleconfig or common
+		parts := strings.SplitN(filepath.ToSlash(path), "/", 3)
+		switch {
+		case parts[0] == "driverconfig" && parts[1] != "common":
+			if parts[1] == string(driverDir) {
+				return true
+			}
+		case parts[0] == "moduleconfig" && parts[1] != "common":
+			// the PowerMax driver runs the reverse proxy without it being listed as a module
+			if parts[1] == string(csmv1.ReverseProxy) && driverDir == csmv1.PowerMax {
+				return true
+			}
+			for _, module := range csm.Spec.Modules {
+				// the modules of a config directory share its name as prefix, like authorization-proxy-server
+				if module.Enabled && strings.HasPrefix(string(module.Name), parts[1]) {
+					return true
+				}
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// SetupWithManager - watches the ConfigMaps the cache holds, see CacheOptions. Every replica reconciles them, since each
+// one renders templates
+func (r *TemplateBundleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("templatebundle").
+		For(&corev1.ConfigMap{}).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r)
+}
+
+// configMapBundleName - returns the name of the template bundle of a ConfigMap
+func configMapBundleName(name string) string {
+	return "configmap/" + name
+}
+
+// TemplateBundlePuller - pulls a template bundle from an OCI registry into the templates, at start and then every Interval
+type TemplateBundlePuller struct {
+	Config    operatorutils.OperatorConfig
+	Reference string
+	Puller    templatebundle.Puller
+	// Interval - defaults to REFRESH_INTERVAL_MINUTES
+	Interval time.Duration
+	// Client - lists the CSMs whose templates a pull changed
+	Client client.Reader
+	// Events - receives the CSMs whose templates a pull changed, for the CSM controller to reconcile them
+	Events chan<- event.GenericEvent
+	// Elected - closed once this replica is the leader, the CSM controller only takes the events from then on
+	Elected <-chan struct{}
+
+	// files - the files of the last successful pull
+	files map[string][]byte
+}
+
+// Start - pulls the bundle until the context is done. A failed pull keeps the bundle of the last successful one
+func (p *TemplateBundlePuller) Start(ctx context.Context) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = getRefreshInterval()
+	}
+	wait.UntilWithContext(ctx, p.pull, interval)
+	return nil
+}
+
+// NeedLeaderElection - every replica pulls the bundle, since each one renders templates
+func (p *TemplateBundlePuller) NeedLeaderElection() bool {
+	return false
+}
+
+// pull - pulls, verifies and merges the bundle, then requeues the CSMs using the files that changed since the last pull
+func (p *TemplateBundlePuller) pull(ctx context.Context) {
+	_, log := logger.GetNewContextWithLogger("templatebundle")
+	files, err := p.Puller.Pull(ctx, p.Reference)
+	if err == nil {
+		err = operatorutils.Templates(p.Config.ConfigDirectory).SetBundle("oci/"+p.Reference, files)
+	}
+	if err != nil {
+		log.Errorw("Pulling template bundle failed", "reference", p.Reference, "Error", err.Error())
+		return
+	}
+	log.Infow("Template bundle merged", "reference", p.Reference, "files", len(files))
+
+	changed := changedBundleFiles(p.files, files)
+	p.files = files
+	if err := requeueCSMs(ctx, p.Client, p.Events, p.Elected, changed); err != nil {
+		log.Errorw("Requeueing the CSMs using the template bundle failed", "reference", p.Reference, "Error", err.Error())
+	}
+}
+
+// changedBundleFiles - returns the paths of the files added, removed or changed between two pulls of a bundle
+func changedBundleFiles(previous, current map[string][]byte) []string {
+	var changed []string
+	for path, data := range current {
+		if old, ok := previous[path]; !ok || !bytes.Equal(old, data) {
+			changed = append(changed, filepath.Clean(path))
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, filepath.Clean(path))
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
@@ -0,0 +1,305 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package controllers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/dell/csm-operator/pkg/templatebundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// bundleArchive - returns a gzipped tar archive of the file and its sha256 digest
+func bundleArchive(t *testing.T, name, content string) ([]byte, string) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	sum := sha256.Sum256(buf.Bytes())
+	return buf.Bytes(), "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestTemplateBundleReconcile(t *testing.T) {
+	ctx := context.Background()
+	config := operatorutils.OperatorConfig{ConfigDirectory: t.TempDir()}
+	templates := operatorutils.Templates(config.ConfigDirectory)
+	archive, digest := bundleArchive(t, "driverconfig/powerflex/v2.17.2/controller.yaml", "kind: Deployment")
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "powerflex-v2.17.2",
+			Namespace:   "dell-csm-operator",
+			Labels:      map[string]string{templatebundle.Label: "true"},
+			Annotations: map[string]string{templatebundle.ChecksumAnnotation: digest},
+		},
+		BinaryData: map[string][]byte{templatebundle.ArchiveKey: archive},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(cm).Build()
+	r := &TemplateBundleReconciler{Client: fakeClient, Config: config, Namespace: "dell-csm-operator"}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"configmap/powerflex-v2.17.2"}, templates.Bundles())
+	buf, err := templates.DriverFile(csmv1.PowerFlex, "v2.17.2", "controller.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Deployment", string(buf))
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		cm.Annotations[templatebundle.ChecksumAnnotation] = "sha256:0"
+		require.NoError(t, fakeClient.Update(ctx, cm))
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		assert.Empty(t, templates.Bundles())
+
+		cm.Annotations[templatebundle.ChecksumAnnotation] = digest
+		require.NoError(t, fakeClient.Update(ctx, cm))
+		_, err = r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"configmap/powerflex-v2.17.2"}, templates.Bundles())
+	})
+
+	t.Run("unlabeled", func(t *testing.T) {
+		delete(cm.Labels, templatebundle.Label)
+		require.NoError(t, fakeClient.Update(ctx, cm))
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		assert.Empty(t, templates.Bundles())
+
+		cm.Labels[templatebundle.Label] = "true"
+		require.NoError(t, fakeClient.Update(ctx, cm))
+		_, err = r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"configmap/powerflex-v2.17.2"}, templates.Bundles())
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		require.NoError(t, fakeClient.Delete(ctx, cm))
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		assert.Empty(t, templates.Bundles())
+		_, err = templates.DriverFile(csmv1.PowerFlex, "v2.17.2", "controller.yaml")
+		assert.Error(t, err)
+	})
+}
+
+func TestTemplateBundleRequeueCSMs(t *testing.T) {
+	ctx := context.Background()
+	config := operatorutils.OperatorConfig{ConfigDirectory: t.TempDir()}
+	templates := operatorutils.Templates(config.ConfigDirectory)
+	archive, digest := bundleArchive(t, "driverconfig/powerscale/v2.17.2/controller.yaml", "kind: Deployment")
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "powerscale-v2.17.2",
+			Namespace:   "dell-csm-operator",
+			Labels:      map[string]string{templatebundle.Label: "true"},
+			Annotations: map[string]string{templatebundle.ChecksumAnnotation: digest},
+		},
+		BinaryData: map[string][]byte{templatebundle.ArchiveKey: archive},
+	}
+	isilon := &csmv1.ContainerStorageModule{
+		ObjectMeta: metav1.ObjectMeta{Name: "isilon", Namespace: "isilon"},
+		Spec:       csmv1.ContainerStorageModuleSpec{Driver: csmv1.Driver{CSIDriverType: csmv1.PowerScale}},
+	}
+	powerflex := &csmv1.ContainerStorageModule{
+		ObjectMeta: metav1.ObjectMeta{Name: "vxflexos", Namespace: "vxflexos"},
+		Spec:       csmv1.ContainerStorageModuleSpec{Driver: csmv1.Driver{CSIDriverType: csmv1.PowerFlex}},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, csmv1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm, isilon, powerflex).Build()
+	events := make(chan event.GenericEvent, 10)
+	elected := make(chan struct{})
+	r := &TemplateBundleReconciler{Client: fakeClient, Config: config, Namespace: "dell-csm-operator", Events: events, Elected: elected}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}}
+	requeued := func() []string {
+		names := []string{}
+		for len(events) > 0 {
+			names = append(names, (<-events).Object.GetName())
+		}
+		return names
+	}
+
+	// a replica that is not the leader only merges the bundle
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"configmap/powerscale-v2.17.2"}, templates.Bundles())
+	assert.Empty(t, requeued())
+
+	close(elected)
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"isilon"}, requeued())
+
+	// the CSMs of both the removed and the new files are requeued
+	archive, digest = bundleArchive(t, "driverconfig/powerflex/v2.17.2/controller.yaml", "kind: Deployment")
+	cm.BinaryData[templatebundle.ArchiveKey] = archive
+	cm.Annotations[templatebundle.ChecksumAnnotation] = digest
+	require.NoError(t, fakeClient.Update(ctx, cm))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"isilon", "vxflexos"}, requeued())
+
+	require.NoError(t, fakeClient.Delete(ctx, cm))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, templates.Bundles())
+	assert.Equal(t, []string{"vxflexos"}, requeued())
+
+	// nothing is requeued when there was no bundle
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, requeued())
+}
+
+func TestUsesBundleFiles(t *testing.T) {
+	powermax := &csmv1.ContainerStorageModule{
+		Spec: csmv1.ContainerStorageModuleSpec{
+			Driver: csmv1.Driver{CSIDriverType: csmv1.PowerMax},
+			Modules: []csmv1.Module{
+				{Name: csmv1.AuthorizationServer, Enabled: true},
+				{Name: csmv1.Resiliency, Enabled: false},
+			},
+		},
+	}
+	tests := map[string]bool{
+		"driverconfig/powermax/v2.17.0/node.yaml":           true,
+		"driverconfig/powerflex/v2.17.0/node.yaml":          false,
+		"driverconfig/common/default-values.yaml":           true,
+		"moduleconfig/authorization/v2.5.0/container.yaml":  true,
+		"moduleconfig/csireverseproxy/v2.16.0/service.yaml": true,
+		"moduleconfig/resiliency/v1.16.0/container.yaml":    false,
+		"moduleconfig/common/version-values.yaml":           true,
+		"common/csm-version-mapping.yaml":                   true,
+	}
+	for path, uses := range tests {
+		assert.Equal(t, uses, usesBundleFiles(powermax, []string{path}), path)
+	}
+}
+
+func TestTemplateBundlePuller(t *testing.T) {
+	config := operatorutils.OperatorConfig{ConfigDirectory: t.TempDir()}
+	templates := operatorutils.Templates(config.ConfigDirectory)
+	archive, digest := bundleArchive(t, "driverconfig/powerflex/v2.17.2/node.yaml", "kind: DaemonSet")
+	manifest, err := json.Marshal(map[string]any{
+		"layers": []map[string]any{{"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "digest": digest, "size": len(archive)}},
+	})
+	require.NoError(t, err)
+	var unavailable atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case unavailable.Load():
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case strings.HasSuffix(r.URL.Path, "/manifests/v2.17.2"):
+			_, _ = w.Write(manifest)
+		case strings.HasSuffix(r.URL.Path, "/blobs/"+digest):
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	isilon := &csmv1.ContainerStorageModule{
+		ObjectMeta: metav1.ObjectMeta{Name: "isilon", Namespace: "isilon"},
+		Spec:       csmv1.ContainerStorageModuleSpec{Driver: csmv1.Driver{CSIDriverType: csmv1.PowerScale}},
+	}
+	powerflex := &csmv1.ContainerStorageModule{
+		ObjectMeta: metav1.ObjectMeta{Name: "vxflexos", Namespace: "vxflexos"},
+		Spec:       csmv1.ContainerStorageModuleSpec{Driver: csmv1.Driver{CSIDriverType: csmv1.PowerFlex}},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, csmv1.AddToScheme(scheme))
+	events := make(chan event.GenericEvent, 10)
+
+	reference := strings.TrimPrefix(server.URL, "http://") + "/dell/csm-templates:v2.17.2"
+	puller := &TemplateBundlePuller{
+		Config:    config,
+		Reference: reference,
+		Puller:    templatebundle.Puller{PlainHTTP: true},
+		Interval:  time.Hour,
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(isilon, powerflex).Build(),
+		Events:    events,
+	}
+	assert.False(t, puller.NeedLeaderElection())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- puller.Start(ctx) }()
+	assert.Eventually(t, func() bool { return len(templates.Bundles()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"oci/" + reference}, templates.Bundles())
+
+	// the CSMs using the pulled files are requeued, a pull that changes nothing requeues none
+	require.Len(t, events, 1)
+	assert.Equal(t, "vxflexos", (<-events).Object.GetName())
+	puller.pull(context.Background())
+	assert.Empty(t, events)
+
+	// a failed pull keeps the bundle of the last successful one
+	unavailable.Store(true)
+	puller.pull(context.Background())
+	assert.Empty(t, events)
+	buf, err := templates.DriverFile(csmv1.PowerFlex, "v2.17.2", "node.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "kind: DaemonSet", string(buf))
+}
+
+func TestChangedBundleFiles(t *testing.T) {
+	previous := map[string][]byte{
+		"driverconfig/powerflex/v2.17.2/node.yaml":       []byte("kind: DaemonSet"),
+		"driverconfig/powerflex/v2.17.2/controller.yaml": []byte("kind: Deployment"),
+		"moduleconfig/resiliency/v1.16.0/container.yaml": []byte("name: podmon"),
+	}
+	current := map[string][]byte{
+		"driverconfig/powerflex/v2.17.2/node.yaml":       []byte("kind: DaemonSet"),
+		"driverconfig/powerflex/v2.17.2/controller.yaml": []byte("kind: Deployment\nspec: {}"),
+		"driverconfig/powerstore/v2.17.0/node.yaml":      []byte("kind: DaemonSet"),
+	}
+
+	assert.Equal(t, []string{
+		"driverconfig/powerflex/v2.17.2/controller.yaml",
+		"driverconfig/powerstore/v2.17.0/node.yaml",
+		"moduleconfig/resiliency/v1.16.0/container.yaml",
+	}, changedBundleFiles(previous, current))
+	assert.Empty(t, changedBundleFiles(current, current))
+	assert.Len(t, changedBundleFiles(nil, current), 3)
+}
@@ -15,6 +15,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -22,7 +23,6 @@ import (
 	osruntime "runtime"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -38,6 +38,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	crzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -52,6 +53,8 @@ import (
 	"github.com/dell/csm-operator/core"
 	k8sClient "github.com/dell/csm-operator/k8s"
 	"github.com/dell/csm-operator/pkg/logger"
+	"github.com/dell/csm-operator/pkg/templatebundle"
+	"github.com/dell/csm-operator/pkg/webhooks"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -230,6 +233,10 @@ var (
 		return r.SetupWithManager
 	}
 
+	setupWebhooksFn = func(mgr ctrl.Manager, config operatorutils.OperatorConfig) error {
+		return webhooks.SetupCSMWebhookWithManager(mgr, config)
+	}
+
 	osExit = func(code int) {
 		os.Exit(code)
 	}
@@ -242,6 +249,27 @@ var (
 				"Enabling this will ensure there is only one active controller manager.")
 		flags.secureMetrics = flag.Bool("metrics-secure", true,
 			"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
+		flags.enableWebhooks = flag.Bool("enable-webhooks", false,
+			"If set, the defaulting and validating webhooks for ContainerStorageModule are served on port 9443. "+
+				"A serving certificate must be mounted for the webhook server.")
+		flags.maxReconcilers = flag.Int("max-concurrent-reconciles", 1,
+			"The number of ContainerStorageModules reconciled in parallel. "+
+				"The status updates of each ContainerStorageModule are serialized independently of the others.")
+		flags.templateBundleNamespace = flag.String("template-bundle-namespace", "",
+			"If set, the template bundles of the ConfigMaps of this namespace labeled "+templatebundle.Label+"=true "+
+				"are merged over the config directory.")
+		flags.templateBundleOCI = flag.String("template-bundle-oci", "",
+			"If set, the template bundle of this OCI artifact, registry/repository:tag or registry/repository@sha256:digest, "+
+				"is pulled anonymously every REFRESH_INTERVAL_MINUTES and merged over the config directory.")
+		flags.templateBundlePlainHTTP = flag.Bool("template-bundle-plain-http", false,
+			"If set, the template bundle OCI artifact is pulled over HTTP instead of HTTPS.")
+		flags.templateBundlePublicKey = flag.String("template-bundle-public-key", "",
+			"Path of a PEM encoded ed25519 public key. Template bundles must be signed with the matching private key. "+
+				"Required when a template bundle source is set, unless --template-bundle-allow-unsigned is set.")
+		flags.templateBundleAllowUnsigned = flag.Bool("template-bundle-allow-unsigned", false,
+			"If set, template bundles are used without a --template-bundle-public-key and only their sha256 checksum is verified. "+
+				"The checksum only detects corruption and gives no authenticity: anyone who can write the bundle ConfigMaps "+
+				"or push the OCI artifact can change the manifests the operator deploys.")
 		opts := initZapFlags()
 		flag.Parse()
 		return opts
@@ -265,11 +293,75 @@ var (
 )
 
 var flags struct {
-	metricsBindAddress     *string
-	healthProbeBindAddress *string
-	leaderElect            *bool
-	secureMetrics          *bool
-	zapOpts                crzap.Options
+	metricsBindAddress      *string
+	healthProbeBindAddress  *string
+	leaderElect             *bool
+	secureMetrics           *bool
+	enableWebhooks          *bool
+	maxReconcilers          *int
+	templateBundleNamespace *string
+	templateBundleOCI       *string
+	templateBundlePlainHTTP *bool
+	templateBundlePublicKey *string
+	// templateBundleAllowUnsigned - uses template bundles without a public key, which only verifies their checksum
+	templateBundleAllowUnsigned *bool
+	zapOpts                     crzap.Options
+}
+
+// setupTemplateBundles - merges the template bundles of the ConfigMaps and OCI artifact set by the flags over the config directory.
+// The CSMs a bundle ConfigMap changes are sent to events
+func setupTemplateBundles(mgr ctrl.Manager, config operatorutils.OperatorConfig, events chan<- event.GenericEvent) error {
+	enabled := (flags.templateBundleNamespace != nil && *flags.templateBundleNamespace != "") ||
+		(flags.templateBundleOCI != nil && *flags.templateBundleOCI != "")
+	verifier := templatebundle.Verifier{}
+	if flags.templateBundlePublicKey != nil && *flags.templateBundlePublicKey != "" {
+		publicKey, err := templatebundle.LoadPublicKey(*flags.templateBundlePublicKey)
+		if err != nil {
+			return err
+		}
+		verifier.PublicKey = publicKey
+	} else if enabled {
+		if flags.templateBundleAllowUnsigned == nil || !*flags.templateBundleAllowUnsigned {
+			return errors.New("--template-bundle-public-key is required to use template bundles, " +
+				"set --template-bundle-allow-unsigned to only verify their checksum")
+		}
+		_, log := logger.GetNewContextWithLogger("templatebundle")
+		log.Warnw("TEMPLATE BUNDLES ARE NOT SIGNED: only their sha256 checksum is verified, which gives no authenticity. " +
+			"Anyone who can write the bundle ConfigMaps or push the OCI artifact can change the manifests the operator deploys. " +
+			"Set --template-bundle-public-key to require signed bundles")
+	}
+
+	if flags.templateBundleNamespace != nil && *flags.templateBundleNamespace != "" {
+		r := &controllers.TemplateBundleReconciler{
+			Client:    mgr.GetCache(),
+			Config:    config,
+			Namespace: *flags.templateBundleNamespace,
+			Verifier:  verifier,
+			Events:    events,
+			Elected:   mgr.Elected(),
+		}
+		if err := r.SetupWithManager(mgr); err != nil {
+			return err
+		}
+	}
+
+	if flags.templateBundleOCI != nil && *flags.templateBundleOCI != "" {
+		puller := &controllers.TemplateBundlePuller{
+			Config:    config,
+			Reference: *flags.templateBundleOCI,
+			Puller: templatebundle.Puller{
+				PlainHTTP: flags.templateBundlePlainHTTP != nil && *flags.templateBundlePlainHTTP,
+				Verifier:  verifier,
+			},
+			Client:  mgr.GetCache(),
+			Events:  events,
+			Elected: mgr.Elected(),
+		}
+		if err := mgr.Add(puller); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func main() {
@@ -286,6 +378,9 @@ func main() {
 		osExit(1)
 		return
 	}
+	operatorConfig.OperatorVersion = ManifestSemver
+	// load the templates before the first reconcile instead of on it
+	operatorutils.Templates(operatorConfig.ConfigDirectory)
 	restConfig := getConfigOrDie()
 
 	var tlsOpts []func(*tls.Config)
@@ -294,8 +389,14 @@ func main() {
 	}
 	tlsOpts = append(tlsOpts, disableHTTP2)
 
+	bundleNamespace := ""
+	if flags.templateBundleNamespace != nil {
+		bundleNamespace = *flags.templateBundleNamespace
+	}
 	mgr, err := newManager(restConfig, ctrl.Options{
 		Scheme: scheme,
+		Cache:  controllers.CacheOptions(bundleNamespace),
+		Client: controllers.ClientOptions(),
 		Metrics: metricsserver.Options{
 			BindAddress:    *flags.metricsBindAddress,
 			SecureServing:  *flags.secureMetrics,
@@ -324,22 +425,40 @@ func main() {
 	expRateLimiter := workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](5*time.Millisecond, 120*time.Second)
 
 	r := &controllers.ContainerStorageModuleReconciler{
-		Client:               mgr.GetClient(),
-		K8sClient:            k8sClient,
-		Log:                  log,
-		Scheme:               mgr.GetScheme(),
-		EventRecorder:        recorder,
-		Config:               operatorConfig,
-		ContentWatchChannels: make(map[string]chan struct{}),
-		ContentWatchLock:     sync.Mutex{},
+		Client:        mgr.GetClient(),
+		K8sClient:     k8sClient,
+		Log:           log,
+		Scheme:        mgr.GetScheme(),
+		EventRecorder: recorder,
+		Config:        operatorConfig,
+		// the template bundle reconciler sends the CSMs whose templates changed
+		TemplateBundleEvents: make(chan event.GenericEvent),
 	}
 
+	maxReconcilers := 1
+	if flags.maxReconcilers != nil && *flags.maxReconcilers > 0 {
+		maxReconcilers = *flags.maxReconcilers
+	}
 	setupWithManager := getSetupWithManagerFn(r)
-	if err := setupWithManager(mgr, expRateLimiter, 1); err != nil {
+	if err := setupWithManager(mgr, expRateLimiter, maxReconcilers); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ContainerStorageModule")
 		osExit(1)
 		return
 	}
+
+	if err := setupTemplateBundles(mgr, operatorConfig, r.TemplateBundleEvents); err != nil {
+		setupLog.Error(err, "unable to set up template bundles")
+		osExit(1)
+		return
+	}
+
+	if flags.enableWebhooks != nil && *flags.enableWebhooks {
+		if err := setupWebhooksFn(mgr, operatorConfig); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ContainerStorageModule")
+			osExit(1)
+			return
+		}
+	}
 	defer close(getControllerWatchCh())
 	//+kubebuilder:scaffold:builder
 
@@ -17,6 +17,7 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -883,6 +884,90 @@ func TestMainSetupWithManagerError(_ *testing.T) {
 	<-osExitCalled
 }
 
+func TestMainSetupWebhooksError(_ *testing.T) {
+	originalIsOpenShift := isOpenShift
+	originalGetKubeAPIServerVersion := getKubeAPIServerVersion
+	originalGetConfigDir := getConfigDir
+	originalGetK8sPathFn := getk8sPathFn
+	originalgetSetupWithManagerFn := getSetupWithManagerFn
+	originalSetupWebhooksFn := setupWebhooksFn
+	originalOsExit := osExit
+	originalInitFlags := initFlags
+	originalInitZapFlags := initZapFlags
+	defer func() {
+		isOpenShift = originalIsOpenShift
+		getKubeAPIServerVersion = originalGetKubeAPIServerVersion
+		getConfigDir = originalGetConfigDir
+		getk8sPathFn = originalGetK8sPathFn
+		getSetupWithManagerFn = originalgetSetupWithManagerFn
+		setupWebhooksFn = originalSetupWebhooksFn
+		osExit = originalOsExit
+		initFlags = originalInitFlags
+		initZapFlags = originalInitZapFlags
+		flags.enableWebhooks = nil
+	}()
+
+	isOpenShift = func(_ *zap.SugaredLogger) (bool, error) { return true, nil }
+	getKubeAPIServerVersion = func() (*version.Info, error) { return &version.Info{Major: "1", Minor: "31"}, nil }
+	getConfigDir = func() string { return "testdata" }
+	getk8sPathFn = func(_ *zap.SugaredLogger, _ string, _, _, _ float64) string {
+		return "/default.yaml"
+	}
+	getSetupWithManagerFn = func(_ *controllers.ContainerStorageModuleReconciler) func(_ ctrl.Manager, _ workqueue.TypedRateLimiter[reconcile.Request], _ int) error {
+		return func(_ ctrl.Manager, _ workqueue.TypedRateLimiter[reconcile.Request], _ int) error {
+			return nil
+		}
+	}
+	setupWebhooksFn = func(_ ctrl.Manager, _ operatorutils.OperatorConfig) error {
+		return errors.New("error")
+	}
+
+	initZapFlags = func() crzap.Options {
+		return crzap.Options{}
+	}
+	initFlags = func() crzap.Options {
+		LEEnabled := false
+		secureMetricsEnabled := true
+		webhooksEnabled := true
+		metricsBindAddress := ":8082"
+		healthProbeBindAddress := ":8081"
+		flags.metricsBindAddress = &metricsBindAddress
+		flags.healthProbeBindAddress = &healthProbeBindAddress
+		flags.leaderElect = &LEEnabled
+		flags.secureMetrics = &secureMetricsEnabled
+		flags.enableWebhooks = &webhooksEnabled
+		opts := initZapFlags()
+		return opts
+	}
+
+	osExitCalled := make(chan struct{})
+	osExit = func(_ int) {
+		osExitCalled <- struct{}{}
+	}
+
+	getConfigOrDie = func() *rest.Config {
+		return &rest.Config{
+			Host: "https://127.0.0.1:6443",
+		}
+	}
+
+	newManager = func(_ *rest.Config, _ manager.Options) (manager.Manager, error) {
+		return &mockManager{
+			Cluster: &mockCluster{},
+		}, nil
+	}
+
+	newConfigOrDie = func(_ *rest.Config) *kubernetes.Clientset {
+		return &kubernetes.Clientset{}
+	}
+
+	go func() {
+		main()
+	}()
+
+	<-osExitCalled
+}
+
 func TestMainAddHealthzCheckError(_ *testing.T) {
 	originalIsOpenShift := isOpenShift
 	originalGetKubeAPIServerVersion := getKubeAPIServerVersion
@@ -1146,6 +1231,39 @@ func TestInitFlags(t *testing.T) {
 	// Should be set to true
 	devFlag := opts.Development
 	assert.Equal(t, true, devFlag)
+	// CSMs are reconciled one at a time by default
+	assert.Equal(t, 1, *flags.maxReconcilers)
+	// template bundles are off by default
+	assert.Empty(t, *flags.templateBundleNamespace)
+	assert.Empty(t, *flags.templateBundleOCI)
+	assert.False(t, *flags.templateBundlePlainHTTP)
+	assert.Empty(t, *flags.templateBundlePublicKey)
+	assert.False(t, *flags.templateBundleAllowUnsigned)
+}
+
+func TestSetupTemplateBundles(t *testing.T) {
+	defer func(namespace, oci, publicKey *string, allowUnsigned *bool) {
+		flags.templateBundleNamespace, flags.templateBundleOCI, flags.templateBundlePublicKey = namespace, oci, publicKey
+		flags.templateBundleAllowUnsigned = allowUnsigned
+	}(flags.templateBundleNamespace, flags.templateBundleOCI, flags.templateBundlePublicKey, flags.templateBundleAllowUnsigned)
+	mgr := &mockManager{Cluster: &mockCluster{}}
+	config := operatorutils.OperatorConfig{ConfigDirectory: t.TempDir()}
+
+	// no bundle source, no public key needed
+	flags.templateBundleNamespace, flags.templateBundleOCI, flags.templateBundlePublicKey, flags.templateBundleAllowUnsigned = nil, nil, nil, nil
+	assert.NoError(t, setupTemplateBundles(mgr, config, nil))
+
+	// a bundle source requires a public key, unless unsigned bundles are allowed
+	oci := "registry.local/dell/csm-templates:v2.17.2"
+	flags.templateBundleOCI = &oci
+	assert.ErrorContains(t, setupTemplateBundles(mgr, config, nil), "--template-bundle-public-key is required")
+	allowUnsigned := true
+	flags.templateBundleAllowUnsigned = &allowUnsigned
+	assert.NoError(t, setupTemplateBundles(mgr, config, nil))
+
+	publicKey := filepath.Join(t.TempDir(), "missing.pem")
+	flags.templateBundlePublicKey = &publicKey
+	assert.ErrorContains(t, setupTemplateBundles(mgr, config, nil), "reading the template bundle public key")
 }
 
 type mockManager struct {
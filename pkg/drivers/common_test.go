@@ -45,7 +45,7 @@ func csmWithTolerations(driver csmv1.DriverType, version string, csmVersion stri
 	res := shared.MakeCSM("csm", "driver-test", shared.ConfigVersion)
 
 	// Add tolerations, node selector to controller and node
-	res.Spec.Driver.Node = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Node = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Node != nil {
 		res.Spec.Driver.Node.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 		res.Spec.Driver.Node.Tolerations = []corev1.Toleration{
@@ -61,7 +61,7 @@ func csmWithTolerations(driver csmv1.DriverType, version string, csmVersion stri
 			},
 		}
 	}
-	res.Spec.Driver.Controller = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Controller = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Controller != nil {
 		res.Spec.Driver.Controller.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 		res.Spec.Driver.Controller.Tolerations = []corev1.Toleration{
@@ -165,7 +165,7 @@ func csmForPowerFlex(customCSMName string) csmv1.ContainerStorageModule {
 	// Add pflex driver version
 	res.Spec.Driver.ConfigVersion = shared.PFlexConfigVersion
 	res.Spec.Driver.CSIDriverType = csmv1.PowerFlex
-	res.Spec.Driver.Node = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Node = &csmv1.PodTemplate{}
 	res.Spec.Driver.Common = &csmv1.ContainerTemplate{}
 
 	// Add Authorization and Replication modules
@@ -251,7 +251,7 @@ func csmWithPowerstore(driver csmv1.DriverType, version string) csmv1.ContainerS
 	healthMonitor := corev1.EnvVar{Name: "X_CSI_HEALTH_MONITOR_ENABLED", Value: "true"}
 	maxVolumesPerNode := corev1.EnvVar{Name: "X_CSI_POWERSTORE_MAX_VOLUMES_PER_NODE", Value: "0"}
 	// Add NodeSelector to node and controller
-	res.Spec.Driver.Node = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Node = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Node != nil {
 		res.Spec.Driver.Node.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 		res.Spec.Driver.Node.Envs = []corev1.EnvVar{enableChap, healthMonitor, maxVolumesPerNode}
@@ -261,7 +261,7 @@ func csmWithPowerstore(driver csmv1.DriverType, version string) csmv1.ContainerS
 	nfsAclsParam := corev1.EnvVar{Name: "X_CSI_NFS_ACLS"}
 	externalAccess := corev1.EnvVar{Name: "X_CSI_POWERSTORE_EXTERNAL_ACCESS"}
 	exclusiveAccess := corev1.EnvVar{Name: "X_CSI_POWERSTORE_EXCLUSIVE_ACCESS"}
-	res.Spec.Driver.Controller = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Controller = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Controller != nil {
 		res.Spec.Driver.Controller.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 		res.Spec.Driver.Controller.Envs = []corev1.EnvVar{nfsAclsParam, healthMonitor, externalAccess, exclusiveAccess}
@@ -309,7 +309,7 @@ func csmWithPowermax(driver csmv1.DriverType, version string) csmv1.ContainerSto
 	}
 
 	// Add node fields specific to powermax
-	res.Spec.Driver.Node = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Node = &csmv1.PodTemplate{}
 	enableChap := corev1.EnvVar{Name: "X_CSI_POWERMAX_ISCSI_ENABLE_CHAP", Value: "true"}
 	healthMonitor := corev1.EnvVar{Name: "X_CSI_HEALTH_MONITOR_ENABLED", Value: "true"}
 	nodeTopology := corev1.EnvVar{Name: "X_CSI_TOPOLOGY_CONTROL_ENABLED", Value: "true"}
@@ -320,7 +320,7 @@ func csmWithPowermax(driver csmv1.DriverType, version string) csmv1.ContainerSto
 	}
 
 	// Add controller fields specific to powermax
-	res.Spec.Driver.Controller = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Controller = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Controller != nil {
 		res.Spec.Driver.Controller.Envs = []corev1.EnvVar{healthMonitor}
 	}
@@ -438,7 +438,7 @@ func csmWithPowerScale(driver csmv1.DriverType, version string) csmv1.ContainerS
 	healthMonitor := corev1.EnvVar{Name: "X_CSI_HEALTH_MONITOR_ENABLED", Value: "true"}
 
 	// Add node fields specific
-	res.Spec.Driver.Node = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Node = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Node != nil {
 		res.Spec.Driver.Node.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 		res.Spec.Driver.Node.Envs = []corev1.EnvVar{csiLogLevel}
@@ -446,7 +446,7 @@ func csmWithPowerScale(driver csmv1.DriverType, version string) csmv1.ContainerS
 	}
 
 	// Add controller fields specific
-	res.Spec.Driver.Controller = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Controller = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Controller != nil {
 		res.Spec.Driver.Controller.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 		res.Spec.Driver.Controller.Envs = []corev1.EnvVar{csiLogLevel}
@@ -505,11 +505,11 @@ func csmWithUnity(driver csmv1.DriverType, version string, certProvided bool) cs
 	res.Spec.Driver.CSIDriverType = driver
 
 	// Add NodeSelector to node and controller
-	res.Spec.Driver.Node = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Node = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Node != nil {
 		res.Spec.Driver.Node.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 	}
-	res.Spec.Driver.Controller = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Controller = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Controller != nil {
 		res.Spec.Driver.Controller.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 	}
@@ -564,11 +564,11 @@ func csmWithUnityInvalidValue(driver csmv1.DriverType, version string) csmv1.Con
 	res.Spec.Driver.CSIDriverType = driver
 
 	// Add NodeSelector to node and controller
-	res.Spec.Driver.Node = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Node = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Node != nil {
 		res.Spec.Driver.Node.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 	}
-	res.Spec.Driver.Controller = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Controller = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Controller != nil {
 		res.Spec.Driver.Controller.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 	}
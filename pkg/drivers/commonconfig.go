@@ -15,8 +15,6 @@ package drivers
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 	"slices"
 	"strings"
 
@@ -24,8 +22,10 @@ import (
 	"github.com/dell/csm-operator/pkg/logger"
 	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	applyv1 "k8s.io/client-go/applyconfigurations/apps/v1"
 	acorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	metacv1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -101,7 +101,7 @@ func GetController(ctx context.Context, cr csmv1.ContainerStorageModule, operato
 	}
 	controllerPath := fmt.Sprintf("%s/driverconfig/%s/%s/controller.yaml", operatorConfig.ConfigDirectory, driverName, version)
 	log.Debugw("GetController", "controllerPath", controllerPath)
-	buf, err := os.ReadFile(filepath.Clean(controllerPath))
+	buf, err := operatorutils.Templates(operatorConfig.ConfigDirectory).DriverFile(driverName, version, "controller.yaml")
 	if err != nil {
 		log.Errorw("GetController failed", "Error", err.Error())
 		return nil, err
@@ -163,6 +163,14 @@ func GetController(ctx context.Context, cr csmv1.ContainerStorageModule, operato
 		controllerYAML.Deployment.Spec.Template.Spec.NodeSelector = cr.Spec.Driver.Controller.NodeSelector
 	}
 
+	err = operatorutils.UpdatePodSchedulingApply(cr.Spec.Driver.Controller, controllerYAML.Deployment.Spec.Template.Spec)
+	if err != nil {
+		log.Errorw("GetController apply pod scheduling failed", "Error", err.Error())
+		return nil, err
+	}
+
+	operatorutils.SetImagePullSecretsApply(cr.Spec.ImagePullSecrets, controllerYAML.Deployment.Spec.Template.Spec)
+
 	// Driver container image override priority (highest wins):
 	//
 	//   1. ConfigMap version match (spec.version) -- image resolved from the
@@ -229,6 +237,12 @@ func GetController(ctx context.Context, cr csmv1.ContainerStorageModule, operato
 			containers[i].Env = operatorutils.ReplaceAllApplyCustomEnvs(c.Env, commonEnvs, controllerEnvs)
 			c.Env = containers[i].Env
 
+			err = updateDriverContainerResources(&c, cr.Spec.Driver.Common, cr.Spec.Driver.Controller)
+			if err != nil {
+				log.Errorw("GetController apply container resources failed", "Error", err.Error())
+				return nil, err
+			}
+
 			SetDriverMetrics(driverType, cr, &c)
 		}
 
@@ -264,7 +278,9 @@ func GetController(ctx context.Context, cr csmv1.ContainerStorageModule, operato
 			// Running it again would double-resolve the image, which corrupts
 			// the path when retainImageRegistryPath is true.
 			if string(*c.Name) != "driver" && string(*c.Name) != "objectstorage-provisioner" {
-				operatorutils.UpdateSideCarApply(ctx, cr.Spec.Driver.SideCars, &c, cr, matched)
+				if err := operatorutils.UpdateSideCarApply(ctx, cr.Spec.Driver.SideCars, &c, cr, matched); err != nil {
+					return nil, err
+				}
 			}
 			newcontainers = append(newcontainers, c)
 		}
@@ -335,6 +351,20 @@ func GetController(ctx context.Context, cr csmv1.ContainerStorageModule, operato
 	return &controllerYAML, nil
 }
 
+// updateDriverContainerResources - applies the resources and security context of the common template and then of the
+// controller or node template to the driver container
+func updateDriverContainerResources(c *acorev1.ContainerApplyConfiguration, common *csmv1.ContainerTemplate, pod *csmv1.PodTemplate) error {
+	if common != nil {
+		if err := operatorutils.UpdateContainerResourcesApply(*common, c); err != nil {
+			return err
+		}
+	}
+	if pod != nil {
+		return operatorutils.UpdateContainerResourcesApply(pod.ContainerTemplate, c)
+	}
+	return nil
+}
+
 func isDriverMetricsTLSEnabled(cr csmv1.ContainerStorageModule) bool {
 	return cr.Spec.Driver.Metrics != nil && cr.Spec.Driver.Metrics.Enabled && cr.Spec.Driver.Metrics.TLSCertSecret != ""
 }
@@ -378,7 +408,7 @@ func GetNode(ctx context.Context, cr csmv1.ContainerStorageModule, operatorConfi
 	}
 	configMapPath := fmt.Sprintf("%s/driverconfig/%s/%s/%s", operatorConfig.ConfigDirectory, driverType, version, filename)
 	log.Debugw("GetNode", "configMapPath", configMapPath)
-	buf, err := os.ReadFile(filepath.Clean(configMapPath))
+	buf, err := operatorutils.Templates(operatorConfig.ConfigDirectory).DriverFile(driverType, version, filename)
 	if err != nil {
 		log.Errorw("GetNode failed", "Error", err.Error())
 		return nil, err
@@ -418,6 +448,11 @@ func GetNode(ctx context.Context, cr csmv1.ContainerStorageModule, operatorConfi
 		nodeYaml.DaemonSetApplyConfig.Spec.Template.Spec.DNSPolicy = &defaultDNSPolicy
 	}
 
+	// with spec.driver.nodeRollout, node pods are only replaced when the operator deletes them
+	if cr.IsNodeRolloutEnabled() {
+		nodeYaml.DaemonSetApplyConfig.Spec.WithUpdateStrategy(applyv1.DaemonSetUpdateStrategy().WithType(appsv1.OnDeleteDaemonSetStrategyType))
+	}
+
 	if cr.Spec.Driver.Node != nil && len(cr.Spec.Driver.Node.Tolerations) != 0 {
 		tols := make([]acorev1.TolerationApplyConfiguration, 0)
 		for _, t := range cr.Spec.Driver.Node.Tolerations {
@@ -439,6 +474,14 @@ func GetNode(ctx context.Context, cr csmv1.ContainerStorageModule, operatorConfi
 		nodeYaml.DaemonSetApplyConfig.Spec.Template.Spec.NodeSelector = cr.Spec.Driver.Node.NodeSelector
 	}
 
+	err = operatorutils.UpdatePodSchedulingApply(cr.Spec.Driver.Node, nodeYaml.DaemonSetApplyConfig.Spec.Template.Spec)
+	if err != nil {
+		log.Errorw("GetNode apply pod scheduling failed", "Error", err.Error())
+		return nil, err
+	}
+
+	operatorutils.SetImagePullSecretsApply(cr.Spec.ImagePullSecrets, nodeYaml.DaemonSetApplyConfig.Spec.Template.Spec)
+
 	// Driver container image override -- same priority as GetController:
 	// 1. ConfigMap version match, 2. CustomRegistry, 3. Common.Image, 4. Template default.
 	found := false
@@ -485,6 +528,12 @@ func GetNode(ctx context.Context, cr csmv1.ContainerStorageModule, operatorConfi
 			}
 			containers[i].Env = operatorutils.ReplaceAllApplyCustomEnvs(c.Env, commonEnvs, nodeEnvs)
 			c.Env = containers[i].Env
+
+			err = updateDriverContainerResources(&c, cr.Spec.Driver.Common, cr.Spec.Driver.Node)
+			if err != nil {
+				log.Errorw("GetNode apply container resources failed", "Error", err.Error())
+				return nil, err
+			}
 		}
 		removeContainer := false
 		if string(*c.Name) == "sdc-monitor" {
@@ -511,13 +560,15 @@ func GetNode(ctx context.Context, cr csmv1.ContainerStorageModule, operatorConfi
 			}
 		}
 		if !removeContainer {
-			operatorutils.ReplaceAllContainerImageApply(operatorConfig.K8sVersion, &containers[i])
+			operatorutils.ReplaceAllContainerImageApply(operatorConfig.K8sVersion, &c)
 			// Skip UpdateSideCarApply for the driver container — its image was
 			// already resolved above (ConfigMap / CustomRegistry / Default).
 			// Running it again would double-resolve the image, which corrupts
 			// the path when retainImageRegistryPath is true.
 			if string(*c.Name) != "driver" && string(*c.Name) != "objectstorage-provisioner" {
-				operatorutils.UpdateSideCarApply(ctx, cr.Spec.Driver.SideCars, &containers[i], cr, matched)
+				if err := operatorutils.UpdateSideCarApply(ctx, cr.Spec.Driver.SideCars, &c, cr, matched); err != nil {
+					return nil, err
+				}
 			}
 			newcontainers = append(newcontainers, c)
 		}
@@ -551,7 +602,9 @@ func GetNode(ctx context.Context, cr csmv1.ContainerStorageModule, operatorConfi
 
 	for i := range initcontainers {
 		operatorutils.ReplaceAllContainerImageApply(operatorConfig.K8sVersion, &initcontainers[i])
-		operatorutils.UpdateInitContainerApply(ctx, updatedCr.Spec.Driver.InitContainers, &initcontainers[i], cr, matched)
+		if err := operatorutils.UpdateInitContainerApply(ctx, updatedCr.Spec.Driver.InitContainers, &initcontainers[i], cr, matched); err != nil {
+			return nil, err
+		}
 		// mdm-container is exclusive to powerflex driver deamonset, will use the driver image as an init container
 		if *initcontainers[i].Name == "mdm-container" {
 			// driver minimial manifest may not have common section
@@ -637,7 +690,7 @@ func GetUpgradeInfo(ctx context.Context, operatorConfig operatorutils.OperatorCo
 	upgradeInfoPath := fmt.Sprintf("%s/driverconfig/%s/%s/upgrade-path.yaml", operatorConfig.ConfigDirectory, driverType, oldVersion)
 	log.Debugw("GetUpgradeInfo", "upgradeInfoPath", upgradeInfoPath)
 
-	buf, err := os.ReadFile(filepath.Clean(upgradeInfoPath))
+	buf, err := operatorutils.Templates(operatorConfig.ConfigDirectory).DriverFile(driverType, oldVersion, "upgrade-path.yaml")
 	if err != nil {
 		log.Errorw("GetUpgradeInfo failed", "Error", err.Error())
 		return "", err
@@ -667,7 +720,7 @@ func GetConfigMap(ctx context.Context, cr csmv1.ContainerStorageModule, operator
 	configMapPath := fmt.Sprintf("%s/driverconfig/%s/%s/driver-config-params.yaml", operatorConfig.ConfigDirectory, driverName, version)
 	log.Debugw("GetConfigMap", "configMapPath", configMapPath)
 
-	buf, err := os.ReadFile(filepath.Clean(configMapPath))
+	buf, err := operatorutils.Templates(operatorConfig.ConfigDirectory).DriverFile(driverName, version, "driver-config-params.yaml")
 	if err != nil {
 		log.Errorw("GetConfigMap failed", "Error", err.Error())
 		return nil, err
@@ -750,7 +803,7 @@ func GetCSIDriver(ctx context.Context, cr csmv1.ContainerStorageModule, operator
 	}
 	configMapPath := fmt.Sprintf("%s/driverconfig/%s/%s/csidriver.yaml", operatorConfig.ConfigDirectory, driverName, version)
 	log.Debugw("GetCSIDriver", "configMapPath", configMapPath)
-	buf, err := os.ReadFile(filepath.Clean(configMapPath))
+	buf, err := operatorutils.Templates(operatorConfig.ConfigDirectory).DriverFile(driverName, version, "csidriver.yaml")
 	if err != nil {
 		log.Errorw("GetCSIDriver failed", "Error", err.Error())
 		return nil, err
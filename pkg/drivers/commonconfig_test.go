@@ -21,8 +21,10 @@ import (
 	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
 	shared "github.com/dell/csm-operator/tests/sharedutil"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	ctrlClientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -452,6 +454,21 @@ func TestGetNode_SDCImageFromConfigMap(t *testing.T) {
 	assert.True(t, foundSDC, "expected to find sdc init container in PowerFlex node DaemonSet")
 }
 
+func TestGetNode_NodeRollout(t *testing.T) {
+	ctx := context.Background()
+	cr := csmForPowerFlex(pflexCSMName)
+
+	node, err := GetNode(ctx, cr, config, csmv1.PowerFlex, "node.yaml", ctrlClientFake.NewClientBuilder().Build(), operatorutils.VersionSpec{})
+	assert.Nil(t, err)
+	assert.Nil(t, node.DaemonSetApplyConfig.Spec.UpdateStrategy)
+
+	// the operator replaces the node pods itself with spec.driver.nodeRollout
+	cr.Spec.Driver.NodeRollout = &csmv1.NodeRolloutStrategy{Enabled: true}
+	node, err = GetNode(ctx, cr, config, csmv1.PowerFlex, "node.yaml", ctrlClientFake.NewClientBuilder().Build(), operatorutils.VersionSpec{})
+	assert.Nil(t, err)
+	assert.Equal(t, appsv1.OnDeleteDaemonSetStrategyType, *node.DaemonSetApplyConfig.Spec.UpdateStrategy.Type)
+}
+
 func TestGetNode_SDCImageFromCustomRegistry(t *testing.T) {
 	ctx := context.Background()
 
@@ -577,6 +594,34 @@ func TestGetNode_SDCNeitherConfigMapNorRegistry(t *testing.T) {
 	assert.True(t, foundSDC, "expected to find sdc init container")
 }
 
+func TestGetNode_SideCarOverrides(t *testing.T) {
+	ctx := context.Background()
+	cr := csmWithPowerstore(csmv1.PowerStore, shared.PStoreConfigVersion)
+	cr.Spec.Driver.SideCars = []csmv1.ContainerTemplate{{
+		Name: "registrar",
+		Args: []string{"--v=3"},
+		Envs: []corev1.EnvVar{{Name: "ADDRESS", Value: "/csi/unit_test_sock"}},
+	}}
+
+	node, err := GetNode(ctx, cr, config, csmv1.PowerStore, "node.yaml", ctrlClientFake.NewClientBuilder().Build(), operatorutils.VersionSpec{})
+	assert.Nil(t, err)
+	found := false
+	for _, c := range node.DaemonSetApplyConfig.Spec.Template.Spec.Containers {
+		if *c.Name == "registrar" {
+			found = true
+			assert.Contains(t, c.Args, "--v=3")
+			foundEnv := false
+			for _, e := range c.Env {
+				if e.Name != nil && e.Value != nil && *e.Name == "ADDRESS" && *e.Value == "/csi/unit_test_sock" {
+					foundEnv = true
+				}
+			}
+			assert.True(t, foundEnv, "expected the registrar container to have the env var of the sidecar")
+		}
+	}
+	assert.True(t, found, "expected to find the registrar container in node daemonset")
+}
+
 func TestGetController_DriverImageFromConfigMap(t *testing.T) {
 	ctx := context.Background()
 
@@ -975,6 +1020,100 @@ func TestGetNode_SDCImageCustomRegistryRetainPath(t *testing.T) {
 	assert.True(t, foundSDC, "expected to find sdc init container in node daemonset")
 }
 
+func TestGetControllerAndNodeSchedulingAndResources(t *testing.T) {
+	ctx := context.Background()
+	runAsNonRoot := true
+	cr := csmWithPowerstore(csmv1.PowerStore, shared.PStoreConfigVersion)
+	cr.Spec.Driver.Common.Resources = &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+	}
+	cr.Spec.Driver.Controller = &csmv1.PodTemplate{
+		ContainerTemplate: csmv1.ContainerTemplate{
+			SecurityContext: &corev1.SecurityContext{RunAsNonRoot: &runAsNonRoot},
+		},
+		Affinity: &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				}},
+			},
+		},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		}},
+	}
+	cr.Spec.Driver.Node = &csmv1.PodTemplate{
+		ContainerTemplate: csmv1.ContainerTemplate{
+			Resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+		},
+		PriorityClassName: "system-node-critical",
+	}
+	cr.Spec.Driver.SideCars = []csmv1.ContainerTemplate{{
+		Name: "provisioner",
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+		},
+	}, {
+		Name: "registrar",
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+		},
+	}}
+
+	controller, err := GetController(ctx, cr, config, csmv1.PowerStore, operatorutils.VersionSpec{})
+	assert.Nil(t, err)
+	podSpec := controller.Deployment.Spec.Template.Spec
+	assert.Equal(t, int32(100), *podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Weight)
+	assert.Equal(t, "topology.kubernetes.io/zone", *podSpec.TopologySpreadConstraints[0].TopologyKey)
+	assert.Nil(t, podSpec.PriorityClassName)
+	for _, c := range podSpec.Containers {
+		switch *c.Name {
+		case "driver":
+			assert.Equal(t, "100m", c.Resources.Requests.Cpu().String())
+			assert.True(t, *c.SecurityContext.RunAsNonRoot)
+		case "provisioner":
+			assert.Equal(t, "200m", c.Resources.Limits.Cpu().String())
+		}
+	}
+
+	node, err := GetNode(ctx, cr, config, csmv1.PowerStore, "node.yaml", ctrlClientFake.NewClientBuilder().Build(), operatorutils.VersionSpec{})
+	assert.Nil(t, err)
+	podSpec = node.DaemonSetApplyConfig.Spec.Template.Spec
+	assert.Equal(t, "system-node-critical", *podSpec.PriorityClassName)
+	assert.Nil(t, podSpec.Affinity)
+	for _, c := range podSpec.Containers {
+		switch *c.Name {
+		case "driver":
+			// node resources replace the common resources
+			assert.Nil(t, c.Resources.Requests)
+			assert.Equal(t, "256Mi", c.Resources.Limits.Memory().String())
+		case "registrar":
+			assert.Equal(t, "50m", c.Resources.Limits.Cpu().String())
+		}
+	}
+}
+
+func TestGetControllerAndNodeImagePullSecrets(t *testing.T) {
+	ctx := context.Background()
+	cr := csmWithPowerstore(csmv1.PowerStore, shared.PStoreConfigVersion)
+	cr.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "registry"}}
+
+	controller, err := GetController(ctx, cr, config, csmv1.PowerStore, operatorutils.VersionSpec{})
+	assert.Nil(t, err)
+	assert.Equal(t, "registry", *controller.Deployment.Spec.Template.Spec.ImagePullSecrets[0].Name)
+
+	node, err := GetNode(ctx, cr, config, csmv1.PowerStore, "node.yaml", ctrlClientFake.NewClientBuilder().Build(), operatorutils.VersionSpec{})
+	assert.Nil(t, err)
+	assert.Equal(t, "registry", *node.DaemonSetApplyConfig.Spec.Template.Spec.ImagePullSecrets[0].Name)
+}
+
 func TestSubstituteEnvVar(t *testing.T) {
 	tests := []struct {
 		name       string
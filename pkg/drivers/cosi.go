@@ -16,7 +16,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"os"
 	"strings"
 
 	csmv1 "github.com/dell/csm-operator/api/v1"
@@ -37,10 +36,8 @@ func PrecheckCosi(ctx context.Context, cr *csmv1.ContainerStorageModule, operato
 		return fmt.Errorf("getting COSI version: %w", err)
 	}
 
-	// Check if driver version is supported by doing a stat on a config file
-	configFilePath := fmt.Sprintf("%s/driverconfig/%s/%s/upgrade-path.yaml", operatorConfig.ConfigDirectory, csmv1.Cosi, version)
-	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
-		log.Errorw("PreCheckCOSI failed in version check", "Error", err.Error())
+	if !operatorutils.DriverVersionSupported(csmv1.Cosi, version, operatorConfig) {
+		log.Errorw("PreCheckCOSI failed in version check", "version", version)
 		return fmt.Errorf("%s %s not supported", csmv1.Cosi, version)
 	}
 
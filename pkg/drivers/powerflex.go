@@ -15,7 +15,6 @@ package drivers
 import (
 	"context"
 	"fmt"
-	"os"
 	"reflect"
 	"regexp"
 	"strings"
@@ -129,10 +128,8 @@ func PrecheckPowerFlex(ctx context.Context, cr *csmv1.ContainerStorageModule, op
 	if err != nil {
 		return err
 	}
-	// Check if driver version is supported by doing a stat on a config file
-	configFilePath := fmt.Sprintf("%s/driverconfig/%s/%s/upgrade-path.yaml", operatorConfig.ConfigDirectory, csmv1.PowerFlex, version)
-	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
-		log.Errorw("PreCheckPowerFlex failed in version check", "Error", err.Error())
+	if !operatorutils.DriverVersionSupported(csmv1.PowerFlex, version, operatorConfig) {
+		log.Errorw("PreCheckPowerFlex failed in version check", "version", version)
 		return fmt.Errorf("%s %s not supported", csmv1.PowerFlexName, version)
 	}
 
@@ -544,84 +541,117 @@ func ValidateZones(ctx context.Context, cr *csmv1.ContainerStorageModule, ct cli
 	return err
 }
 
-// ValidateZonesInSecret - inspects incoming secret for zone validity
-func ValidateZonesInSecret(ctx context.Context, kube client.Client, namespace string, secret string) error {
-	log := logger.GetLogger(ctx)
+// powerFlexZone - zone of a PowerFlex array in the array secret
+type powerFlexZone struct {
+	Name     string `json:"name,omitempty"`
+	LabelKey string `json:"labelKey,omitempty"`
+}
+
+// powerFlexArrayConfig - PowerFlex array in the array secret
+type powerFlexArrayConfig struct {
+	SystemID string        `json:"systemID"`
+	Zone     powerFlexZone `json:"zone,omitempty"`
+}
 
+// getPowerFlexArrayConfigs - returns the arrays configured in the PowerFlex array secret
+func getPowerFlexArrayConfigs(ctx context.Context, kube client.Client, namespace string, secret string) ([]powerFlexArrayConfig, error) {
 	arraySecret, err := operatorutils.GetSecret(ctx, secret, namespace, kube)
 	if err != nil {
-		return fmt.Errorf("reading secret [%s] error %v", secret, err)
+		return nil, fmt.Errorf("reading secret [%s] error %v", secret, err)
 	}
 
-	type Zone struct {
-		Name     string `json:"name,omitempty"`
-		LabelKey string `json:"labelKey,omitempty"`
+	data := arraySecret.Data
+	configBytes := data["config"]
+
+	if string(configBytes) == "" {
+		return nil, fmt.Errorf("array details are not provided in secret")
 	}
 
-	type StorageArrayConfig struct {
-		SystemID string `json:"systemID"`
-		Zone     Zone   `json:"zone,omitempty"`
+	yamlConfig := make([]powerFlexArrayConfig, 0)
+	configs, err := yaml.JSONToYAML(configBytes)
+	if err != nil {
+		return nil, fmt.Errorf("malformed json in array secret - unable to parse multi-array configuration %v", err)
+	}
+	err = yaml.Unmarshal(configs, &yamlConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal array secret %v", err)
 	}
+	return yamlConfig, nil
+}
 
-	data := arraySecret.Data
-	configBytes := data["config"]
+// ValidateZonesInSecret - inspects incoming secret for zone validity
+func ValidateZonesInSecret(ctx context.Context, kube client.Client, namespace string, secret string) error {
+	log := logger.GetLogger(ctx)
 
-	if string(configBytes) != "" {
-		yamlConfig := make([]StorageArrayConfig, 0)
-		configs, err := yaml.JSONToYAML(configBytes)
-		if err != nil {
-			return fmt.Errorf("malformed json in array secret - unable to parse multi-array configuration %v", err)
-		}
-		err = yaml.Unmarshal(configs, &yamlConfig)
-		if err != nil {
-			return fmt.Errorf("unable to unmarshal array secret %v", err)
-		}
+	yamlConfig, err := getPowerFlexArrayConfigs(ctx, kube, namespace, secret)
+	if err != nil {
+		return err
+	}
 
-		var labelKey string
-		var numArrays, numArraysWithZone int
-		numArrays = len(yamlConfig)
-		for _, configParam := range yamlConfig {
-			if configParam.SystemID == "" {
-				return fmt.Errorf("invalid value for SystemID")
+	var labelKey string
+	var numArrays, numArraysWithZone int
+	numArrays = len(yamlConfig)
+	for _, configParam := range yamlConfig {
+		if configParam.SystemID == "" {
+			return fmt.Errorf("invalid value for SystemID")
+		}
+		if reflect.DeepEqual(configParam.Zone, powerFlexZone{}) {
+			log.Infof("Zone is not specified for SystemID: %s", configParam.SystemID)
+		} else {
+			log.Infof("Zone is specified for SystemID: %s", configParam.SystemID)
+			if configParam.Zone.LabelKey == "" {
+				return fmt.Errorf("zone LabelKey is empty or not specified for SystemID: %s",
+					configParam.SystemID)
 			}
-			if reflect.DeepEqual(configParam.Zone, Zone{}) {
-				log.Infof("Zone is not specified for SystemID: %s", configParam.SystemID)
-			} else {
-				log.Infof("Zone is specified for SystemID: %s", configParam.SystemID)
-				if configParam.Zone.LabelKey == "" {
-					return fmt.Errorf("zone LabelKey is empty or not specified for SystemID: %s",
-						configParam.SystemID)
-				}
 
-				if labelKey == "" {
-					labelKey = configParam.Zone.LabelKey
-				} else {
-					if labelKey != configParam.Zone.LabelKey {
-						return fmt.Errorf("labelKey is not consistent across all arrays in secret")
-					}
+			if labelKey == "" {
+				labelKey = configParam.Zone.LabelKey
+			} else {
+				if labelKey != configParam.Zone.LabelKey {
+					return fmt.Errorf("labelKey is not consistent across all arrays in secret")
 				}
+			}
 
-				if configParam.Zone.Name == "" {
-					return fmt.Errorf("zone name is empty or not specified for SystemID: %s",
-						configParam.SystemID)
-				}
-				numArraysWithZone++
+			if configParam.Zone.Name == "" {
+				return fmt.Errorf("zone name is empty or not specified for SystemID: %s",
+					configParam.SystemID)
 			}
+			numArraysWithZone++
 		}
+	}
 
-		log.Infof("found %d arrays zoning on %d", numArrays, numArraysWithZone)
-		if numArraysWithZone > 0 && numArrays != numArraysWithZone {
-			return fmt.Errorf("not all arrays have zoning configured. Check the array info secret, zone key should be the same for all arrays")
-		} else if numArraysWithZone == 0 {
-			log.Info("Zoning information not found in the array secret. Continue with topology-unaware driver installation mode")
-		}
-	} else {
-		return fmt.Errorf("array details are not provided in secret")
+	log.Infof("found %d arrays zoning on %d", numArrays, numArraysWithZone)
+	if numArraysWithZone > 0 && numArrays != numArraysWithZone {
+		return fmt.Errorf("not all arrays have zoning configured. Check the array info secret, zone key should be the same for all arrays")
+	} else if numArraysWithZone == 0 {
+		log.Info("Zoning information not found in the array secret. Continue with topology-unaware driver installation mode")
 	}
 
 	return nil
 }
 
+// GetPowerFlexZoneTopologies - returns the topology of the zone of each PowerFlex system in the array secret
+func GetPowerFlexZoneTopologies(ctx context.Context, cr csmv1.ContainerStorageModule, kube client.Client) (map[string][]corev1.TopologySelectorTerm, error) {
+	yamlConfig, err := getPowerFlexArrayConfigs(ctx, kube, cr.Namespace, cr.Name+"-config")
+	if err != nil {
+		return nil, err
+	}
+
+	topologies := make(map[string][]corev1.TopologySelectorTerm)
+	for _, configParam := range yamlConfig {
+		if configParam.Zone.LabelKey == "" || configParam.Zone.Name == "" {
+			continue
+		}
+		topologies[configParam.SystemID] = []corev1.TopologySelectorTerm{{
+			MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{{
+				Key:    configParam.Zone.LabelKey,
+				Values: []string{configParam.Zone.Name},
+			}},
+		}}
+	}
+	return topologies, nil
+}
+
 func RemoveVolume(configuration *v1.DaemonSetApplyConfiguration, volumeName string) error {
 	if configuration == nil {
 		return fmt.Errorf("RemoveVolume called with a nil daemonset")
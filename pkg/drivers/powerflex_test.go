@@ -91,13 +91,13 @@ var (
 			cr: csmv1.ContainerStorageModule{
 				Spec: csmv1.ContainerStorageModuleSpec{
 					Driver: csmv1.Driver{
-						Controller: &csmv1.ContainerTemplate{
+						Controller: &csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 							Envs: []corev1.EnvVar{
 								{Name: "X_CSI_POWERFLEX_EXTERNAL_ACCESS", Value: "NEW_POWERFLEX_ACCESS"},
 								{Name: "X_CSI_HEALTH_MONITOR_ENABLED", Value: "NEW_HEALTH_MONITOR"},
 								{Name: "X_CSI_DEBUG", Value: "NEW_DEBUG"},
 							},
-						},
+						}},
 					},
 				},
 			},
@@ -110,7 +110,7 @@ var (
 			cr: csmv1.ContainerStorageModule{
 				Spec: csmv1.ContainerStorageModuleSpec{
 					Driver: csmv1.Driver{
-						Node: &csmv1.ContainerTemplate{
+						Node: &csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 							Envs: []corev1.EnvVar{
 								{Name: "X_CSI_SDC_ENABLED", Value: "NEW_SDC_ENABLED"},
 								{Name: "X_CSI_APPROVE_SDC_ENABLED", Value: "NEW_APPROVE_SDC"},
@@ -127,7 +127,7 @@ var (
 								{Name: "X_CSI_FS_CHECK_ENABLED", Value: "false"},
 								{Name: "X_CSI_FS_CHECK_MODE", Value: "checkOnly"},
 							},
-						},
+						}},
 					},
 				},
 			},
@@ -16,7 +16,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
@@ -119,10 +118,8 @@ func PrecheckPowerMax(ctx context.Context, cr *csmv1.ContainerStorageModule, ope
 	if err != nil {
 		return err
 	}
-	// Check if driver version is supported by doing a stat on a config file
-	configFilePath := fmt.Sprintf("%s/driverconfig/powermax/%s/upgrade-path.yaml", operatorConfig.ConfigDirectory, version)
-	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
-		log.Errorw("PreCheckPowerMax failed in version check", "Error", err.Error())
+	if !operatorutils.DriverVersionSupported(csmv1.PowerMax, version, operatorConfig) {
+		log.Errorw("PreCheckPowerMax failed in version check", "version", version)
 		return fmt.Errorf("%s %s not supported", csmv1.PowerMax, version)
 	}
 
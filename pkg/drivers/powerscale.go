@@ -15,7 +15,6 @@ package drivers
 import (
 	"context"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
@@ -61,10 +60,8 @@ func PrecheckPowerScale(ctx context.Context, cr *csmv1.ContainerStorageModule, o
 	if err != nil {
 		return err
 	}
-	// Check if driver version is supported by doing a stat on a config file
-	configFilePath := fmt.Sprintf("%s/driverconfig/%s/%s/upgrade-path.yaml", operatorConfig.ConfigDirectory, csmv1.PowerScaleName, version)
-	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
-		log.Errorw("PreCheckPowerScale failed in version check", "Error", err.Error())
+	if !operatorutils.DriverVersionSupported(csmv1.PowerScaleName, version, operatorConfig) {
+		log.Errorw("PreCheckPowerScale failed in version check", "version", version)
 		return fmt.Errorf("%s %s not supported", csmv1.PowerScaleName, version)
 	}
 
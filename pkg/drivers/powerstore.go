@@ -15,7 +15,6 @@ package drivers
 import (
 	"context"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
@@ -117,10 +116,8 @@ func PrecheckPowerStore(ctx context.Context, cr *csmv1.ContainerStorageModule, o
 	if err != nil {
 		return err
 	}
-	// Check if driver version is supported by doing a stat on a config file
-	configFilePath := fmt.Sprintf("%s/driverconfig/powerstore/%s/upgrade-path.yaml", operatorConfig.ConfigDirectory, version)
-	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
-		log.Errorw("PreCheckPowerStore failed in version check", "Error", err.Error())
+	if !operatorutils.DriverVersionSupported(csmv1.PowerStore, version, operatorConfig) {
+		log.Errorw("PreCheckPowerStore failed in version check", "version", version)
 		return fmt.Errorf("%s %s not supported", csmv1.PowerStore, version)
 	}
 
@@ -268,7 +265,7 @@ func ModifyPowerstoreCR(yamlString string, cr csmv1.ContainerStorageModule, file
 
 		if authorizationModuleFound {
 			if cr.Spec.Driver.Node == nil {
-				cr.Spec.Driver.Node = &csmv1.ContainerTemplate{}
+				cr.Spec.Driver.Node = &csmv1.PodTemplate{}
 			}
 			if cr.Spec.Driver.Node.Envs == nil {
 				cr.Spec.Driver.Node.Envs = []corev1.EnvVar{}
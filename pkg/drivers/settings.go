@@ -0,0 +1,190 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package drivers
+
+import (
+	"strconv"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// scopes of the driver env vars
+const (
+	commonScope     = "Common"
+	controllerScope = "Controller"
+	nodeScope       = "Node"
+)
+
+// driverSetting - a typed driver setting and the env var it replaces
+type driverSetting struct {
+	scope string
+	name  string
+	value string
+}
+
+type driverSettings []driverSetting
+
+func (s *driverSettings) addString(scope, name, value string) {
+	if value != "" {
+		*s = append(*s, driverSetting{scope: scope, name: name, value: value})
+	}
+}
+
+func (s *driverSettings) addBool(scope, name string, value *bool) {
+	if value != nil {
+		*s = append(*s, driverSetting{scope: scope, name: name, value: strconv.FormatBool(*value)})
+	}
+}
+
+func (s *driverSettings) addInt(scope, name string, value *int32) {
+	if value != nil {
+		*s = append(*s, driverSetting{scope: scope, name: name, value: strconv.Itoa(int(*value))})
+	}
+}
+
+func (s *driverSettings) addHealthMonitor(healthMonitor *csmv1.HealthMonitorConfig) {
+	if healthMonitor != nil {
+		s.addBool(controllerScope, "X_CSI_HEALTH_MONITOR_ENABLED", healthMonitor.Controller)
+		s.addBool(nodeScope, "X_CSI_HEALTH_MONITOR_ENABLED", healthMonitor.Node)
+	}
+}
+
+// getDriverSettings - returns the env vars set by the typed configuration of the driver
+func getDriverSettings(driver csmv1.Driver) driverSettings {
+	settings := driverSettings{}
+
+	if pflex := driver.PowerFlex; pflex != nil {
+		settings.addBool(commonScope, "GOSCALEIO_DEBUG", pflex.Debug)
+		settings.addBool(commonScope, "GOSCALEIO_SHOWHTTP", pflex.ShowHTTP)
+		settings.addString(commonScope, "X_CSI_PROBE_TIMEOUT", pflex.ProbeTimeout)
+		settings.addString(commonScope, "X_CSI_AUTH_TYPE", pflex.AuthType)
+		settings.addString(controllerScope, "X_CSI_POWERFLEX_EXTERNAL_ACCESS", pflex.ExternalAccess)
+		settings.addBool(nodeScope, "X_CSI_SDC_ENABLED", pflex.SdcEnabled)
+		settings.addBool(nodeScope, "X_CSI_APPROVE_SDC_ENABLED", pflex.ApproveSdcEnabled)
+		settings.addBool(nodeScope, "X_CSI_RENAME_SDC_ENABLED", pflex.RenameSdcEnabled)
+		settings.addString(nodeScope, "X_CSI_RENAME_SDC_PREFIX", pflex.RenameSdcPrefix)
+		settings.addInt(nodeScope, "X_CSI_MAX_VOLUMES_PER_NODE", pflex.MaxVolumesPerNode)
+		settings.addBool(nodeScope, "X_CSI_SDC_SFTP_REPO_ENABLED", pflex.SftpRepoEnabled)
+		settings.addString(nodeScope, "REPO_ADDRESS", pflex.SftpRepoAddress)
+		settings.addString(nodeScope, "REPO_USER", pflex.SftpRepoUser)
+		settings.addHealthMonitor(pflex.HealthMonitor)
+	}
+
+	if pmax := driver.PowerMax; pmax != nil {
+		settings.addString(commonScope, "X_CSI_MANAGED_ARRAYS", pmax.ManagedArrays)
+		settings.addString(commonScope, "X_CSI_POWERMAX_ENDPOINT", pmax.Endpoint)
+		settings.addBool(commonScope, "X_CSI_POWERMAX_DEBUG", pmax.Debug)
+		settings.addString(commonScope, "X_CSI_POWERMAX_PORTGROUPS", pmax.PortGroups)
+		settings.addString(commonScope, "X_CSI_TRANSPORT_PROTOCOL", pmax.TransportProtocol)
+		settings.addBool(commonScope, "X_CSI_IG_MODIFY_HOSTNAME", pmax.ModifyHostName)
+		settings.addString(commonScope, "X_CSI_IG_NODENAME_TEMPLATE", pmax.NodeNameTemplate)
+		settings.addBool(commonScope, "X_CSI_DYNAMIC_SG_ENABLED", pmax.DynamicSGEnabled)
+		if vsphere := pmax.VSphere; vsphere != nil {
+			settings.addBool(commonScope, "X_CSI_VSPHERE_ENABLED", vsphere.Enabled)
+			settings.addString(commonScope, "X_CSI_VSPHERE_PORTGROUP", vsphere.PortGroup)
+			settings.addString(commonScope, "X_CSI_VSPHERE_HOSTNAME", vsphere.HostName)
+			settings.addString(commonScope, "X_CSI_VCENTER_HOST", vsphere.VCenterHost)
+		}
+		settings.addBool(nodeScope, "X_CSI_POWERMAX_ISCSI_ENABLE_CHAP", pmax.ISCSIEnableCHAP)
+		settings.addBool(nodeScope, "X_CSI_TOPOLOGY_CONTROL_ENABLED", pmax.TopologyControlEnabled)
+		settings.addInt(nodeScope, "X_CSI_MAX_VOLUMES_PER_NODE", pmax.MaxVolumesPerNode)
+		settings.addHealthMonitor(pmax.HealthMonitor)
+	}
+
+	if pstore := driver.PowerStore; pstore != nil {
+		settings.addBool(commonScope, "GOPOWERSTORE_DEBUG", pstore.Debug)
+		settings.addString(commonScope, "X_CSI_POWERSTORE_API_TIMEOUT", pstore.APITimeout)
+		settings.addString(commonScope, "X_CSI_PODMON_ARRAY_CONNECTIVITY_TIMEOUT", pstore.PodmonArrayConnectivityTimeout)
+		settings.addString(commonScope, "X_CSI_POWERSTORE_NODE_NAME_PREFIX", pstore.NodeNamePrefix)
+		settings.addString(commonScope, "X_CSI_FC_PORTS_FILTER_FILE_PATH", pstore.FCPortsFilterFilePath)
+		settings.addString(controllerScope, "X_CSI_NFS_ACLS", pstore.NFSAcls)
+		settings.addString(controllerScope, "X_CSI_POWERSTORE_EXTERNAL_ACCESS", pstore.ExternalAccess)
+		settings.addBool(controllerScope, "X_CSI_POWERSTORE_EXCLUSIVE_ACCESS", pstore.ExclusiveAccess)
+		settings.addBool(nodeScope, "X_CSI_POWERSTORE_ENABLE_CHAP", pstore.EnableCHAP)
+		settings.addInt(nodeScope, "X_CSI_POWERSTORE_MAX_VOLUMES_PER_NODE", pstore.MaxVolumesPerNode)
+		settings.addInt(nodeScope, "X_CSI_VOLUME_DISCONNECT_MAX_RETRIES", pstore.VolumeDisconnectMaxRetries)
+		settings.addString(nodeScope, "X_CSI_VOLUME_DISCONNECT_RETRY_INTERVAL", pstore.VolumeDisconnectRetryInterval)
+		settings.addString(nodeScope, "X_CSI_VOLUME_DISCONNECT_TIMEOUT_SECONDS", pstore.VolumeDisconnectTimeout)
+		settings.addHealthMonitor(pstore.HealthMonitor)
+	}
+
+	if pscale := driver.PowerScale; pscale != nil {
+		settings.addBool(commonScope, "GOISILON_DEBUG", pscale.Debug)
+		settings.addString(controllerScope, "X_CSI_VOL_PREFIX", pscale.VolumePrefix)
+		settings.addHealthMonitor(pscale.HealthMonitor)
+	}
+
+	if unity := driver.Unity; unity != nil {
+		settings.addBool(commonScope, "GOUNITY_DEBUG", unity.Debug)
+		settings.addBool(commonScope, "GOUNITY_SHOWHTTP", unity.ShowHTTP)
+		settings.addString(nodeScope, "X_CSI_ALLOWED_NETWORKS", unity.AllowedNetworks)
+		settings.addHealthMonitor(unity.HealthMonitor)
+	}
+
+	if cosi := driver.Cosi; cosi != nil {
+		settings.addString(commonScope, "OTEL_COLLECTOR_ADDRESS", cosi.OtelCollectorAddress)
+	}
+
+	return settings
+}
+
+// ApplyDriverSettings - returns cr with the typed driver configuration merged into the Common, Controller and Node envs.
+// Typed settings take precedence over env vars of the same name, which remain a fallback.
+// The templates of cr are copied, so the caller's CR is not modified.
+func ApplyDriverSettings(cr csmv1.ContainerStorageModule) csmv1.ContainerStorageModule {
+	for _, setting := range getDriverSettings(cr.Spec.Driver) {
+		switch setting.scope {
+		case commonScope:
+			cr.Spec.Driver.Common = setTemplateEnv(cr.Spec.Driver.Common, setting.name, setting.value)
+		case controllerScope:
+			cr.Spec.Driver.Controller = setPodTemplateEnv(cr.Spec.Driver.Controller, setting.name, setting.value)
+		case nodeScope:
+			cr.Spec.Driver.Node = setPodTemplateEnv(cr.Spec.Driver.Node, setting.name, setting.value)
+		}
+	}
+	return cr
+}
+
+// setTemplateEnv - returns a copy of template with the env var set to value
+func setTemplateEnv(template *csmv1.ContainerTemplate, name, value string) *csmv1.ContainerTemplate {
+	updated := csmv1.ContainerTemplate{}
+	if template != nil {
+		updated = *template
+	}
+
+	envs := make([]corev1.EnvVar, 0, len(updated.Envs)+1)
+	found := false
+	for _, env := range updated.Envs {
+		if env.Name == name {
+			env = corev1.EnvVar{Name: name, Value: value}
+			found = true
+		}
+		envs = append(envs, env)
+	}
+	if !found {
+		envs = append(envs, corev1.EnvVar{Name: name, Value: value})
+	}
+	updated.Envs = envs
+	return &updated
+}
+
+// setPodTemplateEnv - returns a copy of template with the env var set to value
+func setPodTemplateEnv(template *csmv1.PodTemplate, name, value string) *csmv1.PodTemplate {
+	updated := csmv1.PodTemplate{}
+	if template != nil {
+		updated = *template
+	}
+	updated.ContainerTemplate = *setTemplateEnv(&updated.ContainerTemplate, name, value)
+	return &updated
+}
@@ -0,0 +1,83 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package drivers
+
+import (
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplyDriverSettings(t *testing.T) {
+	disabled := false
+	maxVolumes := int32(10)
+
+	t.Run("typed settings override env vars", func(t *testing.T) {
+		cr := csmv1.ContainerStorageModule{}
+		cr.Spec.Driver.CSIDriverType = csmv1.PowerFlex
+		cr.Spec.Driver.Node = &csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
+			Envs: []corev1.EnvVar{
+				{Name: "X_CSI_SDC_ENABLED", Value: "true"},
+				{Name: "X_CSI_RENAME_SDC_PREFIX", Value: "old"},
+			},
+		}}
+		cr.Spec.Driver.PowerFlex = &csmv1.PowerFlexConfig{
+			SdcEnabled:        &disabled,
+			MaxVolumesPerNode: &maxVolumes,
+			ProbeTimeout:      "20s",
+			HealthMonitor:     &csmv1.HealthMonitorConfig{Controller: &disabled},
+		}
+
+		applied := ApplyDriverSettings(cr)
+
+		assert.Equal(t, []corev1.EnvVar{
+			{Name: "X_CSI_SDC_ENABLED", Value: "false"},
+			{Name: "X_CSI_RENAME_SDC_PREFIX", Value: "old"},
+			{Name: "X_CSI_MAX_VOLUMES_PER_NODE", Value: "10"},
+		}, applied.Spec.Driver.Node.Envs)
+		assert.Equal(t, []corev1.EnvVar{{Name: "X_CSI_PROBE_TIMEOUT", Value: "20s"}}, applied.Spec.Driver.Common.Envs)
+		assert.Equal(t, []corev1.EnvVar{{Name: "X_CSI_HEALTH_MONITOR_ENABLED", Value: "false"}}, applied.Spec.Driver.Controller.Envs)
+
+		// the caller's CR is left untouched
+		assert.Equal(t, "true", cr.Spec.Driver.Node.Envs[0].Value)
+		assert.Len(t, cr.Spec.Driver.Node.Envs, 2)
+		assert.Nil(t, cr.Spec.Driver.Common)
+	})
+
+	t.Run("env vars are kept without typed settings", func(t *testing.T) {
+		cr := csmv1.ContainerStorageModule{}
+		cr.Spec.Driver.CSIDriverType = csmv1.Unity
+		cr.Spec.Driver.Common = &csmv1.ContainerTemplate{
+			Envs: []corev1.EnvVar{{Name: "GOUNITY_DEBUG", Value: "true"}},
+		}
+
+		applied := ApplyDriverSettings(cr)
+		assert.Equal(t, cr.Spec.Driver.Common, applied.Spec.Driver.Common)
+		assert.Nil(t, applied.Spec.Driver.Node)
+	})
+
+	t.Run("typed settings replace the template placeholders", func(t *testing.T) {
+		cr := csmv1.ContainerStorageModule{}
+		cr.Spec.Driver.CSIDriverType = csmv1.PowerMax
+		cr.Spec.Driver.PowerMax = &csmv1.PowerMaxConfig{
+			PortGroups:        "pg1,pg2",
+			TransportProtocol: "ISCSI",
+			VSphere:           &csmv1.PowerMaxVSphereConfig{Enabled: &disabled},
+		}
+
+		yamlString := ModifyPowermaxCR("<X_CSI_POWERMAX_PORTGROUPS> <X_CSI_TRANSPORT_PROTOCOL> <X_CSI_VSPHERE_ENABLED>", ApplyDriverSettings(cr), "Controller")
+		assert.Equal(t, "pg1,pg2 ISCSI false", yamlString)
+	})
+}
@@ -0,0 +1,45 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package drivers
+
+import (
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// VolumeSnapshotClassGVK - group version kind of the VolumeSnapshotClass objects
+var VolumeSnapshotClassGVK = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshotClass"}
+
+// GetSnapshotClasses - returns the VolumeSnapshotClass objects for the snapshot classes of the driver
+func GetSnapshotClasses(cr csmv1.ContainerStorageModule, driverName string) []*unstructured.Unstructured {
+	classes := make([]*unstructured.Unstructured, 0, len(cr.Spec.Driver.SnapshotClass))
+	for _, sc := range cr.Spec.Driver.SnapshotClass {
+		class := &unstructured.Unstructured{}
+		class.SetGroupVersionKind(VolumeSnapshotClassGVK)
+		class.SetName(sc.Name)
+		class.SetLabels(operatorutils.GetOwnerLabels(cr))
+		class.Object["driver"] = driverName
+		class.Object["deletionPolicy"] = "Delete"
+		if len(sc.Parameters) > 0 {
+			parameters := make(map[string]interface{}, len(sc.Parameters))
+			for k, v := range sc.Parameters {
+				parameters[k] = v
+			}
+			class.Object["parameters"] = parameters
+		}
+		classes = append(classes, class)
+	}
+	return classes
+}
@@ -0,0 +1,44 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package drivers
+
+import (
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetSnapshotClasses(t *testing.T) {
+	cr := csmv1.ContainerStorageModule{ObjectMeta: metav1.ObjectMeta{Name: "powerstore", Namespace: "csi-powerstore"}}
+	assert.Empty(t, GetSnapshotClasses(cr, "csi-powerstore.dellemc.com"))
+
+	cr.Spec.Driver.SnapshotClass = []csmv1.SnapshotClass{
+		{Name: "powerstore-snapclass", Parameters: map[string]string{"csi.storage.k8s.io/snapshotter-secret-name": "powerstore-config"}},
+		{Name: "powerstore-default"},
+	}
+
+	classes := GetSnapshotClasses(cr, "csi-powerstore.dellemc.com")
+	assert.Len(t, classes, 2)
+
+	class := classes[0]
+	assert.Equal(t, VolumeSnapshotClassGVK, class.GroupVersionKind())
+	assert.Equal(t, "powerstore-snapclass", class.GetName())
+	assert.Equal(t, map[string]string{"csm": "powerstore", "csmNamespace": "csi-powerstore"}, class.GetLabels())
+	assert.Equal(t, "csi-powerstore.dellemc.com", class.Object["driver"])
+	assert.Equal(t, "Delete", class.Object["deletionPolicy"])
+	assert.Equal(t, map[string]interface{}{"csi.storage.k8s.io/snapshotter-secret-name": "powerstore-config"}, class.Object["parameters"])
+
+	assert.NotContains(t, classes[1].Object, "parameters")
+}
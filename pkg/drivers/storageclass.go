@@ -0,0 +1,104 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package drivers
+
+import (
+	"context"
+	"fmt"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// arrayIDParameters - the Storage Class parameter holding the array id for each driver
+var arrayIDParameters = map[csmv1.DriverType]string{
+	csmv1.PowerFlex:  "systemID",
+	csmv1.PowerStore: "arrayID",
+	csmv1.PowerScale: "ClusterName",
+	csmv1.PowerMax:   "SYMID",
+	csmv1.Unity:      "arrayId",
+}
+
+// GetStorageClasses - returns the StorageClass objects for the storage classes of the driver
+func GetStorageClasses(ctx context.Context, cr csmv1.ContainerStorageModule, driverName string, ctrlClient client.Client) ([]*storagev1.StorageClass, error) {
+	if len(cr.Spec.Driver.StorageClasses) == 0 {
+		return nil, nil
+	}
+
+	// zones are only configured in the PowerFlex array secret
+	zoneTopologies := map[string][]corev1.TopologySelectorTerm{}
+	if cr.GetDriverType() == csmv1.PowerFlex && needsZoneTopology(cr.Spec.Driver.StorageClasses) {
+		var err error
+		zoneTopologies, err = GetPowerFlexZoneTopologies(ctx, cr, ctrlClient)
+		if err != nil {
+			return nil, fmt.Errorf("getting zones of the storage classes: %v", err)
+		}
+	}
+
+	classes := make([]*storagev1.StorageClass, 0, len(cr.Spec.Driver.StorageClasses))
+	for _, sc := range cr.Spec.Driver.StorageClasses {
+		parameters := make(map[string]string, len(sc.Parameters)+1)
+		for k, v := range sc.Parameters {
+			parameters[k] = v
+		}
+		if sc.ArrayID != "" {
+			if key, ok := arrayIDParameters[cr.GetDriverType()]; ok {
+				parameters[key] = sc.ArrayID
+			}
+		}
+
+		class := &storagev1.StorageClass{
+			TypeMeta: metav1.TypeMeta{Kind: "StorageClass", APIVersion: "storage.k8s.io/v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   sc.Name,
+				Labels: operatorutils.GetOwnerLabels(cr),
+			},
+			Provisioner:          driverName,
+			ReclaimPolicy:        sc.ReclaimPolicy,
+			VolumeBindingMode:    sc.VolumeBindingMode,
+			AllowVolumeExpansion: sc.AllowVolumeExpansion,
+			MountOptions:         sc.MountOptions,
+			AllowedTopologies:    sc.AllowedTopologies,
+		}
+		if len(parameters) > 0 {
+			class.Parameters = parameters
+		}
+		if len(class.AllowedTopologies) == 0 {
+			class.AllowedTopologies = zoneTopologies[sc.ArrayID]
+		}
+		if class.ReclaimPolicy == nil {
+			reclaimPolicy := corev1.PersistentVolumeReclaimDelete
+			class.ReclaimPolicy = &reclaimPolicy
+		}
+		if class.VolumeBindingMode == nil {
+			bindingMode := storagev1.VolumeBindingWaitForFirstConsumer
+			class.VolumeBindingMode = &bindingMode
+		}
+		classes = append(classes, class)
+	}
+	return classes, nil
+}
+
+// needsZoneTopology - checks if any storage class takes its topology from the zone of its array
+func needsZoneTopology(storageClasses []csmv1.StorageClass) bool {
+	for _, sc := range storageClasses {
+		if sc.ArrayID != "" && len(sc.AllowedTopologies) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package drivers
+
+import (
+	"context"
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlClientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetStorageClasses(t *testing.T) {
+	ctx := context.Background()
+	zoneData := `
+- username: "admin"
+  password: "password"
+  systemID: "2b11bb111111bb1b"
+  endpoint: "https://127.0.0.2"
+  zone:
+    name: "ZONE-1"
+    labelKey: "zone.csi-vxflexos.dellemc.com"
+- username: "admin"
+  password: "password"
+  systemID: "1a99aa999999aa9a"
+  endpoint: "https://127.0.0.1"
+`
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vxflexos-config", Namespace: "vxflexos"},
+		Data:       map[string][]byte{"config": []byte(zoneData)},
+	}
+	retain := corev1.PersistentVolumeReclaimRetain
+
+	cr := csmv1.ContainerStorageModule{ObjectMeta: metav1.ObjectMeta{Name: "vxflexos", Namespace: "vxflexos"}}
+	cr.Spec.Driver.CSIDriverType = csmv1.PowerFlex
+	cr.Spec.Driver.StorageClasses = []csmv1.StorageClass{
+		{Name: "zoned", ArrayID: "2b11bb111111bb1b", Parameters: map[string]string{"storagepool": "pool1"}, ReclaimPolicy: &retain},
+		{Name: "unzoned", ArrayID: "1a99aa999999aa9a"},
+	}
+
+	t.Run("zone topology and defaults", func(t *testing.T) {
+		ctrlClient := ctrlClientFake.NewClientBuilder().WithObjects(secret).Build()
+		classes, err := GetStorageClasses(ctx, cr, "csi-vxflexos.dellemc.com", ctrlClient)
+		assert.NoError(t, err)
+		assert.Len(t, classes, 2)
+
+		zoned := classes[0]
+		assert.Equal(t, "zoned", zoned.Name)
+		assert.Equal(t, operatorutils.GetOwnerLabels(cr), zoned.Labels)
+		assert.Equal(t, "csi-vxflexos.dellemc.com", zoned.Provisioner)
+		assert.Equal(t, map[string]string{"storagepool": "pool1", "systemID": "2b11bb111111bb1b"}, zoned.Parameters)
+		assert.Equal(t, corev1.PersistentVolumeReclaimRetain, *zoned.ReclaimPolicy)
+		assert.Equal(t, storagev1.VolumeBindingWaitForFirstConsumer, *zoned.VolumeBindingMode)
+		assert.Equal(t, []corev1.TopologySelectorTerm{{
+			MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{{
+				Key:    "zone.csi-vxflexos.dellemc.com",
+				Values: []string{"ZONE-1"},
+			}},
+		}}, zoned.AllowedTopologies)
+
+		unzoned := classes[1]
+		assert.Equal(t, corev1.PersistentVolumeReclaimDelete, *unzoned.ReclaimPolicy)
+		assert.Empty(t, unzoned.AllowedTopologies)
+	})
+
+	t.Run("missing array secret", func(t *testing.T) {
+		_, err := GetStorageClasses(ctx, cr, "csi-vxflexos.dellemc.com", ctrlClientFake.NewClientBuilder().Build())
+		assert.Error(t, err)
+	})
+
+	t.Run("explicit topology does not read the secret", func(t *testing.T) {
+		explicit := cr
+		explicit.Spec.Driver.StorageClasses = []csmv1.StorageClass{{
+			Name:    "explicit",
+			ArrayID: "2b11bb111111bb1b",
+			AllowedTopologies: []corev1.TopologySelectorTerm{{
+				MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{{Key: "csi-vxflexos.dellemc.com/2b11bb111111bb1b", Values: []string{"csi-vxflexos.dellemc.com"}}},
+			}},
+		}}
+		classes, err := GetStorageClasses(ctx, explicit, "csi-vxflexos.dellemc.com", ctrlClientFake.NewClientBuilder().Build())
+		assert.NoError(t, err)
+		assert.Equal(t, explicit.Spec.Driver.StorageClasses[0].AllowedTopologies, classes[0].AllowedTopologies)
+	})
+
+	t.Run("array id parameter of the driver", func(t *testing.T) {
+		pscale := csmv1.ContainerStorageModule{ObjectMeta: metav1.ObjectMeta{Name: "isilon", Namespace: "isilon"}}
+		pscale.Spec.Driver.CSIDriverType = csmv1.PowerScale
+		pscale.Spec.Driver.StorageClasses = []csmv1.StorageClass{{Name: "isilon", ArrayID: "cluster1", Parameters: map[string]string{"AccessZone": "System"}}}
+		classes, err := GetStorageClasses(ctx, pscale, "csi-isilon.dellemc.com", ctrlClientFake.NewClientBuilder().Build())
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"AccessZone": "System", "ClusterName": "cluster1"}, classes[0].Parameters)
+	})
+}
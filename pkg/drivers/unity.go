@@ -15,7 +15,6 @@ package drivers
 import (
 	"context"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
@@ -68,10 +67,8 @@ func PrecheckUnity(ctx context.Context, cr *csmv1.ContainerStorageModule, operat
 	if err != nil {
 		return err
 	}
-	// Check if driver version is supported by doing a stat on a config file
-	configFilePath := fmt.Sprintf("%s/driverconfig/unity/%s/upgrade-path.yaml", operatorConfig.ConfigDirectory, version)
-	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
-		log.Errorw("PreCheckUnity failed in version check", "Error", err.Error(), "Namespace", cr.Namespace)
+	if !operatorutils.DriverVersionSupported(csmv1.Unity, version, operatorConfig) {
+		log.Errorw("PreCheckUnity failed in version check", "version", version, "Namespace", cr.Namespace)
 		return fmt.Errorf("%s %s not supported", csmv1.Unity, version)
 	}
 
@@ -0,0 +1,531 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package helmvalues converts the values.yaml of a Dell csi-<driver> Helm chart into a ContainerStorageModule
+package helmvalues
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultReplicas - controllerCount of the charts when the values do not set it
+const defaultReplicas = 2
+
+// container of the driver that an env var is set on
+type target int
+
+const (
+	common target = iota
+	controller
+	node
+)
+
+// envKey - a chart key that maps onto an env var of the driver
+type envKey struct {
+	key    string
+	target target
+	env    string
+}
+
+// keys shared by the charts of every driver
+var commonEnvKeys = []envKey{
+	{"logLevel", common, "CSI_LOG_LEVEL"},
+	{"kubeletConfigDir", common, "KUBELET_CONFIG_DIR"},
+	{"certSecretCount", common, "CERT_SECRET_COUNT"},
+	{"controller.healthMonitor.enabled", controller, "X_CSI_HEALTH_MONITOR_ENABLED"},
+	{"node.healthMonitor.enabled", node, "X_CSI_HEALTH_MONITOR_ENABLED"},
+}
+
+// keys of each chart, with the env names the Modify*CR functions expect
+var driverEnvKeys = map[csmv1.DriverType][]envKey{
+	csmv1.PowerFlex: {
+		{"enablelistvolumesnapshot", common, "X_CSI_VXFLEXOS_ENABLELISTVOLUMESNAPSHOT"},
+		{"enablesnapshotcgdelete", common, "X_CSI_VXFLEXOS_ENABLESNAPSHOTCGDELETE"},
+		{"enableQuota", common, "X_CSI_QUOTA_ENABLED"},
+		{"externalAccess", controller, "X_CSI_POWERFLEX_EXTERNAL_ACCESS"},
+		{"maxVxflexosVolumesPerNode", node, "X_CSI_MAX_VOLUMES_PER_NODE"},
+		{"node.approveSDC.enabled", node, "X_CSI_APPROVE_SDC_ENABLED"},
+		{"node.renameSDC.enabled", node, "X_CSI_RENAME_SDC_ENABLED"},
+		{"node.renameSDC.prefix", node, "X_CSI_RENAME_SDC_PREFIX"},
+	},
+	csmv1.PowerStore: {
+		{"nodeNamePrefix", common, "X_CSI_POWERSTORE_NODE_NAME_PREFIX"},
+		{"nodeFCPortsFilterFile", common, "X_CSI_FC_PORTS_FILTER_FILE_PATH"},
+		{"externalAccess", controller, "X_CSI_POWERSTORE_EXTERNAL_ACCESS"},
+		{"controller.nfsAcls", controller, "X_CSI_NFS_ACLS"},
+		{"connection.enableCHAP", node, "X_CSI_POWERSTORE_ENABLE_CHAP"},
+		{"maxPowerstoreVolumesPerNode", node, "X_CSI_POWERSTORE_MAX_VOLUMES_PER_NODE"},
+	},
+	csmv1.PowerScale: {
+		{"verbose", common, "X_CSI_VERBOSE"},
+		{"isiPort", common, "X_CSI_ISI_PORT"},
+		{"isiPath", common, "X_CSI_ISI_PATH"},
+		{"noProbeOnStart", common, "X_CSI_ISI_NO_PROBE_ON_START"},
+		{"autoProbe", common, "X_CSI_ISI_AUTOPROBE"},
+		{"isiInsecure", common, "X_CSI_ISI_SKIP_CERTIFICATE_VALIDATION"},
+		{"isiAuthType", common, "X_CSI_ISI_AUTH_TYPE"},
+		{"enableCustomTopology", common, "X_CSI_CUSTOM_TOPOLOGY_ENABLED"},
+		{"enableQuota", controller, "X_CSI_ISI_QUOTA_ENABLED"},
+		{"isiAccessZone", controller, "X_CSI_ISI_ACCESS_ZONE"},
+		{"isiVolumePathPermissions", controller, "X_CSI_ISI_VOLUME_PATH_PERMISSIONS"},
+		{"ignoreUnresolvableHosts", controller, "X_CSI_ISI_IGNORE_UNRESOLVABLE_HOSTS"},
+		{"maxPathLen", controller, "X_CSI_MAX_PATH_LIMIT"},
+		{"maxPathLen", node, "X_CSI_MAX_PATH_LIMIT"},
+		{"maxIsilonVolumesPerNode", node, "X_CSI_MAX_VOLUMES_PER_NODE"},
+		{"allowedNetworks", node, "X_CSI_ALLOWED_NETWORKS"},
+	},
+	csmv1.Unity: {
+		{"allowRWOMultiPodAccess", common, "X_CSI_UNITY_ALLOW_MULTI_POD_ACCESS"},
+		{"syncNodeInfoInterval", common, "X_CSI_UNITY_SYNC_NODEINFO_INTERVAL"},
+		{"tenantName", common, "TENANT_NAME"},
+		{"allowedNetworks", node, "X_CSI_ALLOWED_NETWORKS"},
+	},
+	csmv1.PowerMax: {
+		{"clusterPrefix", common, "X_CSI_K8S_CLUSTER_PREFIX"},
+		{"portGroups", common, "X_CSI_POWERMAX_PORTGROUPS"},
+		{"transportProtocol", common, "X_CSI_TRANSPORT_PROTOCOL"},
+		{"skipCertificateValidation", common, "X_CSI_POWERMAX_SKIP_CERTIFICATE_VALIDATION"},
+		{"powerMaxDebug", common, "X_CSI_POWERMAX_DEBUG"},
+		{"enableCHAP", node, "X_CSI_POWERMAX_ISCSI_ENABLE_CHAP"},
+		{"node.topologyControl.enabled", node, "X_CSI_TOPOLOGY_CONTROL_ENABLED"},
+		{"maxPowerMaxVolumesPerNode", node, "X_CSI_MAX_VOLUMES_PER_NODE"},
+	},
+}
+
+// DefaultNames - names of the Helm releases in the Dell installation docs, by driver type
+var DefaultNames = map[csmv1.DriverType]string{
+	csmv1.PowerFlex:  "vxflexos",
+	csmv1.PowerStore: "powerstore",
+	csmv1.PowerScale: "isilon",
+	csmv1.Unity:      "unity",
+	csmv1.PowerMax:   "powermax",
+}
+
+// Options - identity of the ContainerStorageModule built from the values
+type Options struct {
+	DriverType csmv1.DriverType
+	Name       string
+	Namespace  string
+	// Version - CSM version of the CR, the version of the chart is used as the driver config version when it is empty
+	Version string
+}
+
+// values - the parsed values, with the keys consumed by the conversion
+type values struct {
+	data     map[string]interface{}
+	consumed map[string]bool
+}
+
+// get - returns the value at the dotted path and marks it as consumed
+func (v *values) get(path string) (interface{}, bool) {
+	var cur interface{} = v.data
+	for _, k := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+	v.consumed[path] = true
+	return cur, true
+}
+
+// str - returns the value at the path as an env var value
+func (v *values) str(path string) (string, bool, error) {
+	raw, ok := v.get(path)
+	if !ok {
+		return "", false, nil
+	}
+	s, err := scalar(raw)
+	if err != nil {
+		return "", false, fmt.Errorf("%s: %v", path, err)
+	}
+	return s, true, nil
+}
+
+// boolean - returns the value at the path as a bool
+func (v *values) boolean(path string) (bool, bool, error) {
+	s, ok, err := v.str(path)
+	if !ok || err != nil {
+		return false, ok, err
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, false, fmt.Errorf("%s: %q is not a boolean", path, s)
+	}
+	return b, true, nil
+}
+
+// into - decodes the value at the path into out
+func (v *values) into(path string, out interface{}) (bool, error) {
+	raw, ok := v.get(path)
+	if !ok || raw == nil {
+		return false, nil
+	}
+	buf, err := json.Marshal(raw)
+	if err != nil {
+		return false, fmt.Errorf("%s: %v", path, err)
+	}
+	if err := json.Unmarshal(buf, out); err != nil {
+		return false, fmt.Errorf("%s: %v", path, err)
+	}
+	return true, nil
+}
+
+// unmapped - returns the paths of the values that carry a setting and were not consumed
+func (v *values) unmapped() []string {
+	var paths []string
+	var walk func(prefix string, val interface{})
+	walk = func(prefix string, val interface{}) {
+		if prefix != "" && v.isConsumed(prefix) {
+			return
+		}
+		switch t := val.(type) {
+		case map[string]interface{}:
+			for k, child := range t {
+				p := k
+				if prefix != "" {
+					p = prefix + "." + k
+				}
+				walk(p, child)
+			}
+		case nil:
+		case string:
+			if t != "" {
+				paths = append(paths, prefix)
+			}
+		case []interface{}:
+			if len(t) > 0 {
+				paths = append(paths, prefix)
+			}
+		default:
+			paths = append(paths, prefix)
+		}
+	}
+	walk("", v.data)
+	sort.Strings(paths)
+	return paths
+}
+
+func (v *values) isConsumed(path string) bool {
+	for p := path; ; {
+		if v.consumed[p] {
+			return true
+		}
+		i := strings.LastIndex(p, ".")
+		if i < 0 {
+			return false
+		}
+		p = p[:i]
+	}
+}
+
+// scalar - formats a value as an env var value, lists are joined with commas
+func scalar(raw interface{}) (string, error) {
+	switch t := raw.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	case []interface{}:
+		items := make([]string, 0, len(t))
+		for _, i := range t {
+			s, err := scalar(i)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("%v is not a scalar", raw)
+	}
+}
+
+// Convert - builds a ContainerStorageModule from the values.yaml of a csi-<driver> chart
+// It also returns the paths of the values it could not map, values left empty are not reported
+func Convert(buf []byte, opts Options) (*csmv1.ContainerStorageModule, []string, error) {
+	if _, ok := DefaultNames[opts.DriverType]; !ok {
+		return nil, nil, fmt.Errorf("unsupported driver type %s", opts.DriverType)
+	}
+	data := map[string]interface{}{}
+	if err := yaml.Unmarshal(buf, &data); err != nil {
+		return nil, nil, fmt.Errorf("parsing the values: %v", err)
+	}
+	v := &values{data: data, consumed: map[string]bool{}}
+
+	name := opts.Name
+	if name == "" {
+		name = DefaultNames[opts.DriverType]
+	}
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = name
+	}
+
+	cr := &csmv1.ContainerStorageModule{
+		TypeMeta:   metav1.TypeMeta{APIVersion: csmv1.GroupVersion.String(), Kind: "ContainerStorageModule"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: csmv1.ContainerStorageModuleSpec{
+			Version: opts.Version,
+			Driver: csmv1.Driver{
+				CSIDriverType: opts.DriverType,
+				CSIDriverSpec: &csmv1.CSIDriverSpec{},
+				Replicas:      defaultReplicas,
+				Common:        &csmv1.ContainerTemplate{},
+				Controller:    &csmv1.PodTemplate{},
+				Node:          &csmv1.PodTemplate{},
+			},
+		},
+	}
+
+	for _, convert := range []func(*values, *csmv1.ContainerStorageModule) error{convertDriver, convertEnvs, convertSideCars, convertModules} {
+		if err := convert(v, cr); err != nil {
+			return nil, nil, err
+		}
+	}
+	return cr, v.unmapped(), nil
+}
+
+// convertDriver - maps the keys onto the fields of the driver
+func convertDriver(v *values, cr *csmv1.ContainerStorageModule) error {
+	driver := &cr.Spec.Driver
+
+	version, ok, err := v.str("version")
+	if err != nil {
+		return err
+	}
+	if ok && cr.Spec.Version == "" {
+		driver.ConfigVersion = version
+	}
+
+	for _, key := range []string{"controller.controllerCount", "controller.replicas"} {
+		replicas, ok, err := v.str(key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(replicas, 10, 32)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a number", key, replicas)
+		}
+		driver.Replicas = int32(n)
+	}
+
+	if policy, ok, err := v.str("imagePullPolicy"); err != nil {
+		return err
+	} else if ok {
+		driver.Common.ImagePullPolicy = corev1.PullPolicy(policy)
+	}
+	if policy, ok, err := v.str("fsGroupPolicy"); err != nil {
+		return err
+	} else if ok {
+		driver.CSIDriverSpec.FSGroupPolicy = policy
+	}
+	if enabled, ok, err := v.boolean("storageCapacity.enabled"); err != nil {
+		return err
+	} else if ok {
+		driver.CSIDriverSpec.StorageCapacity = enabled
+	}
+	if policy, ok, err := v.str("node.dnsPolicy"); err != nil {
+		return err
+	} else if ok {
+		driver.DNSPolicy = policy
+	}
+
+	for prefix, tmpl := range map[string]*csmv1.ContainerTemplate{"controller": &driver.Controller.ContainerTemplate, "node": &driver.Node.ContainerTemplate} {
+		if _, err := v.into(prefix+".nodeSelector", &tmpl.NodeSelector); err != nil {
+			return err
+		}
+		if _, err := v.into(prefix+".tolerations", &tmpl.Tolerations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertEnvs - maps the keys onto the env vars of the driver
+func convertEnvs(v *values, cr *csmv1.ContainerStorageModule) error {
+	driver := &cr.Spec.Driver
+	templates := map[target]*csmv1.ContainerTemplate{common: driver.Common, controller: &driver.Controller.ContainerTemplate, node: &driver.Node.ContainerTemplate}
+
+	keys := append(append([]envKey{}, commonEnvKeys...), driverEnvKeys[driver.CSIDriverType]...)
+	for _, k := range keys {
+		value, ok, err := v.str(k.key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		tmpl := templates[k.target]
+		tmpl.Envs = append(tmpl.Envs, corev1.EnvVar{Name: k.env, Value: value})
+	}
+	return nil
+}
+
+// healthMonitorSideCar - name of the external health monitor sidecar in the driver templates
+func healthMonitorSideCar(driverType csmv1.DriverType) string {
+	if driverType == csmv1.PowerFlex {
+		return "csi-external-health-monitor-controller"
+	}
+	return "external-health-monitor"
+}
+
+// convertSideCars - maps the snapshot, resizer, health monitor and provisioner keys onto the sidecars
+func convertSideCars(v *values, cr *csmv1.ContainerStorageModule) error {
+	var sideCars []csmv1.ContainerTemplate
+	add := func(name, enabledKey string, args map[string]string) error {
+		sc := csmv1.ContainerTemplate{Name: name}
+		if enabledKey != "" {
+			enabled, ok, err := v.boolean(enabledKey)
+			if err != nil {
+				return err
+			}
+			if ok {
+				sc.Enabled = &enabled
+			}
+		}
+		// sorted so that the output is stable
+		keys := make([]string, 0, len(args))
+		for key := range args {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value, ok, err := v.str(key)
+			if err != nil {
+				return err
+			}
+			if ok && value != "" {
+				sc.Args = append(sc.Args, fmt.Sprintf("--%s=%s", args[key], value))
+			}
+		}
+		if sc.Enabled != nil || len(sc.Args) > 0 {
+			sideCars = append(sideCars, sc)
+		}
+		return nil
+	}
+
+	if err := add("provisioner", "", map[string]string{
+		"controller.volumeNamePrefix":  "volume-name-prefix",
+		"storageCapacity.pollInterval": "capacity-poll-interval",
+	}); err != nil {
+		return err
+	}
+	if err := add("snapshotter", "controller.snapshot.enabled", map[string]string{
+		"controller.snapshot.snapNamePrefix": "snapshot-name-prefix",
+	}); err != nil {
+		return err
+	}
+	if err := add("resizer", "controller.resizer.enabled", nil); err != nil {
+		return err
+	}
+	// the env var of controller.healthMonitor.enabled is set by convertEnvs, the key is read again for the sidecar
+	if err := add(healthMonitorSideCar(cr.Spec.Driver.CSIDriverType), "controller.healthMonitor.enabled", map[string]string{
+		"controller.healthMonitor.interval": "monitor-interval",
+	}); err != nil {
+		return err
+	}
+	if cr.Spec.Driver.CSIDriverType == csmv1.PowerFlex {
+		if err := add("sdc-monitor", "monitor.enabled", nil); err != nil {
+			return err
+		}
+	}
+
+	cr.Spec.Driver.SideCars = sideCars
+	return nil
+}
+
+// convertModules - maps podmon, authorization and replication onto the modules
+func convertModules(v *values, cr *csmv1.ContainerStorageModule) error {
+	// resiliency
+	if enabled, ok, err := v.boolean("podmon.enabled"); err != nil {
+		return err
+	} else if ok {
+		m := csmv1.Module{Name: csmv1.Resiliency, Enabled: enabled}
+		for _, c := range []string{"controller", "node"} {
+			var args []string
+			if _, err := v.into("podmon."+c+".args", &args); err != nil {
+				return err
+			}
+			if len(args) > 0 {
+				m.Components = append(m.Components, csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{Name: "podmon-" + c, Args: args}})
+			}
+		}
+		cr.Spec.Modules = append(cr.Spec.Modules, m)
+	}
+
+	// authorization
+	if enabled, ok, err := v.boolean("authorization.enabled"); err != nil {
+		return err
+	} else if ok {
+		m := csmv1.Module{Name: csmv1.Authorization, Enabled: enabled}
+		proxy := csmv1.ContainerTemplate{Name: "karavi-authorization-proxy"}
+		for key, env := range map[string]string{"authorization.proxyHost": "PROXY_HOST", "authorization.skipCertificateValidation": "SKIP_CERTIFICATE_VALIDATION"} {
+			value, ok, err := v.str(key)
+			if err != nil {
+				return err
+			}
+			if ok {
+				proxy.Envs = append(proxy.Envs, corev1.EnvVar{Name: env, Value: value})
+			}
+		}
+		sort.Slice(proxy.Envs, func(i, j int) bool { return proxy.Envs[i].Name < proxy.Envs[j].Name })
+		if len(proxy.Envs) > 0 {
+			m.Components = append(m.Components, csmv1.PodTemplate{ContainerTemplate: proxy})
+		}
+		cr.Spec.Modules = append(cr.Spec.Modules, m)
+	}
+
+	// replication
+	if enabled, ok, err := v.boolean("controller.replication.enabled"); err != nil {
+		return err
+	} else if ok {
+		m := csmv1.Module{Name: csmv1.Replication, Enabled: enabled}
+		replicator := csmv1.ContainerTemplate{Name: "dell-csi-replicator"}
+		for _, k := range []struct{ key, env string }{
+			{"controller.replication.replicationPrefix", "X_CSI_REPLICATION_PREFIX"},
+			{"controller.replication.replicationContextPrefix", "X_CSI_REPLICATION_CONTEXT_PREFIX"},
+		} {
+			value, ok, err := v.str(k.key)
+			if err != nil {
+				return err
+			}
+			if ok {
+				replicator.Envs = append(replicator.Envs, corev1.EnvVar{Name: k.env, Value: value})
+			}
+		}
+		if len(replicator.Envs) > 0 {
+			m.Components = append(m.Components, csmv1.PodTemplate{ContainerTemplate: replicator})
+		}
+		cr.Spec.Modules = append(cr.Spec.Modules, m)
+	}
+	return nil
+}
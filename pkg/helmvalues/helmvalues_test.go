@@ -0,0 +1,182 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package helmvalues
+
+import (
+	"os"
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func envValue(t *testing.T, envs []corev1.EnvVar, name string) string {
+	for _, e := range envs {
+		if e.Name == name {
+			return e.Value
+		}
+	}
+	t.Fatalf("env %s not found in %+v", name, envs)
+	return ""
+}
+
+func sideCar(cr *csmv1.ContainerStorageModule, name string) *csmv1.ContainerTemplate {
+	for i := range cr.Spec.Driver.SideCars {
+		if cr.Spec.Driver.SideCars[i].Name == name {
+			return &cr.Spec.Driver.SideCars[i]
+		}
+	}
+	return nil
+}
+
+func module(cr *csmv1.ContainerStorageModule, name csmv1.ModuleType) *csmv1.Module {
+	for i := range cr.Spec.Modules {
+		if cr.Spec.Modules[i].Name == name {
+			return &cr.Spec.Modules[i]
+		}
+	}
+	return nil
+}
+
+func TestConvert(t *testing.T) {
+	buf, err := os.ReadFile("testdata/powerstore_values.yaml")
+	require.NoError(t, err)
+
+	cr, unmapped, err := Convert(buf, Options{DriverType: csmv1.PowerStore, Namespace: "csi-powerstore"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ContainerStorageModule", cr.Kind)
+	assert.Equal(t, "powerstore", cr.Name)
+	assert.Equal(t, "csi-powerstore", cr.Namespace)
+
+	driver := cr.Spec.Driver
+	assert.Equal(t, "v2.17.0", driver.ConfigVersion)
+	assert.Equal(t, int32(1), driver.Replicas)
+	assert.Equal(t, "ClusterFirstWithHostNet", driver.DNSPolicy)
+	assert.Equal(t, corev1.PullIfNotPresent, driver.Common.ImagePullPolicy)
+	assert.Equal(t, "ReadWriteOnceWithFSType", driver.CSIDriverSpec.FSGroupPolicy)
+	assert.True(t, driver.CSIDriverSpec.StorageCapacity)
+
+	assert.Equal(t, "debug", envValue(t, driver.Common.Envs, "CSI_LOG_LEVEL"))
+	assert.Equal(t, "1", envValue(t, driver.Common.Envs, "CERT_SECRET_COUNT"))
+	assert.Equal(t, "csi-node", envValue(t, driver.Common.Envs, "X_CSI_POWERSTORE_NODE_NAME_PREFIX"))
+	assert.Equal(t, "", envValue(t, driver.Controller.Envs, "X_CSI_POWERSTORE_EXTERNAL_ACCESS"))
+	assert.Equal(t, "0777", envValue(t, driver.Controller.Envs, "X_CSI_NFS_ACLS"))
+	assert.Equal(t, "true", envValue(t, driver.Controller.Envs, "X_CSI_HEALTH_MONITOR_ENABLED"))
+	assert.Equal(t, "false", envValue(t, driver.Node.Envs, "X_CSI_HEALTH_MONITOR_ENABLED"))
+	assert.Equal(t, "true", envValue(t, driver.Node.Envs, "X_CSI_POWERSTORE_ENABLE_CHAP"))
+	assert.Equal(t, "0", envValue(t, driver.Node.Envs, "X_CSI_POWERSTORE_MAX_VOLUMES_PER_NODE"))
+
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/control-plane": ""}, driver.Controller.NodeSelector)
+	require.Len(t, driver.Controller.Tolerations, 1)
+	assert.Equal(t, corev1.TaintEffectNoSchedule, driver.Controller.Tolerations[0].Effect)
+	require.Len(t, driver.Node.Tolerations, 1)
+	assert.Equal(t, corev1.TaintEffectNoExecute, driver.Node.Tolerations[0].Effect)
+
+	provisioner := sideCar(cr, "provisioner")
+	require.NotNil(t, provisioner)
+	assert.Nil(t, provisioner.Enabled)
+	assert.Equal(t, []string{"--volume-name-prefix=csivol", "--capacity-poll-interval=5m"}, provisioner.Args)
+	snapshotter := sideCar(cr, "snapshotter")
+	require.NotNil(t, snapshotter)
+	assert.True(t, *snapshotter.Enabled)
+	assert.Equal(t, []string{"--snapshot-name-prefix=csi-snap"}, snapshotter.Args)
+	resizer := sideCar(cr, "resizer")
+	require.NotNil(t, resizer)
+	assert.False(t, *resizer.Enabled)
+	healthMonitor := sideCar(cr, "external-health-monitor")
+	require.NotNil(t, healthMonitor)
+	assert.True(t, *healthMonitor.Enabled)
+	assert.Equal(t, []string{"--monitor-interval=60s"}, healthMonitor.Args)
+	assert.Nil(t, sideCar(cr, "sdc-monitor"))
+
+	resiliency := module(cr, csmv1.Resiliency)
+	require.NotNil(t, resiliency)
+	assert.True(t, resiliency.Enabled)
+	require.Len(t, resiliency.Components, 2)
+	assert.Equal(t, "podmon-controller", resiliency.Components[0].Name)
+	assert.Contains(t, resiliency.Components[1].Args, "--mode=node")
+
+	auth := module(cr, csmv1.Authorization)
+	require.NotNil(t, auth)
+	assert.True(t, auth.Enabled)
+	require.Len(t, auth.Components, 1)
+	assert.Equal(t, "csm-authorization.com", envValue(t, auth.Components[0].Envs, "PROXY_HOST"))
+	assert.Equal(t, "true", envValue(t, auth.Components[0].Envs, "SKIP_CERTIFICATE_VALIDATION"))
+
+	replication := module(cr, csmv1.Replication)
+	require.NotNil(t, replication)
+	assert.False(t, replication.Enabled)
+	assert.Equal(t, "powerstore", envValue(t, replication.Components[0].Envs, "X_CSI_REPLICATION_CONTEXT_PREFIX"))
+
+	assert.Equal(t, []string{
+		"authorization.sidecarProxyImage",
+		"driverName",
+		"images.driver.image",
+		"podmon.image",
+	}, unmapped)
+}
+
+func TestConvertOptions(t *testing.T) {
+	t.Run("csm version takes precedence over the chart version", func(t *testing.T) {
+		cr, _, err := Convert([]byte("version: v2.16.0\n"), Options{DriverType: csmv1.PowerFlex, Version: "v1.17.1"})
+		require.NoError(t, err)
+		assert.Equal(t, "vxflexos", cr.Name)
+		assert.Equal(t, "vxflexos", cr.Namespace)
+		assert.Equal(t, "v1.17.1", cr.Spec.Version)
+		assert.Empty(t, cr.Spec.Driver.ConfigVersion)
+		assert.Equal(t, int32(defaultReplicas), cr.Spec.Driver.Replicas)
+	})
+
+	t.Run("driver specific keys", func(t *testing.T) {
+		values := "maxVxflexosVolumesPerNode: 10\nmonitor:\n  enabled: true\nnode:\n  renameSDC:\n    enabled: true\n    prefix: sdc\ncontroller:\n  healthMonitor:\n    enabled: false\n"
+		cr, unmapped, err := Convert([]byte(values), Options{DriverType: csmv1.PowerFlex})
+		require.NoError(t, err)
+		assert.Empty(t, unmapped)
+		assert.Equal(t, "10", envValue(t, cr.Spec.Driver.Node.Envs, "X_CSI_MAX_VOLUMES_PER_NODE"))
+		assert.Equal(t, "sdc", envValue(t, cr.Spec.Driver.Node.Envs, "X_CSI_RENAME_SDC_PREFIX"))
+		assert.True(t, *sideCar(cr, "sdc-monitor").Enabled)
+		assert.False(t, *sideCar(cr, "csi-external-health-monitor-controller").Enabled)
+	})
+
+	t.Run("lists are joined", func(t *testing.T) {
+		cr, _, err := Convert([]byte("allowedNetworks: [10.0.0.0/8, 192.168.0.0/16]\n"), Options{DriverType: csmv1.PowerScale})
+		require.NoError(t, err)
+		assert.Equal(t, "isilon", cr.Name)
+		assert.Equal(t, "10.0.0.0/8,192.168.0.0/16", envValue(t, cr.Spec.Driver.Node.Envs, "X_CSI_ALLOWED_NETWORKS"))
+	})
+}
+
+func TestConvertErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     string
+		driverType csmv1.DriverType
+		wantErr    string
+	}{
+		{"unsupported driver", "", "cosi", "unsupported driver type cosi"},
+		{"invalid yaml", "controller: [", csmv1.PowerStore, "parsing the values"},
+		{"replicas not a number", "controller:\n  controllerCount: two\n", csmv1.PowerStore, `controller.controllerCount: "two" is not a number`},
+		{"toggle not a boolean", "podmon:\n  enabled: maybe\n", csmv1.PowerStore, `podmon.enabled: "maybe" is not a boolean`},
+		{"env not a scalar", "logLevel:\n  level: debug\n", csmv1.Unity, "logLevel:"},
+		{"tolerations of the wrong type", "node:\n  tolerations: yes\n", csmv1.PowerMax, "node.tolerations:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Convert([]byte(tt.values), Options{DriverType: tt.driverType})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
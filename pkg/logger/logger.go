@@ -65,6 +65,11 @@ func GetNewContextWithLogger(key string) (context.Context, *zap.SugaredLogger) {
 	return newCtx, GetLogger(newCtx)
 }
 
+// NewContextWithZapLogger returns a new child context that logs with the given logger
+func NewContextWithZapLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
 // withFields returns a new context derived from ctx
 // that has a logger that always logs the given fields.
 func withFields(ctx context.Context, fields ...zapcore.Field) context.Context {
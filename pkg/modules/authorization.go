@@ -21,8 +21,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -341,8 +339,7 @@ func getAuthApplyCR(ctx context.Context, cr csmv1.ContainerStorageModule, op ope
 		}
 	}
 
-	configMapPath := fmt.Sprintf("%s/moduleconfig/authorization/%s/container.yaml", op.ConfigDirectory, authConfigVersion)
-	buf, err := os.ReadFile(filepath.Clean(configMapPath))
+	buf, err := operatorutils.Templates(op.ConfigDirectory).ModuleFile(csmv1.Authorization, authConfigVersion, "container.yaml")
 	if err != nil {
 		return nil, nil, emptySpec, err
 	}
@@ -484,8 +481,7 @@ func getAuthApplyVolumes(ctx context.Context, cr csmv1.ContainerStorageModule, o
 		}
 	}
 
-	configMapPath := fmt.Sprintf("%s/moduleconfig/authorization/%s/volumes.yaml", op.ConfigDirectory, authConfigVersion)
-	buf, err := os.ReadFile(filepath.Clean(configMapPath))
+	buf, err := operatorutils.Templates(op.ConfigDirectory).ModuleFile(csmv1.Authorization, authConfigVersion, "volumes.yaml")
 	if err != nil {
 		return nil, err
 	}
@@ -544,8 +540,10 @@ func AuthInjectDaemonset(ctx context.Context, ds applyv1.DaemonSetApplyConfigura
 	}
 
 	container := *containerPtr
-	operatorutils.UpdateSideCarApply(ctx, authModule.Components, &container, cr, matched)
-	vols, err := getAuthApplyVolumes(ctx, cr, op, authModule.Components[0], ctrlClient)
+	if err := operatorutils.UpdateSideCarApply(ctx, operatorutils.ContainerTemplates(authModule.Components), &container, cr, matched); err != nil {
+		return nil, err
+	}
+	vols, err := getAuthApplyVolumes(ctx, cr, op, authModule.Components[0].ContainerTemplate, ctrlClient)
 	if err != nil {
 		return nil, err
 	}
@@ -571,9 +569,11 @@ func AuthInjectDeployment(ctx context.Context, dp applyv1.DeploymentApplyConfigu
 	}
 
 	container := *containerPtr
-	operatorutils.UpdateSideCarApply(ctx, authModule.Components, &container, cr, matched)
+	if err := operatorutils.UpdateSideCarApply(ctx, operatorutils.ContainerTemplates(authModule.Components), &container, cr, matched); err != nil {
+		return nil, err
+	}
 
-	vols, err := getAuthApplyVolumes(ctx, cr, op, authModule.Components[0], ctrlClient)
+	vols, err := getAuthApplyVolumes(ctx, cr, op, authModule.Components[0].ContainerTemplate, ctrlClient)
 	if err != nil {
 		return nil, err
 	}
@@ -606,8 +606,6 @@ func AuthorizationPrecheck(ctx context.Context, op operatorutils.OperatorConfig,
 		}
 	}
 
-	// Check for secrets
-	skipCertValid := false
 	// check if components are present or not
 	for i, component := range auth.Components {
 		if component.Name == "karavi-authorization-proxy" {
@@ -628,17 +626,9 @@ func AuthorizationPrecheck(ctx context.Context, op operatorutils.OperatorConfig,
 		}
 	}
 
-	for _, env := range auth.Components[0].Envs {
-		if env.Name == "SKIP_CERTIFICATE_VALIDATION" {
-			b, err := strconv.ParseBool(env.Value)
-			if err != nil {
-				return fmt.Errorf("%s is an invalid value for SKIP_CERTIFICATE_VALIDATION: %v", env.Value, err)
-			}
-			skipCertValid = b
-		}
-		if env.Name == "PROXY_HOST" && env.Value == "" {
-			return fmt.Errorf("PROXY_HOST for authorization is empty")
-		}
+	skipCertValid, err := ValidateAuthorizationEnvs(auth)
+	if err != nil {
+		return err
 	}
 
 	secrets := []string{"proxy-authz-tokens"}
@@ -683,6 +673,27 @@ func AuthorizationPrecheck(ctx context.Context, op operatorutils.OperatorConfig,
 	return nil
 }
 
+// ValidateAuthorizationEnvs - validates the environment of the authorization sidecar and returns whether the proxy certificate is validated
+func ValidateAuthorizationEnvs(auth csmv1.Module) (bool, error) {
+	skipCertValid := false
+	if len(auth.Components) == 0 {
+		return skipCertValid, nil
+	}
+	for _, env := range auth.Components[0].Envs {
+		if env.Name == "SKIP_CERTIFICATE_VALIDATION" {
+			b, err := strconv.ParseBool(env.Value)
+			if err != nil {
+				return skipCertValid, fmt.Errorf("%s is an invalid value for SKIP_CERTIFICATE_VALIDATION: %v", env.Value, err)
+			}
+			skipCertValid = b
+		}
+		if env.Name == "PROXY_HOST" && env.Value == "" {
+			return skipCertValid, fmt.Errorf("PROXY_HOST for authorization is empty")
+		}
+	}
+	return skipCertValid, nil
+}
+
 // AuthorizationServerPrecheck  - runs precheck for CSM Authorization Proxy Server
 func AuthorizationServerPrecheck(ctx context.Context, op operatorutils.OperatorConfig, auth csmv1.Module, cr csmv1.ContainerStorageModule, r operatorutils.ReconcileCSM) error {
 	log := logger.GetLogger(ctx)
@@ -819,7 +830,7 @@ func getAuthorizationServerDeployment(ctx context.Context, op operatorutils.Oper
 			YamlString = strings.ReplaceAll(YamlString, AuthRedisSentinel, component.Sentinel)
 			YamlString = strings.ReplaceAll(YamlString, AuthCSMNameSpace, cr.Namespace)
 
-			ok, err := validateRedisConfig(component)
+			ok, err := validateRedisConfig(component.ContainerTemplate)
 			if err != nil {
 				return YamlString, fmt.Errorf("validating redis: %w", err)
 			}
@@ -859,7 +870,7 @@ func AuthorizationServerDeployment(ctx context.Context, isDeleting bool, op oper
 		return err
 	}
 
-	err = applyDeleteObjects(ctx, ctrlClient, YamlString, isDeleting)
+	err = applyDeleteWorkloads(ctx, ctrlClient, YamlString, isDeleting, operatorutils.GetImagePullSecrets(cr, csmv1.AuthorizationServer), operatorutils.GetModuleComponents(cr, csmv1.AuthorizationServer))
 	if err != nil {
 		return err
 	}
@@ -943,7 +954,7 @@ func authorizationStorageServiceV2(ctx context.Context, isDeleting bool, cr csmv
 		case AuthRedisComponent:
 			sentinelName = component.Sentinel
 			redisReplicas = component.RedisReplicas
-			updateRedisGlobalVars(component)
+			updateRedisGlobalVars(component.ContainerTemplate)
 		case AuthStorageSystemCredentialsComponent:
 			secretProviderClasses = component.SecretProviderClasses
 			secrets = component.Secrets
@@ -1072,7 +1083,7 @@ func authorizationStorageServiceV2(ctx context.Context, isDeleting bool, cr csmv
 		return fmt.Errorf("converting storage-service json to yaml: %w", err)
 	}
 
-	err = applyDeleteObjects(ctx, ctrlClient, string(deploymentYaml), isDeleting)
+	err = applyDeleteWorkloads(ctx, ctrlClient, string(deploymentYaml), isDeleting, operatorutils.GetImagePullSecrets(cr, csmv1.AuthorizationServer), operatorutils.GetModuleComponents(cr, csmv1.AuthorizationServer))
 	if err != nil {
 		return fmt.Errorf("applying storage-service deployment: %w", err)
 	}
@@ -1294,9 +1305,9 @@ func applyDeleteAuthorizationProxyServerV2(ctx context.Context, isDeleting bool,
 		case AuthRedisComponent:
 			sentinelName = component.Sentinel
 			redisReplicas = component.RedisReplicas
-			updateRedisGlobalVars(component)
+			updateRedisGlobalVars(component.ContainerTemplate)
 		case AuthConfigSecretComponent:
-			updateConfigGlobalVars(component)
+			updateConfigGlobalVars(component.ContainerTemplate)
 		default:
 			continue
 		}
@@ -1321,7 +1332,7 @@ func applyDeleteAuthorizationProxyServerV2(ctx context.Context, isDeleting bool,
 		return fmt.Errorf("marshalling proxy-server deployment: %w", err)
 	}
 
-	err = applyDeleteObjects(ctx, ctrlClient, string(deploymentBytes), isDeleting)
+	err = applyDeleteWorkloads(ctx, ctrlClient, string(deploymentBytes), isDeleting, operatorutils.GetImagePullSecrets(cr, csmv1.AuthorizationServer), operatorutils.GetModuleComponents(cr, csmv1.AuthorizationServer))
 	if err != nil {
 		return fmt.Errorf("applying proxy-server deployment: %w", err)
 	}
@@ -1352,9 +1363,9 @@ func applyDeleteAuthorizationTenantServiceV2(ctx context.Context, isDeleting boo
 		case AuthRedisComponent:
 			sentinelName = component.Sentinel
 			redisReplicas = component.RedisReplicas
-			updateRedisGlobalVars(component)
+			updateRedisGlobalVars(component.ContainerTemplate)
 		case AuthConfigSecretComponent:
-			updateConfigGlobalVars(component)
+			updateConfigGlobalVars(component.ContainerTemplate)
 		default:
 			continue
 		}
@@ -1379,7 +1390,7 @@ func applyDeleteAuthorizationTenantServiceV2(ctx context.Context, isDeleting boo
 		return fmt.Errorf("marshalling tenant-service deployment: %w", err)
 	}
 
-	err = applyDeleteObjects(ctx, ctrlClient, string(deploymentBytes), isDeleting)
+	err = applyDeleteWorkloads(ctx, ctrlClient, string(deploymentBytes), isDeleting, operatorutils.GetImagePullSecrets(cr, csmv1.AuthorizationServer), operatorutils.GetModuleComponents(cr, csmv1.AuthorizationServer))
 	if err != nil {
 		return fmt.Errorf("applying tenant-service deployment: %w", err)
 	}
@@ -1398,7 +1409,7 @@ func applyDeleteAuthorizationRedisStatefulsetV2(ctx context.Context, isDeleting
 			image = getDefaultAuthImage(component.Redis, DefaultRedisImage, matched)
 			image = getImageForKey(ctx, "redis", image, cr, matched)
 			redisReplicas = component.RedisReplicas
-			updateRedisGlobalVars(component)
+			updateRedisGlobalVars(component.ContainerTemplate)
 		default:
 			continue
 		}
@@ -1423,7 +1434,7 @@ func applyDeleteAuthorizationRedisStatefulsetV2(ctx context.Context, isDeleting
 		return fmt.Errorf("marshalling redis statefulset: %w", err)
 	}
 
-	err = applyDeleteObjects(ctx, ctrlClient, string(statefulsetBytes), isDeleting)
+	err = applyDeleteWorkloads(ctx, ctrlClient, string(statefulsetBytes), isDeleting, operatorutils.GetImagePullSecrets(cr, csmv1.AuthorizationServer), operatorutils.GetModuleComponents(cr, csmv1.AuthorizationServer))
 	if err != nil {
 		return fmt.Errorf("applying redis statefulset: %w", err)
 	}
@@ -1443,7 +1454,7 @@ func applyDeleteAuthorizationRediscommanderDeploymentV2(ctx context.Context, isD
 			image = getDefaultAuthImage(component.Commander, DefaultRedisCommanderImage, matched)
 			image = getImageForKey(ctx, "commander", image, cr, matched)
 			redisReplicas = component.RedisReplicas
-			updateRedisGlobalVars(component)
+			updateRedisGlobalVars(component.ContainerTemplate)
 		default:
 			continue
 		}
@@ -1468,7 +1479,7 @@ func applyDeleteAuthorizationRediscommanderDeploymentV2(ctx context.Context, isD
 		return fmt.Errorf("marshalling rediscommander deployment: %w", err)
 	}
 
-	err = applyDeleteObjects(ctx, ctrlClient, string(deploymentBytes), isDeleting)
+	err = applyDeleteWorkloads(ctx, ctrlClient, string(deploymentBytes), isDeleting, operatorutils.GetImagePullSecrets(cr, csmv1.AuthorizationServer), operatorutils.GetModuleComponents(cr, csmv1.AuthorizationServer))
 	if err != nil {
 		return fmt.Errorf("applying rediscommander deployment: %w", err)
 	}
@@ -1488,7 +1499,7 @@ func applyDeleteAuthorizationSentinelStatefulsetV2(ctx context.Context, isDeleti
 			image = getDefaultAuthImage(component.Redis, DefaultRedisImage, matched)
 			image = getImageForKey(ctx, "redis", image, cr, matched)
 			redisReplicas = component.RedisReplicas
-			updateRedisGlobalVars(component)
+			updateRedisGlobalVars(component.ContainerTemplate)
 		default:
 			continue
 		}
@@ -1513,7 +1524,7 @@ func applyDeleteAuthorizationSentinelStatefulsetV2(ctx context.Context, isDeleti
 		return fmt.Errorf("marshalling sentinel statefulset: %w", err)
 	}
 
-	err = applyDeleteObjects(ctx, ctrlClient, string(statefulsetBytes), isDeleting)
+	err = applyDeleteWorkloads(ctx, ctrlClient, string(statefulsetBytes), isDeleting, operatorutils.GetImagePullSecrets(cr, csmv1.AuthorizationServer), operatorutils.GetModuleComponents(cr, csmv1.AuthorizationServer))
 	if err != nil {
 		return fmt.Errorf("applying sentinel statefulset: %w", err)
 	}
@@ -1840,7 +1851,7 @@ func GatewayController(ctx context.Context, isDeleting bool, op operatorutils.Op
 		return err
 	}
 
-	err = applyDeleteObjects(ctx, ctrlClient, YamlString, isDeleting)
+	err = applyDeleteWorkloads(ctx, ctrlClient, YamlString, isDeleting, operatorutils.GetImagePullSecrets(cr, csmv1.AuthorizationServer), operatorutils.GetModuleComponents(cr, csmv1.AuthorizationServer))
 	if err != nil {
 		return err
 	}
@@ -1903,7 +1914,7 @@ func NginxIngressController(ctx context.Context, isDeleting bool, op operatoruti
 		return err
 	}
 
-	err = applyDeleteObjects(ctx, ctrlClient, YamlString, isDeleting)
+	err = applyDeleteWorkloads(ctx, ctrlClient, YamlString, isDeleting, operatorutils.GetImagePullSecrets(cr, csmv1.AuthorizationServer), operatorutils.GetModuleComponents(cr, csmv1.AuthorizationServer))
 	if err != nil {
 		return err
 	}
@@ -2074,7 +2085,7 @@ func InstallWithCerts(ctx context.Context, isDeleting bool, op operatorutils.Ope
 		}
 	}
 
-	err = applyDeleteObjects(ctx, ctrlClient, YamlString, isDeleting)
+	err = applyDeleteWorkloads(ctx, ctrlClient, YamlString, isDeleting, operatorutils.GetImagePullSecrets(cr, csmv1.AuthorizationServer), operatorutils.GetModuleComponents(cr, csmv1.AuthorizationServer))
 	if err != nil {
 		return err
 	}
@@ -2658,32 +2669,29 @@ func getLatestAuthVersion(configDirectory string) (string, error) {
 	authConfigPath := fmt.Sprintf("%s/moduleconfig/%s", configDirectory, csmv1.Authorization)
 
 	// Read the directory to find all version subdirectories
-	files, err := os.ReadDir(authConfigPath)
+	versions, err := operatorutils.Templates(configDirectory).ModuleVersions(csmv1.Authorization)
 	if err != nil {
 		return "", fmt.Errorf("failed to read authorization config directory %s: %w", authConfigPath, err)
 	}
 
-	if len(files) == 0 {
+	if len(versions) == 0 {
 		return "", fmt.Errorf("no authorization versions found in directory %s", authConfigPath)
 	}
 
 	latestVersion := ""
-	for _, file := range files {
-		if file.IsDir() {
-			version := file.Name()
-			if latestVersion == "" {
-				latestVersion = version
-				continue
-			}
-			// Use semantic version comparison via MinVersionCheck
-			isNewer, err := operatorutils.MinVersionCheck(latestVersion, version)
-			if err != nil {
-				log.Printf("Warning: skipping version %s due to version comparison error: %v (comparing with %s)", version, err, latestVersion)
-				continue
-			}
-			if isNewer {
-				latestVersion = version
-			}
+	for _, version := range versions {
+		if latestVersion == "" {
+			latestVersion = version
+			continue
+		}
+		// Use semantic version comparison via MinVersionCheck
+		isNewer, err := operatorutils.MinVersionCheck(latestVersion, version)
+		if err != nil {
+			log.Printf("Warning: skipping version %s due to version comparison error: %v (comparing with %s)", version, err, latestVersion)
+			continue
+		}
+		if isNewer {
+			latestVersion = version
 		}
 	}
 
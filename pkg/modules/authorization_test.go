@@ -2998,7 +2998,7 @@ func TestRemoveVaultFromStorageService(t *testing.T) {
 			continue
 		}
 		cr.Spec.Modules[i].ConfigVersion = "v2.3.0"
-		cr.Spec.Modules[i].Components = append(cr.Spec.Modules[i].Components, csmv1.ContainerTemplate{
+		cr.Spec.Modules[i].Components = append(cr.Spec.Modules[i].Components, csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 			Name: AuthVaultComponent,
 			Vaults: []csmv1.Vault{
 				{
@@ -3011,7 +3011,7 @@ func TestRemoveVaultFromStorageService(t *testing.T) {
 					Identifier: "vault2",
 				},
 			},
-		})
+		}})
 	}
 
 	caSecret := &corev1.Secret{
@@ -3179,13 +3179,13 @@ func TestApplyDeleteVaultCertificates(t *testing.T) {
 					if cr.Spec.Modules[i].Name != csmv1.AuthorizationServer {
 						continue
 					}
-					cr.Spec.Modules[i].Components = append(cr.Spec.Modules[i].Components, csmv1.ContainerTemplate{
+					cr.Spec.Modules[i].Components = append(cr.Spec.Modules[i].Components, csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 						Name: AuthVaultComponent,
 						Vaults: []csmv1.Vault{{
 							Identifier:           "vault-bad-ca",
 							CertificateAuthority: "not-base64!!!",
 						}},
-					})
+					}})
 				}
 				return cr
 			},
@@ -3198,14 +3198,14 @@ func TestApplyDeleteVaultCertificates(t *testing.T) {
 					if cr.Spec.Modules[i].Name != csmv1.AuthorizationServer {
 						continue
 					}
-					cr.Spec.Modules[i].Components = append(cr.Spec.Modules[i].Components, csmv1.ContainerTemplate{
+					cr.Spec.Modules[i].Components = append(cr.Spec.Modules[i].Components, csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 						Name: AuthVaultComponent,
 						Vaults: []csmv1.Vault{{
 							Identifier:        "vault-bad-cert",
 							ClientCertificate: "not-base64!!!",
 							ClientKey:         base64.StdEncoding.EncodeToString([]byte("key")),
 						}},
-					})
+					}})
 				}
 				return cr
 			},
@@ -3218,14 +3218,14 @@ func TestApplyDeleteVaultCertificates(t *testing.T) {
 					if cr.Spec.Modules[i].Name != csmv1.AuthorizationServer {
 						continue
 					}
-					cr.Spec.Modules[i].Components = append(cr.Spec.Modules[i].Components, csmv1.ContainerTemplate{
+					cr.Spec.Modules[i].Components = append(cr.Spec.Modules[i].Components, csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 						Name: AuthVaultComponent,
 						Vaults: []csmv1.Vault{{
 							Identifier:        "vault-bad-key",
 							ClientCertificate: base64.StdEncoding.EncodeToString([]byte("cert")),
 							ClientKey:         "not-base64!!!",
 						}},
-					})
+					}})
 				}
 				return cr
 			},
@@ -3235,7 +3235,7 @@ func TestApplyDeleteVaultCertificates(t *testing.T) {
 			buildCR: func() csmv1.ContainerStorageModule {
 				cr := CsmAuthorizationCR()
 				for i := range cr.Spec.Modules {
-					cr.Spec.Modules[i].Components = append(cr.Spec.Modules[i].Components, csmv1.ContainerTemplate{
+					cr.Spec.Modules[i].Components = append(cr.Spec.Modules[i].Components, csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 						Name: AuthVaultComponent,
 						Vaults: []csmv1.Vault{{
 							Identifier:           "vault-good",
@@ -3243,7 +3243,7 @@ func TestApplyDeleteVaultCertificates(t *testing.T) {
 							ClientCertificate:    base64.StdEncoding.EncodeToString([]byte("cert")),
 							ClientKey:            base64.StdEncoding.EncodeToString([]byte("key")),
 						}},
-					})
+					}})
 				}
 				return cr
 			},
@@ -3408,8 +3408,8 @@ func TestGetAuthApplyCR(t *testing.T) {
 			t.Fatalf("expected CR to have modules")
 		}
 		cr.Spec.Modules[0].ConfigVersion = shared.AuthServerConfigVersion
-		cr.Spec.Modules[0].Components = []csmv1.ContainerTemplate{
-			{
+		cr.Spec.Modules[0].Components = []csmv1.PodTemplate{
+			{ContainerTemplate: csmv1.ContainerTemplate{
 				Name: "karavi-authorization-proxy",
 				Envs: []corev1.EnvVar{
 					{
@@ -3417,7 +3417,7 @@ func TestGetAuthApplyCR(t *testing.T) {
 						Value: "testing-proxy-host",
 					},
 				},
-			},
+			}},
 		}
 
 		client := ctrlClientFake.NewClientBuilder().WithObjects().Build()
@@ -3666,16 +3666,16 @@ func CsmAuthorizationCR() csmv1.ContainerStorageModule {
 					Enabled:           true,
 					ConfigVersion:     shared.AuthServerConfigVersion,
 					ForceRemoveModule: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    AuthNginxIngressComponent,
 							Enabled: &trueBool,
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    AuthCertManagerComponent,
 							Enabled: &trueBool,
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:                            AuthProxyServerComponent,
 							Enabled:                         &trueBool,
 							ProxyServiceReplicas:            1,
@@ -3696,8 +3696,8 @@ func CsmAuthorizationCR() csmv1.ContainerStorageModule {
 								},
 							},
 							OpenTelemetryCollectorAddress: "",
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name: AuthRedisComponent,
 							RedisSecretProviderClass: []csmv1.RedisSecretProviderClass{
 								{
@@ -3715,8 +3715,8 @@ func CsmAuthorizationCR() csmv1.ContainerStorageModule {
 							RedisCommander: "rediscommander",
 							Sentinel:       "sentinel",
 							RedisReplicas:  5,
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name: AuthConfigSecretComponent,
 							ConfigSecretProviderClass: []csmv1.ConfigSecretProviderClass{
 								{
@@ -3727,13 +3727,13 @@ func CsmAuthorizationCR() csmv1.ContainerStorageModule {
 									},
 								},
 							},
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name: AuthStorageSystemCredentialsComponent,
 							SecretProviderClasses: &csmv1.StorageSystemSecretProviderClasses{
 								Vaults: []string{"secret-provider-class-1", "secret-provider-class-2"},
 							},
-						},
+						}},
 					},
 				},
 			},
@@ -3760,14 +3760,14 @@ func csmPowerScaleWithAuthCR() csmv1.ContainerStorageModule {
 	csiLogLevel := corev1.EnvVar{Name: "CSI_LOG_LEVEL", Value: "debug"}
 
 	// Add node fields specific
-	res.Spec.Driver.Node = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Node = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Node != nil {
 		res.Spec.Driver.Node.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 		res.Spec.Driver.Node.Envs = []corev1.EnvVar{csiLogLevel}
 	}
 
 	// Add controller fields specific
-	res.Spec.Driver.Controller = &csmv1.ContainerTemplate{}
+	res.Spec.Driver.Controller = &csmv1.PodTemplate{}
 	if res.Spec.Driver.Controller != nil {
 		res.Spec.Driver.Controller.NodeSelector = map[string]string{"thisIs": "NodeSelector"}
 		res.Spec.Driver.Controller.Envs = []corev1.EnvVar{csiLogLevel}
@@ -3776,7 +3776,7 @@ func csmPowerScaleWithAuthCR() csmv1.ContainerStorageModule {
 	res.Spec.Modules = []csmv1.Module{{
 		Name:    csmv1.Authorization,
 		Enabled: true,
-		Components: []csmv1.ContainerTemplate{{
+		Components: []csmv1.PodTemplate{{ContainerTemplate: csmv1.ContainerTemplate{
 			Name:  "karavi-authorization-proxy",
 			Image: "image",
 			Envs: []corev1.EnvVar{
@@ -3789,7 +3789,7 @@ func csmPowerScaleWithAuthCR() csmv1.ContainerStorageModule {
 					Value: "true",
 				},
 			},
-		}},
+		}}},
 	}}
 	return res
 }
@@ -4026,8 +4026,8 @@ func createAuthCRWithGatewayAPI() csmv1.ContainerStorageModule {
 					Enabled:           true,
 					ConfigVersion:     "v2.5.0",
 					ForceRemoveModule: false,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:  "proxy-server",
 							Image: "dellemc/csm-authorization-proxy:v2.5.0",
 							Envs: []corev1.EnvVar{
@@ -4037,7 +4037,7 @@ func createAuthCRWithGatewayAPI() csmv1.ContainerStorageModule {
 								GatewayClassName: "nginx",
 								Hosts:            []string{},
 							},
-						},
+						}},
 					},
 				},
 			},
@@ -4442,3 +4442,23 @@ func TestGetHosts(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAuthorizationEnvs(t *testing.T) {
+	auth := func(envs ...corev1.EnvVar) csmv1.Module {
+		return csmv1.Module{Name: csmv1.Authorization, Components: []csmv1.PodTemplate{{ContainerTemplate: csmv1.ContainerTemplate{Name: "karavi-authorization-proxy", Envs: envs}}}}
+	}
+
+	skipCertValid, err := ValidateAuthorizationEnvs(auth(corev1.EnvVar{Name: "PROXY_HOST", Value: "authorization-ingress-nginx-controller.authorization.svc.cluster.local"}, corev1.EnvVar{Name: "SKIP_CERTIFICATE_VALIDATION", Value: "true"}))
+	assert.NoError(t, err)
+	assert.True(t, skipCertValid)
+
+	_, err = ValidateAuthorizationEnvs(auth(corev1.EnvVar{Name: "SKIP_CERTIFICATE_VALIDATION", Value: "yes please"}))
+	assert.ErrorContains(t, err, "invalid value for SKIP_CERTIFICATE_VALIDATION")
+
+	_, err = ValidateAuthorizationEnvs(auth(corev1.EnvVar{Name: "PROXY_HOST", Value: ""}))
+	assert.ErrorContains(t, err, "PROXY_HOST for authorization is empty")
+
+	skipCertValid, err = ValidateAuthorizationEnvs(csmv1.Module{Name: csmv1.Authorization})
+	assert.NoError(t, err)
+	assert.False(t, skipCertValid)
+}
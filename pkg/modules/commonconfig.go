@@ -15,12 +15,13 @@ package modules
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	csmv1 "github.com/dell/csm-operator/api/v1"
 	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	corev1 "k8s.io/api/core/v1"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -72,27 +73,43 @@ type SupportedDriverParam struct {
 }
 
 func checkVersion(moduleType, givenVersion, configPath string) error {
-	files, err := os.ReadDir(fmt.Sprintf("%s/moduleconfig/%s/", configPath, moduleType))
+	versions, err := operatorutils.Templates(configPath).ModuleVersions(csmv1.ModuleType(moduleType))
 	if err != nil {
 		return err
 	}
-	found := false
-	supportedVersions := ""
-	for _, file := range files {
-		supportedVersions += (file.Name() + ",")
-		if file.Name() == givenVersion {
-			found = true
-		}
-	}
-	if !found {
+	if !slices.Contains(versions, givenVersion) {
 		return fmt.Errorf(
 			"CSM %s does not have %s version. The following are supported versions: %s",
-			moduleType, givenVersion, supportedVersions[:len(supportedVersions)-1],
+			moduleType, givenVersion, strings.Join(versions, ","),
 		)
 	}
 	return nil
 }
 
+// CheckVersion - returns an error if the config version of the module type is not in the config directory
+func CheckVersion(moduleType csmv1.ModuleType, givenVersion, configPath string) error {
+	return checkVersion(string(moduleType), givenVersion, configPath)
+}
+
+// GetSupportedDrivers - returns the drivers supported by the module type, false if the module type is unknown
+func GetSupportedDrivers(moduleType csmv1.ModuleType) (map[string]SupportedDriverParam, bool) {
+	switch moduleType {
+	case csmv1.Authorization:
+		return AuthorizationSupportedDrivers, true
+	case csmv1.Replication:
+		return ReplicationSupportedDrivers, true
+	case csmv1.Resiliency:
+		return ResiliencySupportedDrivers, true
+	case csmv1.Observability:
+		return ObservabilitySupportedDrivers, true
+	case csmv1.ReverseProxy:
+		return ReverseproxySupportedDrivers, true
+	case csmv1.VgSnapShotter:
+		return VgSnapshotterSupportedDrivers, true
+	}
+	return nil, false
+}
+
 func readConfigFile(ctx context.Context, module csmv1.Module, cr csmv1.ContainerStorageModule, op operatorutils.OperatorConfig, filename string) ([]byte, error) {
 	moduleConfigVersion := module.ConfigVersion
 	if moduleConfigVersion == "" {
@@ -118,19 +135,16 @@ func readConfigFile(ctx context.Context, module csmv1.Module, cr csmv1.Container
 	}
 
 	if module.Name == csmv1.AuthorizationServer {
-		configPath := fmt.Sprintf("%s/moduleconfig/%s/%s/%s", op.ConfigDirectory, csmv1.Authorization, moduleConfigVersion, filename)
-		return os.ReadFile(filepath.Clean(configPath))
+		return operatorutils.Templates(op.ConfigDirectory).ModuleFile(csmv1.Authorization, moduleConfigVersion, filename)
 	}
 
-	configMapPath := fmt.Sprintf("%s/moduleconfig/%s/%s/%s", op.ConfigDirectory, module.Name, moduleConfigVersion, filename)
-	return os.ReadFile(filepath.Clean(configMapPath))
+	return operatorutils.Templates(op.ConfigDirectory).ModuleFile(module.Name, moduleConfigVersion, filename)
 }
 
 // getCertManager - configure cert-manager with the specified namespace before installation
 func getCertManager(ctx context.Context, op operatorutils.OperatorConfig, cr csmv1.ContainerStorageModule, matched operatorutils.VersionSpec) (string, error) {
 	YamlString := ""
-	certManagerPath := fmt.Sprintf("%s/moduleconfig/common/cert-manager/%s", op.ConfigDirectory, CertManagerManifest)
-	buf, err := os.ReadFile(filepath.Clean(certManagerPath))
+	buf, err := operatorutils.Templates(op.ConfigDirectory).ReadFile(filepath.Join("moduleconfig", "common", "cert-manager", CertManagerManifest))
 	if err != nil {
 		return YamlString, err
 	}
@@ -172,8 +186,7 @@ func getCertManager(ctx context.Context, op operatorutils.OperatorConfig, cr csm
 func getCertManagerCRDs(op operatorutils.OperatorConfig) (string, error) {
 	YamlString := ""
 
-	certManagerPath := fmt.Sprintf("%s/moduleconfig/common/cert-manager/%s", op.ConfigDirectory, CertManagerCRDsManifest)
-	buf, err := os.ReadFile(filepath.Clean(certManagerPath))
+	buf, err := operatorutils.Templates(op.ConfigDirectory).ReadFile(filepath.Join("moduleconfig", "common", "cert-manager", CertManagerCRDsManifest))
 	if err != nil {
 		return YamlString, err
 	}
@@ -230,8 +243,7 @@ func CommonCertManager(ctx context.Context, isDeleting bool, op operatorutils.Op
 func getCSMDRCRDs(op operatorutils.OperatorConfig) (string, error) {
 	YamlString := ""
 
-	certManagerPath := fmt.Sprintf("%s/moduleconfig/common/disaster-recovery/%s", op.ConfigDirectory, CSMDRCRDsManifest)
-	buf, err := os.ReadFile(filepath.Clean(certManagerPath))
+	buf, err := operatorutils.Templates(op.ConfigDirectory).ReadFile(filepath.Join("moduleconfig", "common", "disaster-recovery", CSMDRCRDsManifest))
 	if err != nil {
 		return YamlString, err
 	}
@@ -267,10 +279,17 @@ func PatchCSMDRCRDs(ctx context.Context, isDeleting bool, op operatorutils.Opera
 }
 
 func applyDeleteObjects(ctx context.Context, ctrlClient crclient.Client, yamlString string, isDeleting bool) error {
+	return applyDeleteWorkloads(ctx, ctrlClient, yamlString, isDeleting, nil, nil)
+}
+
+// applyDeleteWorkloads - apply/delete the objects in yamlString, adding the image pull secrets and component templates to their pod templates
+func applyDeleteWorkloads(ctx context.Context, ctrlClient crclient.Client, yamlString string, isDeleting bool, imagePullSecrets []corev1.LocalObjectReference, components []csmv1.PodTemplate) error {
 	ctrlObjects, err := operatorutils.GetModuleComponentObj([]byte(yamlString))
 	if err != nil {
 		return err
 	}
+	operatorutils.SetImagePullSecrets(ctrlObjects, imagePullSecrets)
+	operatorutils.SetContainerTemplates(ctrlObjects, components)
 
 	for _, ctrlObj := range ctrlObjects {
 		if isDeleting {
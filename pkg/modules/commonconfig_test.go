@@ -617,3 +617,21 @@ func TestGetCertManager_EmptyVersionFallsThrough(t *testing.T) {
 	// Should fall through to defaults.
 	assert.Contains(t, yaml, CertManagerCaInjectorImage)
 }
+
+func TestCheckVersion(t *testing.T) {
+	assert.NoError(t, CheckVersion(csmv1.VgSnapShotter, "v1.8.0", operatorConfig.ConfigDirectory))
+	assert.ErrorContains(t, CheckVersion(csmv1.Replication, "v0.0.1", operatorConfig.ConfigDirectory), "does not have v0.0.1 version")
+}
+
+func TestGetSupportedDrivers(t *testing.T) {
+	supported, ok := GetSupportedDrivers(csmv1.Replication)
+	assert.True(t, ok)
+	assert.Contains(t, supported, string(csmv1.PowerStore))
+
+	supported, ok = GetSupportedDrivers(csmv1.VgSnapShotter)
+	assert.True(t, ok)
+	assert.NotContains(t, supported, string(csmv1.Unity))
+
+	_, ok = GetSupportedDrivers(csmv1.AuthorizationServer)
+	assert.False(t, ok)
+}
@@ -21,6 +21,7 @@ import (
 	drivers "github.com/dell/csm-operator/pkg/drivers"
 	"github.com/dell/csm-operator/pkg/logger"
 	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/dell/csm-operator/pkg/resources/contenthash"
 	"github.com/dell/csm-operator/pkg/resources/deployment"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -258,6 +259,20 @@ const (
 // ComponentNameToSecretPrefix - map from component name to secret prefix
 var ComponentNameToSecretPrefix = map[string]string{ObservabilityOtelCollectorName: "otel-collector", ObservabilityTopologyName: "karavi-topology", ObservabilityMetricsPowerStoreName: "karavi-metrics-powerstore"}
 
+// observabilityContainerTemplates - returns the observability components named after the containers they configure,
+// the topology and metrics containers carry a karavi- prefix
+func observabilityContainerTemplates(cr csmv1.ContainerStorageModule) []csmv1.PodTemplate {
+	components := operatorutils.GetModuleComponents(cr, csmv1.Observability)
+	templates := make([]csmv1.PodTemplate, 0, len(components))
+	for _, component := range components {
+		if component.Name != ObservabilityOtelCollectorName && component.Name != ObservabilityCertManagerComponent {
+			component.Name = "karavi-" + component.Name
+		}
+		templates = append(templates, component)
+	}
+	return templates
+}
+
 // ObservabilitySupportedDrivers is a map containing the CSI Drivers supported by CSM Replication. The key is driver name and the value is the driver plugin identifier
 var ObservabilitySupportedDrivers = map[string]SupportedDriverParam{
 	"powerscale": {
@@ -351,6 +366,13 @@ func ObservabilityTopology(ctx context.Context, isDeleting bool, op operatorutil
 				}
 			}
 		}
+		if !isDeleting {
+			if err := copyObservabilityImagePullSecrets(ctx, cr, ctrlClient); err != nil {
+				return err
+			}
+		} else if err := operatorutils.DeleteImagePullSecrets(ctx, operatorutils.ObservabilityNamespace, operatorutils.GetOwnerLabels(cr), ctrlClient); err != nil {
+			return err
+		}
 	} else {
 		return fmt.Errorf("CSM Operator does not suport topology deployment from CSM 1.15 onwards")
 	}
@@ -375,7 +397,7 @@ func getTopology(ctx context.Context, op operatorutils.OperatorConfig, cr csmv1.
 
 	for _, component := range obs.Components {
 		if component.Name == ObservabilityTopologyName {
-			topologyImage = operatorutils.GetFinalImage(ctx, cr, matched, component, YamlString)
+			topologyImage = operatorutils.GetFinalImage(ctx, cr, matched, component.ContainerTemplate, YamlString)
 			for _, env := range component.Envs {
 				if strings.Contains(TopologyLogLevel, env.Name) {
 					logLevel = env.Value
@@ -393,6 +415,8 @@ func getTopology(ctx context.Context, op operatorutils.OperatorConfig, cr csmv1.
 		return nil, err
 	}
 	operatorutils.SetContainerImage(topoObjects, "karavi-topology", "karavi-topology", topologyImage)
+	operatorutils.SetImagePullSecrets(topoObjects, operatorutils.GetImagePullSecrets(cr, csmv1.Observability))
+	operatorutils.SetContainerTemplates(topoObjects, observabilityContainerTemplates(cr))
 
 	return topoObjects, nil
 }
@@ -408,6 +432,8 @@ func OtelCollector(ctx context.Context, isDeleting bool, op operatorutils.Operat
 	if err != nil {
 		return err
 	}
+	operatorutils.SetImagePullSecrets(otelObjects, operatorutils.GetImagePullSecrets(cr, csmv1.Observability))
+	operatorutils.SetContainerTemplates(otelObjects, observabilityContainerTemplates(cr))
 
 	for _, ctrlObj := range otelObjects {
 		if isDeleting {
@@ -421,7 +447,10 @@ func OtelCollector(ctx context.Context, isDeleting bool, op operatorutils.Operat
 		}
 	}
 
-	return nil
+	if !isDeleting {
+		return copyObservabilityImagePullSecrets(ctx, cr, ctrlClient)
+	}
+	return operatorutils.DeleteImagePullSecrets(ctx, operatorutils.ObservabilityNamespace, operatorutils.GetOwnerLabels(cr), ctrlClient)
 }
 
 // getOtelCollector - get otel collector yaml string
@@ -576,11 +605,15 @@ func PowerScaleMetrics(ctx context.Context, isDeleting bool, op operatorutils.Op
 			if err = ctrlClient.Delete(ctx, deploymentObj); err != nil && !k8serrors.IsNotFound(err) {
 				return fmt.Errorf("error deleting deployment: %v", err)
 			}
+			contenthash.Forget(deploymentObj)
 		} else {
 			log.Infow("error getting deployment", "deploymentKey", deploymentKey)
 		}
 	} else {
 		// Create/Update Deployment
+		if err = copyObservabilityImagePullSecrets(ctx, cr, ctrlClient); err != nil {
+			return err
+		}
 		if err = deployment.SyncDeployment(ctx, *dpApply, k8sClient, cr.Name); err != nil {
 			return err
 		}
@@ -651,11 +684,15 @@ func PowerStoreMetrics(ctx context.Context, isDeleting bool, op operatorutils.Op
 			if err = ctrlClient.Delete(ctx, deploymentObj); err != nil && !k8serrors.IsNotFound(err) {
 				return fmt.Errorf("error deleting deployment: %v", err)
 			}
+			contenthash.Forget(deploymentObj)
 		} else {
 			log.Infow("error getting deployment", "deploymentKey", deploymentKey)
 		}
 	} else {
 		// Create/Update Deployment
+		if err = copyObservabilityImagePullSecrets(ctx, cr, ctrlClient); err != nil {
+			return err
+		}
 		if err = deployment.SyncDeployment(ctx, *dpApply, k8sClient, cr.Name); err != nil {
 			return err
 		}
@@ -696,7 +733,7 @@ func getPowerStoreMetricsObjects(ctx context.Context, op operatorutils.OperatorC
 
 	for _, component := range obs.Components {
 		if component.Name == ObservabilityMetricsPowerStoreName {
-			obsPstoreImage = operatorutils.GetFinalImage(ctx, cr, matched, component, YamlString)
+			obsPstoreImage = operatorutils.GetFinalImage(ctx, cr, matched, component.ContainerTemplate, YamlString)
 			for _, env := range component.Envs {
 				if strings.Contains(PstoreMaxConcurrentQueries, env.Name) {
 					maxConcurrentQueries = env.Value
@@ -793,7 +830,7 @@ func getPowerScaleMetricsObjects(ctx context.Context, op operatorutils.OperatorC
 
 	for _, component := range obs.Components {
 		if component.Name == ObservabilityMetricsPowerScaleName {
-			pscaleImage = operatorutils.GetFinalImage(ctx, cr, matched, component, YamlString)
+			pscaleImage = operatorutils.GetFinalImage(ctx, cr, matched, component.ContainerTemplate, YamlString)
 			for _, env := range component.Envs {
 				if strings.Contains(PowerscaleLogLevel, env.Name) {
 					logLevel = env.Value
@@ -876,6 +913,11 @@ func parseObservabilityMetricsDeployment(ctx context.Context, deployment *appsv1
 		}
 	}
 
+	operatorutils.SetImagePullSecretsApply(operatorutils.GetImagePullSecrets(cr, csmv1.Observability), dpApply.Spec.Template.Spec)
+	if err := operatorutils.SetContainerTemplatesApply(observabilityContainerTemplates(cr), dpApply.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+
 	// inject authorization to deployment
 	if authorizationEnabled, _ := operatorutils.IsModuleEnabled(ctx, cr, csmv1.Authorization); authorizationEnabled {
 		dpApply, err = AuthInjectDeployment(ctx, *dpApply, cr, op, ctrlClient)
@@ -986,11 +1028,15 @@ func PowerFlexMetrics(ctx context.Context, isDeleting bool, op operatorutils.Ope
 			if err = ctrlClient.Delete(ctx, deploymentObj); err != nil && !k8serrors.IsNotFound(err) {
 				return fmt.Errorf("error deleting deployment: %v", err)
 			}
+			contenthash.Forget(deploymentObj)
 		} else {
 			log.Infow("error getting deployment", "deploymentKey", deploymentKey)
 		}
 	} else {
 		// Create/Update Deployment
+		if err = copyObservabilityImagePullSecrets(ctx, cr, ctrlClient); err != nil {
+			return err
+		}
 		if err = deployment.SyncDeployment(ctx, *dpApply, k8sClient, cr.Name); err != nil {
 			return err
 		}
@@ -1028,7 +1074,7 @@ func getPowerFlexMetricsObject(ctx context.Context, op operatorutils.OperatorCon
 
 	for _, component := range obs.Components {
 		if component.Name == ObservabilityMetricsPowerFlexName {
-			pflexImage = operatorutils.GetFinalImage(ctx, cr, matched, component, YamlString)
+			pflexImage = operatorutils.GetFinalImage(ctx, cr, matched, component.ContainerTemplate, YamlString)
 			for _, env := range component.Envs {
 				if strings.Contains(PowerflexLogLevel, env.Name) {
 					logLevel = env.Value
@@ -1154,6 +1200,15 @@ func createObsSecretObj(driverSecret corev1.Secret, newNameSpace, newSecretName
 	}
 }
 
+// copyObservabilityImagePullSecrets - copy the image pull secrets of observability into the karavi namespace
+func copyObservabilityImagePullSecrets(ctx context.Context, cr csmv1.ContainerStorageModule, ctrlClient client.Client) error {
+	imagePullSecrets := operatorutils.GetImagePullSecrets(cr, csmv1.Observability)
+	if err := operatorutils.CopyImagePullSecrets(ctx, cr, imagePullSecrets, operatorutils.ObservabilityNamespace, operatorutils.GetOwnerLabels(cr), ctrlClient); err != nil {
+		return fmt.Errorf("copy image pull secrets from %s: %v", cr.Namespace, err)
+	}
+	return nil
+}
+
 // getNewAuthSecretName - add prefix to secretName
 func getNewAuthSecretName(driverType csmv1.DriverType, secretName string) string {
 	return fmt.Sprintf("%s-%s", driverType, secretName)
@@ -1322,11 +1377,15 @@ func PowerMaxMetrics(ctx context.Context, isDeleting bool, op operatorutils.Oper
 			if err = ctrlClient.Delete(ctx, deploymentObj); err != nil && !k8serrors.IsNotFound(err) {
 				return fmt.Errorf("error deleting deployment: %v", err)
 			}
+			contenthash.Forget(deploymentObj)
 		} else {
 			log.Infow("error getting deployment", "deploymentKey", deploymentKey)
 		}
 	} else {
 		// Create/Update Deployment
+		if err = copyObservabilityImagePullSecrets(ctx, cr, ctrlClient); err != nil {
+			return err
+		}
 		if err = deployment.SyncDeployment(ctx, *dpApply, k8sClient, cr.Name); err != nil {
 			return err
 		}
@@ -1418,7 +1477,7 @@ func getPowerMaxMetricsObject(ctx context.Context, op operatorutils.OperatorConf
 
 	for _, component := range obs.Components {
 		if component.Name == ObservabilityMetricsPowerMaxName {
-			pmaxImage = operatorutils.GetFinalImage(ctx, cr, matched, component, YamlString)
+			pmaxImage = operatorutils.GetFinalImage(ctx, cr, matched, component.ContainerTemplate, YamlString)
 			for _, env := range component.Envs {
 				if strings.Contains(PmaxLogLevel, env.Name) {
 					logLevel = env.Value
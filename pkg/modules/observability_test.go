@@ -1572,8 +1572,8 @@ func TestGetTopology_MockedInputs_CoversImageAndLogLevelBranch(t *testing.T) {
 		return csmv1.Module{
 			Name:    csmv1.Observability,
 			Enabled: true,
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:  ObservabilityTopologyName,                                         // e.g., "karavi-topology"
 					Image: csmv1.ImageType("registry.example/karavi-topology:test-override"), // non-empty → covers image branch
 					Envs: []corev1.EnvVar{
@@ -1583,7 +1583,7 @@ func TestGetTopology_MockedInputs_CoversImageAndLogLevelBranch(t *testing.T) {
 							Value: "DEBUG",
 						},
 					},
-				},
+				}},
 			},
 		}, nil
 	}
@@ -1680,14 +1680,14 @@ func TestGetPowerFlexMetricsObject_ImageFromMatchedVersionSpec(t *testing.T) {
 		return csmv1.Module{
 			Name:    csmv1.Observability,
 			Enabled: true,
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name: ObservabilityMetricsPowerFlexName, // the component we scan
 					// Do NOT set component.Image here so matched override is visible
 					Envs: []corev1.EnvVar{
 						{Name: PowerflexLogLevel, Value: "INFO"},
 					},
-				},
+				}},
 			},
 		}, nil
 	}
@@ -1774,11 +1774,11 @@ func TestAppendObservabilitySecrets_SkipCertTrue_SkipsRootCert(t *testing.T) {
 				{
 					Name:    csmv1.Authorization,
 					Enabled: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name: "karavi-authorization-proxy",
 							Envs: []corev1.EnvVar{{Name: "SKIP_CERTIFICATE_VALIDATION", Value: "true"}},
-						},
+						}},
 					},
 				},
 			},
@@ -1841,11 +1841,11 @@ func TestAppendObservabilitySecrets_SkipCertFalse_IncludesRootCert(t *testing.T)
 				{
 					Name:    csmv1.Authorization,
 					Enabled: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name: "karavi-authorization-proxy",
 							Envs: []corev1.EnvVar{{Name: "SKIP_CERTIFICATE_VALIDATION", Value: "false"}},
-						},
+						}},
 					},
 				},
 			},
@@ -1899,11 +1899,11 @@ func TestAppendObservabilitySecrets_InvalidSkipCertValue_ReturnsError(t *testing
 				{
 					Name:    csmv1.Authorization,
 					Enabled: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name: "karavi-authorization-proxy",
 							Envs: []corev1.EnvVar{{Name: "SKIP_CERTIFICATE_VALIDATION", Value: "not-a-bool"}},
-						},
+						}},
 					},
 				},
 			},
@@ -1938,12 +1938,12 @@ func TestObservabilityTopology_SupportedVersion_AppliesObjects(t *testing.T) {
 		return csmv1.Module{
 			Name:    csmv1.Observability,
 			Enabled: true,
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    ObservabilityTopologyName,
 					Enabled: func() *bool { b := true; return &b }(),
 					Envs:    []corev1.EnvVar{{Name: TopologyLogLevel, Value: "DEBUG"}},
-				},
+				}},
 			},
 		}, nil
 	}
@@ -2019,12 +2019,12 @@ func TestObservabilityTopology_SupportedVersion_Deleting_DeletesObjects(t *testi
 		return csmv1.Module{
 			Name:    csmv1.Observability,
 			Enabled: true,
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:    ObservabilityTopologyName,
 					Enabled: func() *bool { b := true; return &b }(),
 					Envs:    []corev1.EnvVar{{Name: TopologyLogLevel, Value: "INFO"}},
-				},
+				}},
 			},
 		}, nil
 	}
@@ -2078,12 +2078,12 @@ func TestGetPowerFlexMetricsObject_ComponentImageOverridesMatched(t *testing.T)
 		return csmv1.Module{
 			Name:    csmv1.Observability,
 			Enabled: true,
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name:  ObservabilityMetricsPowerFlexName,
 					Image: csmv1.ImageType("registry.example/karavi-metrics-powerflex:from-component"),
 					Envs:  nil,
-				},
+				}},
 			},
 		}, nil
 	}
@@ -2161,13 +2161,13 @@ func TestGetPowerFlexMetricsObject_CustomRegistryOnly(t *testing.T) {
 		return csmv1.Module{
 			Name:    csmv1.Observability,
 			Enabled: true,
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name: ObservabilityMetricsPowerFlexName,
 					Envs: []corev1.EnvVar{
 						{Name: PowerflexLogLevel, Value: "INFO"},
 					},
-				},
+				}},
 			},
 		}, nil
 	}
@@ -2243,13 +2243,13 @@ func TestGetPowerFlexMetricsObject_ConfigMapWinsOverCustomRegistry(t *testing.T)
 		return csmv1.Module{
 			Name:    csmv1.Observability,
 			Enabled: true,
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name: ObservabilityMetricsPowerFlexName,
 					Envs: []corev1.EnvVar{
 						{Name: PowerflexLogLevel, Value: "INFO"},
 					},
-				},
+				}},
 			},
 		}, nil
 	}
@@ -2331,13 +2331,13 @@ func TestGetPowerFlexMetricsObject_NeitherConfigMapNorRegistry(t *testing.T) {
 		return csmv1.Module{
 			Name:    csmv1.Observability,
 			Enabled: true,
-			Components: []csmv1.ContainerTemplate{
-				{
+			Components: []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{
 					Name: ObservabilityMetricsPowerFlexName,
 					Envs: []corev1.EnvVar{
 						{Name: PowerflexLogLevel, Value: "INFO"},
 					},
-				},
+				}},
 			},
 		}, nil
 	}
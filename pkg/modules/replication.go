@@ -164,10 +164,14 @@ func getReplicaApplyCR(ctx context.Context, cr csmv1.ContainerStorageModule, op
 
 	for _, component := range replicaModule.Components {
 		if component.Name == operatorutils.ReplicationSideCarName {
-			*container.Image = operatorutils.GetFinalImage(ctx, cr, matched, component, *container.Image)
+			*container.Image = operatorutils.GetFinalImage(ctx, cr, matched, component.ContainerTemplate, *container.Image)
 			if component.ImagePullPolicy != "" {
 				container.ImagePullPolicy = &component.ImagePullPolicy
 			}
+			err = operatorutils.UpdateContainerResourcesApply(component.ContainerTemplate, &container)
+			if err != nil {
+				return nil, nil, err
+			}
 		}
 	}
 
@@ -400,7 +404,7 @@ func getReplicaController(ctx context.Context, op operatorutils.OperatorConfig,
 
 	for _, component := range replica.Components {
 		if component.Name == operatorutils.ReplicationControllerManager {
-			replicaImage = operatorutils.GetFinalImage(ctx, cr, matched, component, YamlString)
+			replicaImage = operatorutils.GetFinalImage(ctx, cr, matched, component.ContainerTemplate, YamlString)
 			for _, env := range component.Envs {
 				if strings.Contains(DefaultLogLevel, env.Name) && env.Value != "" {
 					logLevel = env.Value
@@ -447,6 +451,16 @@ func getReplicaController(ctx context.Context, op operatorutils.OperatorConfig,
 			}
 		}
 	}
+	operatorutils.SetImagePullSecrets(ctrlObjects, operatorutils.GetImagePullSecrets(cr, csmv1.Replication))
+	// the controller manager component configures the manager container of the deployment
+	templates := make([]csmv1.PodTemplate, 0, len(replica.Components))
+	for _, component := range replica.Components {
+		if component.Name == operatorutils.ReplicationControllerManager {
+			component.Name = "manager"
+		}
+		templates = append(templates, component)
+	}
+	operatorutils.SetContainerTemplates(ctrlObjects, templates)
 	return ctrlObjects, nil
 }
 
@@ -488,6 +502,15 @@ func ReplicationManagerController(ctx context.Context, isDeleting bool, op opera
 		}
 	}
 
+	if !isDeleting {
+		imagePullSecrets := operatorutils.GetImagePullSecrets(cr, csmv1.Replication)
+		if err := operatorutils.CopyImagePullSecrets(ctx, cr, imagePullSecrets, operatorutils.ReplicationControllerNameSpace, operatorutils.GetOwnerLabels(cr), ctrlClient); err != nil {
+			return err
+		}
+	} else if err := operatorutils.DeleteImagePullSecrets(ctx, operatorutils.ReplicationControllerNameSpace, operatorutils.GetOwnerLabels(cr), ctrlClient); err != nil {
+		return err
+	}
+
 	return nil
 }
 
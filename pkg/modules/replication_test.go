@@ -463,6 +463,28 @@ func TestReplicationManagerController(t *testing.T) {
 	}
 }
 
+func TestReplicationManagerControllerImagePullSecrets(t *testing.T) {
+	ctx := context.Background()
+	cr, err := getCustomResource("./testdata/cr_powerscale_replica.yaml")
+	if err != nil {
+		panic(err)
+	}
+	cr.Spec.CopyImagePullSecrets = true
+	cr.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "registry"}}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: cr.Namespace}}
+	sourceClient := ctrlClientFake.NewClientBuilder().WithObjects(secret).Build()
+	key := t1.NamespacedName{Name: "registry", Namespace: operatorutils.ReplicationControllerNameSpace}
+
+	assert.NoError(t, ReplicationManagerController(ctx, false, operatorConfig, cr, sourceClient))
+	copied := &corev1.Secret{}
+	assert.NoError(t, sourceClient.Get(ctx, key, copied))
+	assert.Equal(t, operatorutils.GetOwnerLabels(cr), copied.Labels)
+
+	// the copy is removed with the replication controller
+	assert.NoError(t, ReplicationManagerController(ctx, true, operatorConfig, cr, sourceClient))
+	assert.True(t, k8serrors.IsNotFound(sourceClient.Get(ctx, key, &corev1.Secret{})))
+}
+
 func TestReplicationConfigmap(t *testing.T) {
 	// Create a fake client to use in the test
 	scheme := runtime.NewScheme()
@@ -721,6 +743,43 @@ func TestGetReplicaController_UsesMatchedManagerImage_And_InitImageFromComponent
 	}
 }
 
+func TestGetReplicaController_ContainerTemplate(t *testing.T) {
+	ctx := context.Background()
+	cr, err := getCustomResource("./testdata/cr_powerscale_replica.yaml")
+	if err != nil {
+		panic(err)
+	}
+	runAsNonRoot := true
+	for i, c := range cr.Spec.Modules[0].Components {
+		if c.Name == operatorutils.ReplicationControllerManager {
+			cr.Spec.Modules[0].Components[i].SecurityContext = &corev1.SecurityContext{RunAsNonRoot: &runAsNonRoot}
+			cr.Spec.Modules[0].Components[i].PriorityClassName = "system-cluster-critical"
+		}
+	}
+	op := operatorutils.OperatorConfig{ConfigDirectory: "../../operatorconfig"}
+
+	ctrlObjects, err := getReplicaController(ctx, op, cr, operatorutils.VersionSpec{})
+	assert.NoError(t, err)
+	var dep *appsv1.Deployment
+	for _, obj := range ctrlObjects {
+		if d, ok := obj.(*appsv1.Deployment); ok {
+			dep = d
+		}
+	}
+	if dep == nil {
+		t.Fatalf("expected a Deployment in ctrlObjects, got none")
+	}
+
+	// the controller manager component configures the manager container
+	assert.Equal(t, "manager", dep.Spec.Template.Spec.Containers[0].Name)
+	assert.Equal(t, &runAsNonRoot, dep.Spec.Template.Spec.Containers[0].SecurityContext.RunAsNonRoot)
+	assert.Equal(t, "system-cluster-critical", dep.Spec.Template.Spec.PriorityClassName)
+	// the components of the cr keep their names
+	for _, c := range cr.Spec.Modules[0].Components {
+		assert.NotEqual(t, "manager", c.Name)
+	}
+}
+
 func TestGetReplicaController_CoversInitComponentBranch(t *testing.T) {
 	ctx := context.Background()
 
@@ -186,7 +186,7 @@ func getResiliencyEnv(resiliencyModule csmv1.Module, _ csmv1.DriverType) string
 }
 
 // Apply resiliency module from the manifest file to the podmon sidecar
-func modifyPodmon(ctx context.Context, component csmv1.ContainerTemplate, container *acorev1.ContainerApplyConfiguration, matched operatorutils.VersionSpec, cr csmv1.ContainerStorageModule) {
+func modifyPodmon(ctx context.Context, component csmv1.ContainerTemplate, container *acorev1.ContainerApplyConfiguration, matched operatorutils.VersionSpec, cr csmv1.ContainerStorageModule) error {
 	matchedImageApplied := false
 	if matched.Version != "" {
 		containerName := *container.Name
@@ -215,9 +215,13 @@ func modifyPodmon(ctx context.Context, component csmv1.ContainerTemplate, contai
 	emptyEnv := make([]corev1.EnvVar, 0)
 	container.Env = operatorutils.ReplaceAllApplyCustomEnvs(container.Env, emptyEnv, component.Envs)
 	container.Args = operatorutils.ReplaceAllArgs(container.Args, component.Args)
+	if err := operatorutils.UpdateContainerResourcesApply(component, container); err != nil {
+		return fmt.Errorf("applying resources of container %s: %v", component.Name, err)
+	}
+	return nil
 }
 
-func setResiliencyArgs(ctx context.Context, m csmv1.Module, mode string, container *acorev1.ContainerApplyConfiguration, matched operatorutils.VersionSpec, cr csmv1.ContainerStorageModule) {
+func setResiliencyArgs(ctx context.Context, m csmv1.Module, mode string, container *acorev1.ContainerApplyConfiguration, matched operatorutils.VersionSpec, cr csmv1.ContainerStorageModule) error {
 	// handle minimal manifest (no components listed) for override with configmap
 	if len(m.Components) == 0 {
 		var synthetic csmv1.ContainerTemplate
@@ -231,18 +235,23 @@ func setResiliencyArgs(ctx context.Context, m csmv1.Module, mode string, contain
 				Name: operatorutils.PodmonNodeComponent,
 			}
 		default:
-			return
+			return nil
 		}
-		modifyPodmon(ctx, synthetic, container, matched, cr)
+		return modifyPodmon(ctx, synthetic, container, matched, cr)
 	}
 	for _, component := range m.Components {
 		if component.Name == operatorutils.PodmonControllerComponent && mode == controllerMode {
-			modifyPodmon(ctx, component, container, matched, cr)
+			if err := modifyPodmon(ctx, component.ContainerTemplate, container, matched, cr); err != nil {
+				return err
+			}
 		}
 		if component.Name == operatorutils.PodmonNodeComponent && mode == "node" {
-			modifyPodmon(ctx, component, container, matched, cr)
+			if err := modifyPodmon(ctx, component.ContainerTemplate, container, matched, cr); err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
 func getPollRateFromArgs(args []string) string {
@@ -286,7 +295,9 @@ func getResiliencyApplyCR(ctx context.Context, cr csmv1.ContainerStorageModule,
 	}
 
 	// read args from the respective components
-	setResiliencyArgs(ctx, resiliencyModule, mode, &container, matched, cr)
+	if err := setResiliencyArgs(ctx, resiliencyModule, mode, &container, matched, cr); err != nil {
+		return nil, nil, err
+	}
 	return &resiliencyModule, &container, nil
 }
 
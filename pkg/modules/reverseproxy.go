@@ -15,8 +15,6 @@ package modules
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -149,6 +147,8 @@ func ReverseProxyServer(ctx context.Context, isDeleting bool, op operatorutils.O
 	if err != nil {
 		return err
 	}
+	operatorutils.SetImagePullSecrets(deployObjects, operatorutils.GetImagePullSecrets(cr, csmv1.ReverseProxy))
+	operatorutils.SetContainerTemplates(deployObjects, operatorutils.GetModuleComponents(cr, csmv1.ReverseProxy))
 
 	for _, ctrlObj := range deployObjects {
 		log.Infof("Object: %v -----\n", ctrlObj)
@@ -213,6 +213,8 @@ func ReverseProxyStartService(ctx context.Context, isDeleting bool, op operatoru
 	if err != nil {
 		return err
 	}
+	operatorutils.SetImagePullSecrets(deployObjects, operatorutils.GetImagePullSecrets(cr, csmv1.ReverseProxy))
+	operatorutils.SetContainerTemplates(deployObjects, operatorutils.GetModuleComponents(cr, csmv1.ReverseProxy))
 
 	for _, ctrlObj := range deployObjects {
 		log.Infof("Object: %v -----\n", ctrlObj)
@@ -292,8 +294,7 @@ func getReverseProxyDeployment(ctx context.Context, op operatorutils.OperatorCon
 		}
 	}
 
-	deploymentPath := fmt.Sprintf("%s/moduleconfig/%s/%s/%s", op.ConfigDirectory, csmv1.ReverseProxy, revProxyConfigVersion, ReverseProxyDeployment)
-	buf, err := os.ReadFile(filepath.Clean(deploymentPath))
+	buf, err := operatorutils.Templates(op.ConfigDirectory).ModuleFile(csmv1.ReverseProxy, revProxyConfigVersion, ReverseProxyDeployment)
 	if err != nil {
 		return YamlString, err
 	}
@@ -307,7 +308,7 @@ func getReverseProxyDeployment(ctx context.Context, op operatorutils.OperatorCon
 
 	for _, component := range revProxy.Components {
 		if component.Name == ReverseProxyServerComponent {
-			image = operatorutils.GetFinalImage(ctx, cr, matched, component, image)
+			image = operatorutils.GetFinalImage(ctx, cr, matched, component.ContainerTemplate, image)
 			for _, env := range component.Envs {
 				if env.Name == "X_CSI_REVPROXY_TLS_SECRET" {
 					proxyTLSSecret = env.Value
@@ -350,7 +351,7 @@ func ReverseProxyInjectDeployment(ctx context.Context, dp v1.DeploymentApplyConf
 	// update the image
 	for _, side := range revProxyModule.Components {
 		if side.Name == ReverseProxyServerComponent {
-			*container.Image = operatorutils.GetFinalImage(ctx, cr, matched, side, *container.Image)
+			*container.Image = operatorutils.GetFinalImage(ctx, cr, matched, side.ContainerTemplate, *container.Image)
 			// Merge CR environment variables with template environment variables
 			for _, env := range side.Envs {
 				envName := env.Name
@@ -373,6 +374,10 @@ func ReverseProxyInjectDeployment(ctx context.Context, dp v1.DeploymentApplyConf
 					})
 				}
 			}
+			err = operatorutils.UpdateContainerResourcesApply(side.ContainerTemplate, &container)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 	dp.Spec.Template.Spec.Containers = append(dp.Spec.Template.Spec.Containers, container)
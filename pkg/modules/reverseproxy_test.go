@@ -770,8 +770,8 @@ func TestUpdatePowerMaxConfigMap(t *testing.T) {
 					Modules: []csmv1.Module{
 						{
 							Name: csmv1.ReverseProxy,
-							Components: []csmv1.ContainerTemplate{
-								{
+							Components: []csmv1.PodTemplate{
+								{ContainerTemplate: csmv1.ContainerTemplate{
 									Name: ReverseProxyServerComponent,
 									Envs: []corev1.EnvVar{
 										{
@@ -779,7 +779,7 @@ func TestUpdatePowerMaxConfigMap(t *testing.T) {
 											Value: port,
 										},
 									},
-								},
+								}},
 							},
 						},
 					},
@@ -958,14 +958,14 @@ func TestGetRevProxyVolumeComp_TLSSecretConditional(t *testing.T) {
 			revProxyModule := csmv1.Module{
 				Name:          csmv1.ReverseProxy,
 				ConfigVersion: tt.configVersion,
-				Components: []csmv1.ContainerTemplate{
-					{
+				Components: []csmv1.PodTemplate{
+					{ContainerTemplate: csmv1.ContainerTemplate{
 						Name: ReverseProxyServerComponent,
 						Envs: []corev1.EnvVar{
 							{Name: "X_CSI_REVPROXY_TLS_SECRET", Value: tt.envTLSSecretValue},
 							{Name: "X_CSI_CONFIG_MAP_NAME", Value: tt.envConfigMapValue},
 						},
-					},
+					}},
 				},
 			}
 
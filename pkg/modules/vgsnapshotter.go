@@ -0,0 +1,189 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package modules
+
+import (
+	"context"
+	"fmt"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/drivers"
+	"github.com/dell/csm-operator/pkg/logger"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	applyv1 "k8s.io/client-go/applyconfigurations/apps/v1"
+	acorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// VolumeGroupSnapshotClassGVK - group version kind of the VolumeGroupSnapshotClass objects
+var VolumeGroupSnapshotClassGVK = schema.GroupVersionKind{Group: "groupsnapshot.storage.k8s.io", Version: "v1beta1", Kind: "VolumeGroupSnapshotClass"}
+
+// VgSnapshotterSupportedDrivers is a map containing the CSI Drivers supported by CSM VG Snapshotter. The key is driver name and the value is the driver plugin identifier
+var VgSnapshotterSupportedDrivers = map[string]SupportedDriverParam{
+	string(csmv1.PowerFlex): {
+		PluginIdentifier:              drivers.PowerFlexPluginIdentifier,
+		DriverConfigParamsVolumeMount: drivers.PowerFlexConfigParamsVolumeMount,
+	},
+	string(csmv1.PowerFlexName): {
+		PluginIdentifier:              drivers.PowerFlexPluginIdentifier,
+		DriverConfigParamsVolumeMount: drivers.PowerFlexConfigParamsVolumeMount,
+	},
+	string(csmv1.PowerStore): {
+		PluginIdentifier:              drivers.PowerStorePluginIdentifier,
+		DriverConfigParamsVolumeMount: drivers.PowerStoreConfigParamsVolumeMount,
+	},
+}
+
+func getVgSnapshotterModule(cr csmv1.ContainerStorageModule) (csmv1.Module, error) {
+	for _, m := range cr.Spec.Modules {
+		if m.Name == csmv1.VgSnapShotter {
+			return m, nil
+		}
+	}
+	return csmv1.Module{}, fmt.Errorf("could not find vgsnapshotter module")
+}
+
+func getVgSnapshotterApplyCR(ctx context.Context, cr csmv1.ContainerStorageModule, op operatorutils.OperatorConfig, matched operatorutils.VersionSpec) (*acorev1.ContainerApplyConfiguration, error) {
+	vgsModule, err := getVgSnapshotterModule(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := readConfigFile(ctx, vgsModule, cr, op, "container.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	YamlString := operatorutils.ModifyCommonCR(string(buf), cr)
+
+	var container acorev1.ContainerApplyConfiguration
+	err = yaml.Unmarshal([]byte(YamlString), &container)
+	if err != nil {
+		return nil, err
+	}
+
+	// For minimal manifest image override with configmap where component isn't mentioned
+	if len(vgsModule.Components) == 0 {
+		synthetic := csmv1.ContainerTemplate{
+			Name: operatorutils.VgSnapshotterSideCarName,
+		}
+		*container.Image = operatorutils.GetFinalImage(ctx, cr, matched, synthetic, *container.Image)
+	}
+
+	for _, component := range vgsModule.Components {
+		if component.Name == operatorutils.VgSnapshotterSideCarName {
+			*container.Image = operatorutils.GetFinalImage(ctx, cr, matched, component.ContainerTemplate, *container.Image)
+			if component.ImagePullPolicy != "" {
+				container.ImagePullPolicy = &component.ImagePullPolicy
+			}
+			err = operatorutils.UpdateContainerResourcesApply(component.ContainerTemplate, &container)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &container, nil
+}
+
+// VgSnapshotterInjectDeployment - inject the volume group snapshotter sidecar into deployment
+func VgSnapshotterInjectDeployment(ctx context.Context, dp applyv1.DeploymentApplyConfiguration, cr csmv1.ContainerStorageModule, op operatorutils.OperatorConfig, matched operatorutils.VersionSpec) (*applyv1.DeploymentApplyConfiguration, error) {
+	container, err := getVgSnapshotterApplyCR(ctx, cr, op, matched)
+	if err != nil {
+		return nil, err
+	}
+
+	dp.Spec.Template.Spec.Containers = append(dp.Spec.Template.Spec.Containers, *container)
+	return &dp, nil
+}
+
+// VgSnapshotterInjectClusterRole - inject volume group snapshotter rules into clusterrole
+func VgSnapshotterInjectClusterRole(ctx context.Context, clusterRole rbacv1.ClusterRole, cr csmv1.ContainerStorageModule, op operatorutils.OperatorConfig) (*rbacv1.ClusterRole, error) {
+	vgsModule, err := getVgSnapshotterModule(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := readConfigFile(ctx, vgsModule, cr, op, "rules.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []rbacv1.PolicyRule
+	err = yaml.Unmarshal(buf, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterRole.Rules = append(clusterRole.Rules, rules...)
+	return &clusterRole, nil
+}
+
+// VgSnapshotterPrecheck - runs precheck for CSM VG Snapshotter
+func VgSnapshotterPrecheck(ctx context.Context, op operatorutils.OperatorConfig, vgs csmv1.Module, cr csmv1.ContainerStorageModule, _ operatorutils.ReconcileCSM) error {
+	log := logger.GetLogger(ctx)
+
+	if _, ok := VgSnapshotterSupportedDrivers[string(cr.Spec.Driver.CSIDriverType)]; !ok {
+		return fmt.Errorf("CSM Operator does not suport VG Snapshotter deployment for %s driver", cr.Spec.Driver.CSIDriverType)
+	}
+
+	// check if provided version is supported
+	if vgs.ConfigVersion != "" {
+		err := checkVersion(string(csmv1.VgSnapShotter), vgs.ConfigVersion, op.ConfigDirectory)
+		if err != nil {
+			return err
+		}
+	} else {
+		// the driver version must have a matching vgsnapshotter version
+		version, err := operatorutils.GetVersion(ctx, &cr, op)
+		if err != nil {
+			return err
+		}
+		if _, err := operatorutils.GetModuleDefaultVersion(version, cr.Spec.Driver.CSIDriverType, csmv1.VgSnapShotter, op.ConfigDirectory); err != nil {
+			return fmt.Errorf("CSM Operator does not suport VG Snapshotter deployment for %s driver version %s: %v", cr.Spec.Driver.CSIDriverType, version, err)
+		}
+	}
+
+	log.Infof("\nperformed pre checks for: %s", vgs.Name)
+	return nil
+}
+
+// GetVolumeGroupSnapshotClasses - returns the VolumeGroupSnapshotClass objects of the vgsnapshotter module, if it is enabled
+func GetVolumeGroupSnapshotClasses(ctx context.Context, cr csmv1.ContainerStorageModule, driverName string) []*unstructured.Unstructured {
+	enabled, vgsModule := operatorutils.IsModuleEnabled(ctx, cr, csmv1.VgSnapShotter)
+	if !enabled {
+		return nil
+	}
+
+	classes := make([]*unstructured.Unstructured, 0, len(vgsModule.VolumeGroupSnapshotClasses))
+	for _, sc := range vgsModule.VolumeGroupSnapshotClasses {
+		class := &unstructured.Unstructured{}
+		class.SetGroupVersionKind(VolumeGroupSnapshotClassGVK)
+		class.SetName(sc.Name)
+		class.SetLabels(operatorutils.GetOwnerLabels(cr))
+		class.Object["driver"] = driverName
+		class.Object["deletionPolicy"] = "Delete"
+		if len(sc.Parameters) > 0 {
+			parameters := make(map[string]interface{}, len(sc.Parameters))
+			for k, v := range sc.Parameters {
+				parameters[k] = v
+			}
+			class.Object["parameters"] = parameters
+		}
+		classes = append(classes, class)
+	}
+	return classes
+}
@@ -0,0 +1,144 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package modules
+
+import (
+	"context"
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	drivers "github.com/dell/csm-operator/pkg/drivers"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestVgSnapshotterInjectDeployment(t *testing.T) {
+	ctx := context.Background()
+	cr, err := getCustomResource("./testdata/cr_powerflex_vgsnapshotter.yaml")
+	assert.NoError(t, err)
+
+	controllerYAML, err := drivers.GetController(ctx, cr, operatorConfig, csmv1.PowerFlex, operatorutils.VersionSpec{})
+	assert.NoError(t, err)
+
+	dp, err := VgSnapshotterInjectDeployment(ctx, controllerYAML.Deployment, cr, operatorConfig, operatorutils.VersionSpec{})
+	assert.NoError(t, err)
+
+	found := false
+	for _, cnt := range dp.Spec.Template.Spec.Containers {
+		if *cnt.Name == operatorutils.VgSnapshotterSideCarName {
+			found = true
+			assert.Equal(t, "quay.io/dell/container-storage-modules/csi-volumegroup-snapshotter:v1.8.0", *cnt.Image)
+			assert.Contains(t, cnt.Args, "--csi-address=$(ADDRESS)")
+		}
+	}
+	assert.True(t, found)
+
+	_, err = VgSnapshotterInjectDeployment(ctx, controllerYAML.Deployment, cr, badOperatorConfig, operatorutils.VersionSpec{})
+	assert.Error(t, err)
+}
+
+func TestVgSnapshotterInjectClusterRole(t *testing.T) {
+	ctx := context.Background()
+	cr, err := getCustomResource("./testdata/cr_powerflex_vgsnapshotter.yaml")
+	assert.NoError(t, err)
+
+	clusterRole, err := VgSnapshotterInjectClusterRole(ctx, rbacv1.ClusterRole{}, cr, operatorConfig)
+	assert.NoError(t, err)
+
+	found := false
+	for _, rule := range clusterRole.Rules {
+		if len(rule.APIGroups) > 0 && rule.APIGroups[0] == "groupsnapshot.storage.k8s.io" && rule.Resources[0] == "volumegroupsnapshotcontents" {
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	cr.Spec.Modules = nil
+	_, err = VgSnapshotterInjectClusterRole(ctx, rbacv1.ClusterRole{}, cr, operatorConfig)
+	assert.Error(t, err)
+}
+
+func TestVgSnapshotterPrecheck(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		modify  func(cr *csmv1.ContainerStorageModule)
+		wantErr string
+	}{
+		"success - powerflex": {
+			modify: func(_ *csmv1.ContainerStorageModule) {},
+		},
+		"success - powerstore": {
+			modify: func(cr *csmv1.ContainerStorageModule) {
+				cr.Spec.Driver.CSIDriverType = csmv1.PowerStore
+			},
+		},
+		"success - supported config version": {
+			modify: func(cr *csmv1.ContainerStorageModule) {
+				cr.Spec.Modules[0].ConfigVersion = "v1.8.0"
+			},
+		},
+		"fail - unsupported driver": {
+			modify: func(cr *csmv1.ContainerStorageModule) {
+				cr.Spec.Driver.CSIDriverType = csmv1.PowerScale
+			},
+			wantErr: "does not suport VG Snapshotter deployment for isilon driver",
+		},
+		"fail - unsupported config version": {
+			modify: func(cr *csmv1.ContainerStorageModule) {
+				cr.Spec.Modules[0].ConfigVersion = "v0.1.0"
+			},
+			wantErr: "CSM vgsnapshotter does not have v0.1.0 version",
+		},
+		"fail - unsupported driver version": {
+			modify: func(cr *csmv1.ContainerStorageModule) {
+				cr.Spec.Driver.ConfigVersion = "v2.16.0"
+			},
+			wantErr: "driver version v2.16.0",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr, err := getCustomResource("./testdata/cr_powerflex_vgsnapshotter.yaml")
+			assert.NoError(t, err)
+			tc.modify(&cr)
+
+			err = VgSnapshotterPrecheck(ctx, operatorConfig, cr.Spec.Modules[0], cr, nil)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetVolumeGroupSnapshotClasses(t *testing.T) {
+	ctx := context.Background()
+	cr, err := getCustomResource("./testdata/cr_powerflex_vgsnapshotter.yaml")
+	assert.NoError(t, err)
+
+	classes := GetVolumeGroupSnapshotClasses(ctx, cr, "csi-vxflexos.dellemc.com")
+	assert.Len(t, classes, 1)
+	assert.Equal(t, VolumeGroupSnapshotClassGVK, classes[0].GroupVersionKind())
+	assert.Equal(t, "vxflexos-groupsnapclass", classes[0].GetName())
+	assert.Equal(t, operatorutils.GetOwnerLabels(cr), classes[0].GetLabels())
+	assert.Equal(t, "csi-vxflexos.dellemc.com", classes[0].Object["driver"])
+	assert.Equal(t, "Delete", classes[0].Object["deletionPolicy"])
+	assert.Equal(t, map[string]interface{}{"VolumeGroupNamePrefix": "csi-vg"}, classes[0].Object["parameters"])
+
+	cr.Spec.Modules[0].Enabled = false
+	assert.Empty(t, GetVolumeGroupSnapshotClasses(ctx, cr, "csi-vxflexos.dellemc.com"))
+}
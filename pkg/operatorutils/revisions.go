@@ -0,0 +1,196 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package operatorutils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/logger"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	t1 "k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxRevisionChanges - number of changed fields listed in the changes annotation of a revision
+const maxRevisionChanges = 20
+
+// GetRevisionName - returns the name of the ControllerRevision holding a revision of the CSM
+func GetRevisionName(cr csmv1.ContainerStorageModule, revision int64) string {
+	return fmt.Sprintf("%s-%d", cr.Name, revision)
+}
+
+// ListRevisions - returns the revisions of the CSM, oldest first
+func ListRevisions(ctx context.Context, cr csmv1.ContainerStorageModule, ctrlClient crclient.Client) ([]appsv1.ControllerRevision, error) {
+	list := &appsv1.ControllerRevisionList{}
+	err := ctrlClient.List(ctx, list, crclient.InNamespace(cr.Namespace), crclient.MatchingLabels{csmv1.RevisionLabel: cr.Name})
+	if err != nil {
+		return nil, fmt.Errorf("listing the revisions of %s: %v", cr.Name, err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].Revision < list.Items[j].Revision
+	})
+	return list.Items, nil
+}
+
+// GetRevisionSpec - returns the spec recorded in a revision of the CSM
+func GetRevisionSpec(ctx context.Context, cr csmv1.ContainerStorageModule, revision int64, ctrlClient crclient.Client) (*csmv1.ContainerStorageModuleSpec, error) {
+	found := &appsv1.ControllerRevision{}
+	err := ctrlClient.Get(ctx, t1.NamespacedName{Name: GetRevisionName(cr, revision), Namespace: cr.Namespace}, found)
+	if err != nil {
+		if k8serror.IsNotFound(err) {
+			return nil, fmt.Errorf("revision %d of %s not found", revision, cr.Name)
+		}
+		return nil, err
+	}
+
+	spec := &csmv1.ContainerStorageModuleSpec{}
+	if err := json.Unmarshal(found.Data.Raw, spec); err != nil {
+		return nil, fmt.Errorf("error unmarshalling revision %d of %s: %v", revision, cr.Name, err)
+	}
+	return spec, nil
+}
+
+// RecordRevision - records the applied spec of the CSM in its revision history and sets status.revision.
+// A spec that differs from the latest revision is recorded as a new revision, and the oldest revisions
+// beyond spec.revisionHistoryLimit are deleted
+func RecordRevision(ctx context.Context, cr *csmv1.ContainerStorageModule, operatorVersion string, ctrlClient crclient.Client) error {
+	log := logger.GetLogger(ctx)
+
+	revisions, err := ListRevisions(ctx, *cr, ctrlClient)
+	if err != nil {
+		return err
+	}
+
+	revision := int64(0)
+	limit := cr.GetRevisionHistoryLimit()
+	if limit > 0 {
+		spec := cr.Spec.DeepCopy()
+		spec.RollbackTo = nil
+		data, err := json.Marshal(spec)
+		if err != nil {
+			return fmt.Errorf("error marshalling spec: %v", err)
+		}
+
+		var latest *appsv1.ControllerRevision
+		if len(revisions) > 0 {
+			latest = &revisions[len(revisions)-1]
+		}
+		if latest != nil && bytes.Equal(latest.Data.Raw, data) {
+			revision = latest.Revision
+		} else {
+			created, err := createRevision(ctx, cr, latest, data, operatorVersion, ctrlClient)
+			if err != nil {
+				return err
+			}
+			log.Infow("Recorded revision", "name", created.Name, "revision", created.Revision)
+			revision = created.Revision
+			revisions = append(revisions, *created)
+		}
+	}
+
+	for len(revisions) > limit {
+		if err := ctrlClient.Delete(ctx, &revisions[0]); err != nil && !k8serror.IsNotFound(err) {
+			return fmt.Errorf("deleting revision %s: %v", revisions[0].Name, err)
+		}
+		revisions = revisions[1:]
+	}
+
+	if cr.Status.Revision != revision {
+		cr.Status.Revision = revision
+		if err := UpdateCSMStatus(ctx, cr, ctrlClient); err != nil {
+			return err
+		}
+	}
+	return updateRevisionState(ctx, *cr, ctrlClient)
+}
+
+// createRevision - creates the revision that follows latest with the spec in data
+func createRevision(ctx context.Context, cr *csmv1.ContainerStorageModule, latest *appsv1.ControllerRevision, data []byte, operatorVersion string, ctrlClient crclient.Client) (*appsv1.ControllerRevision, error) {
+	revision := int64(1)
+	changes := "initial revision"
+	if latest != nil {
+		revision = latest.Revision + 1
+		previous := csmv1.ContainerStorageModuleSpec{}
+		if err := json.Unmarshal(latest.Data.Raw, &previous); err != nil {
+			return nil, fmt.Errorf("error unmarshalling revision %d of %s: %v", latest.Revision, cr.Name, err)
+		}
+		diff, err := DiffSpecs(previous, cr.Spec)
+		if err != nil {
+			return nil, err
+		}
+		if len(diff) > maxRevisionChanges {
+			diff = append(diff[:maxRevisionChanges], fmt.Sprintf("and %d more", len(diff)-maxRevisionChanges))
+		}
+		changes = strings.Join(diff, "\n")
+	}
+
+	isController := true
+	created := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetRevisionName(*cr, revision),
+			Namespace: cr.Namespace,
+			Labels:    map[string]string{csmv1.RevisionLabel: cr.Name},
+			Annotations: map[string]string{
+				csmv1.RevisionStateAnnotation:           string(cr.Status.State),
+				csmv1.RevisionOperatorVersionAnnotation: operatorVersion,
+				csmv1.RevisionChangesAnnotation:         changes,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: csmv1.GroupVersion.String(),
+				Kind:       "ContainerStorageModule",
+				Name:       cr.Name,
+				UID:        cr.GetUID(),
+				Controller: &isController,
+			}},
+		},
+		Data:     runtime.RawExtension{Raw: data},
+		Revision: revision,
+	}
+	if err := ctrlClient.Create(ctx, created); err != nil {
+		return nil, fmt.Errorf("creating revision %s: %v", created.Name, err)
+	}
+	return created, nil
+}
+
+// updateRevisionState - records the state of the CSM on the revision in status.revision
+func updateRevisionState(ctx context.Context, cr csmv1.ContainerStorageModule, ctrlClient crclient.Client) error {
+	if cr.Status.Revision == 0 || cr.Status.State == "" {
+		return nil
+	}
+
+	found := &appsv1.ControllerRevision{}
+	err := ctrlClient.Get(ctx, t1.NamespacedName{Name: GetRevisionName(cr, cr.Status.Revision), Namespace: cr.Namespace}, found)
+	if err != nil {
+		if k8serror.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if found.Annotations[csmv1.RevisionStateAnnotation] == string(cr.Status.State) {
+		return nil
+	}
+	if found.Annotations == nil {
+		found.Annotations = map[string]string{}
+	}
+	found.Annotations[csmv1.RevisionStateAnnotation] = string(cr.Status.State)
+	return ctrlClient.Update(ctx, found)
+}
@@ -0,0 +1,103 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package operatorutils
+
+import (
+	"context"
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newRevisionClient(t *testing.T, cr *csmv1.ContainerStorageModule) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, csmv1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(cr).WithStatusSubresource(cr).Build()
+}
+
+func TestRecordRevision(t *testing.T) {
+	ctx := context.Background()
+	cr := &csmv1.ContainerStorageModule{
+		ObjectMeta: metav1.ObjectMeta{Name: "powerstore", Namespace: "powerstore", UID: "csm-uid"},
+		Spec:       csmv1.ContainerStorageModuleSpec{Driver: csmv1.Driver{CSIDriverType: csmv1.PowerStore, Replicas: 2}},
+		Status:     csmv1.ContainerStorageModuleStatus{State: constants.Succeeded},
+	}
+	ctrlClient := newRevisionClient(t, cr)
+
+	// the first applied spec is revision 1
+	require.NoError(t, RecordRevision(ctx, cr, "v1.12.0", ctrlClient))
+	assert.Equal(t, int64(1), cr.Status.Revision)
+	revisions, err := ListRevisions(ctx, *cr, ctrlClient)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, "powerstore-1", revisions[0].Name)
+	assert.Equal(t, "v1.12.0", revisions[0].Annotations[csmv1.RevisionOperatorVersionAnnotation])
+	assert.Equal(t, string(constants.Succeeded), revisions[0].Annotations[csmv1.RevisionStateAnnotation])
+	assert.Equal(t, "initial revision", revisions[0].Annotations[csmv1.RevisionChangesAnnotation])
+	assert.Equal(t, "csm-uid", string(revisions[0].OwnerReferences[0].UID))
+
+	// the same spec only updates the state of the revision
+	cr.Status.State = constants.Failed
+	require.NoError(t, RecordRevision(ctx, cr, "v1.12.0", ctrlClient))
+	revisions, err = ListRevisions(ctx, *cr, ctrlClient)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, string(constants.Failed), revisions[0].Annotations[csmv1.RevisionStateAnnotation])
+
+	// a changed spec is a new revision listing the changes
+	cr.Spec.Driver.Replicas = 1
+	require.NoError(t, RecordRevision(ctx, cr, "v1.12.0", ctrlClient))
+	assert.Equal(t, int64(2), cr.Status.Revision)
+	revisions, err = ListRevisions(ctx, *cr, ctrlClient)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, "spec.driver.replicas: 2 -> 1", revisions[1].Annotations[csmv1.RevisionChangesAnnotation])
+
+	spec, err := GetRevisionSpec(ctx, *cr, 1, ctrlClient)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), spec.Driver.Replicas)
+
+	// the oldest revisions beyond the limit are deleted
+	limit := int32(2)
+	cr.Spec.RevisionHistoryLimit = &limit
+	cr.Spec.Driver.Replicas = 3
+	require.NoError(t, RecordRevision(ctx, cr, "v1.13.0", ctrlClient))
+	assert.Equal(t, int64(3), cr.Status.Revision)
+	revisions, err = ListRevisions(ctx, *cr, ctrlClient)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, []int64{2, 3}, []int64{revisions[0].Revision, revisions[1].Revision})
+	_, err = GetRevisionSpec(ctx, *cr, 1, ctrlClient)
+	assert.ErrorContains(t, err, "revision 1 of powerstore not found")
+
+	// no history is kept with a limit of 0
+	limit = 0
+	require.NoError(t, RecordRevision(ctx, cr, "v1.13.0", ctrlClient))
+	assert.Equal(t, int64(0), cr.Status.Revision)
+	revisions, err = ListRevisions(ctx, *cr, ctrlClient)
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	found := &csmv1.ContainerStorageModule{}
+	require.NoError(t, ctrlClient.Get(ctx, client.ObjectKeyFromObject(cr), found))
+	assert.Equal(t, int64(0), found.Status.Revision)
+}
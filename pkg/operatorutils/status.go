@@ -28,8 +28,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-
-	// metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	t1 "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -38,15 +38,65 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-var dMutex sync.RWMutex
+// csmLock - serializes the status updates of a CSM, refs counts the updates holding or waiting for it
+type csmLock struct {
+	sync.Mutex
+	refs int
+}
+
+var (
+	// csmLocks - holds a csmLock per CSM while it is used, so that the status updates of a CSM are serialized without
+	// blocking the other CSMs
+	csmLocks = map[t1.NamespacedName]*csmLock{}
+	// csmLocksMutex - guards csmLocks and the refs of its locks
+	csmLocksMutex sync.Mutex
+)
+
+// lockCSM - locks the status updates of the CSM and returns the function that unlocks them. The lock is dropped once
+// no update holds it or waits for it, so that the locks of deleted CSMs are not kept
+func lockCSM(instance *csmv1.ContainerStorageModule) func() {
+	name := t1.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+	csmLocksMutex.Lock()
+	lock, ok := csmLocks[name]
+	if !ok {
+		lock = &csmLock{}
+		csmLocks[name] = lock
+	}
+	lock.refs++
+	csmLocksMutex.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		csmLocksMutex.Lock()
+		defer csmLocksMutex.Unlock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(csmLocks, name)
+		}
+	}
+}
 
-var checkModuleStatus = map[csmv1.ModuleType]func(context.Context, *csmv1.ContainerStorageModule, ReconcileCSM, *csmv1.ContainerStorageModuleStatus, OperatorConfig) (bool, error){
+// reverseProxyDeployment - name of the reverseproxy deployment when it is not deployed as a sidecar
+const reverseProxyDeployment = "csipowermax-reverseproxy"
+
+// ErrUpgradeBlocked - wrapped by precheck errors caused by an unsupported upgrade path
+var ErrUpgradeBlocked = errors.New("failed upgrade check")
+
+// checkModuleStatus - status checks for modules that only run their own deployments; other modules run as sidecars of
+// the driver pods and are checked in sidecarModuleStatusCheck
+var checkModuleStatus = map[csmv1.ModuleType]func(context.Context, *csmv1.ContainerStorageModule, ReconcileCSM, *csmv1.ModuleStatus, OperatorConfig) (bool, error){
 	csmv1.Observability:       observabilityStatusCheck,
 	csmv1.AuthorizationServer: authProxyStatusCheck,
 }
 
 // calculates deployment state of drivers only; module deployment status will be checked in checkModuleStatus
 func getDeploymentStatus(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM) (csmv1.PodStatus, error) {
+	return getClusterDeploymentStatus(ctx, instance, GetCluster(ctx, r))
+}
+
+// getClusterDeploymentStatus - calculates the state of the driver deployment on the cluster
+func getClusterDeploymentStatus(ctx context.Context, instance *csmv1.ContainerStorageModule, clusterClient ClusterConfig) (csmv1.PodStatus, error) {
 	log := logger.GetLogger(ctx)
 	var msg string
 	deployment := &appsv1.Deployment{}
@@ -61,11 +111,6 @@ func getDeploymentStatus(ctx context.Context, instance *csmv1.ContainerStorageMo
 		Failed:    "0",
 	}
 
-	clusterClient := GetCluster(ctx, r)
-	if err != nil {
-		return emptyStatus, err
-	}
-
 	log.Infof("getting deployment status for cluster: %s", clusterClient.ClusterID)
 	msg += fmt.Sprintf("error message for %s \n", clusterClient.ClusterID)
 
@@ -100,6 +145,11 @@ func getDeploymentStatus(ctx context.Context, instance *csmv1.ContainerStorageMo
 }
 
 func getDaemonSetStatus(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM) (int32, csmv1.PodStatus, error) {
+	return getClusterDaemonSetStatus(ctx, instance, GetCluster(ctx, r))
+}
+
+// getClusterDaemonSetStatus - calculates the state of the driver daemonset on the cluster
+func getClusterDaemonSetStatus(ctx context.Context, instance *csmv1.ContainerStorageModule, clusterClient ClusterConfig) (int32, csmv1.PodStatus, error) {
 	log := logger.GetLogger(ctx)
 
 	var msg string
@@ -109,7 +159,6 @@ func getDaemonSetStatus(ctx context.Context, instance *csmv1.ContainerStorageMod
 	totalFailedCount := 0
 	totalRunning := int32(0)
 
-	clusterClient := GetCluster(ctx, r)
 	totalRunning = 0
 	log.Infof("\ngetting daemonset status for cluster: %s", clusterClient.ClusterID)
 	msg += fmt.Sprintf("error message for %s \n", clusterClient.ClusterID)
@@ -197,25 +246,16 @@ func getDaemonSetStatus(ctx context.Context, instance *csmv1.ContainerStorageMod
 	}, err
 }
 
-func calculateState(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, newStatus *csmv1.ContainerStorageModuleStatus, op OperatorConfig, deploymentStatusOverride ...csmv1.PodStatus) (bool, error) {
+func calculateState(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, newStatus *csmv1.ContainerStorageModuleStatus, op OperatorConfig) (bool, error) {
 	log := logger.GetLogger(ctx)
 	running := true
 	var err error
 	nodeStatusGood := true
 	newStatus.State = constants.Succeeded
 
-	var controllerStatus csmv1.PodStatus
-	if len(deploymentStatusOverride) > 0 {
-		// Use the deployment status from the informer event to avoid stale cache reads
-		controllerStatus = deploymentStatusOverride[0]
-		log.Infof("using deployment status override: desired=%s, available=%s, failed=%s",
-			controllerStatus.Desired, controllerStatus.Available, controllerStatus.Failed)
-	} else {
-		var controllerErr error
-		controllerStatus, controllerErr = getDeploymentStatus(ctx, instance, r)
-		if controllerErr != nil {
-			log.Infof("error from getDeploymentStatus: %s", controllerErr.Error())
-		}
+	controllerStatus, controllerErr := getDeploymentStatus(ctx, instance, r)
+	if controllerErr != nil {
+		log.Infof("error from getDeploymentStatus: %s", controllerErr.Error())
 	}
 
 	// Auth proxy and Cosi driver have no daemonset. Putting this if/else in here and setting nodeStatusGood to true by
@@ -239,23 +279,18 @@ func calculateState(ctx context.Context, instance *csmv1.ContainerStorageModule,
 	log.Infof("deployment controllerStatus.Desired [%s]", controllerStatus.Desired)
 	log.Infof("deployment controllerStatus.Available [%s]", controllerStatus.Available)
 
-	if (controllerStatus.Desired == controllerStatus.Available) && nodeStatusGood {
-		for _, module := range instance.Spec.Modules {
-			moduleStatus, exists := checkModuleStatus[module.Name]
-			if exists && module.Enabled {
-				moduleRunning, err := moduleStatus(ctx, instance, r, newStatus, op)
-				if err != nil {
-					log.Infof("status for module err msg [%s]", err.Error())
-				}
+	failedModule, moduleErr := calculateModuleStatus(ctx, instance, r, newStatus, op)
 
-				if !moduleRunning {
-					running = false
-					newStatus.State = constants.Failed
-					log.Infof("%s module not running", module.Name)
-					break
-				}
-				log.Infof("%s module running", module.Name)
+	if (controllerStatus.Desired == controllerStatus.Available) && nodeStatusGood {
+		if failedModule != "" {
+			// a module that is not running is reported in its own status entry and in the Degraded condition;
+			// the driver state stays Succeeded unless the module is the whole installation
+			running = false
+			if isAuthorizationProxyServer(instance) {
+				newStatus.State = constants.Failed
 			}
+			log.Infof("%s module not running", failedModule)
+			setModuleNotReadyConditions(instance, failedModule, moduleErr)
 		}
 	} else {
 		log.Infof("deployment or daemonset did not have enough available pods")
@@ -264,7 +299,33 @@ func calculateState(ctx context.Context, instance *csmv1.ContainerStorageModule,
 		log.Infof("daemonset healthy: [%v]", nodeStatusGood)
 		running = false
 		newStatus.State = constants.Failed
+		setPodsNotReadyConditions(instance, controllerStatus, newStatus.NodeStatus, err)
+	}
+
+	// the driver on the target clusters is part of the installation
+	if len(instance.Spec.TargetClusters) > 0 && instance.GetName() != "" && !isAuthorizationProxyServer(instance) {
+		newStatus.Clusters = calculateClusterStatuses(ctx, instance, r, newStatus)
+		for _, clusterStatus := range newStatus.Clusters {
+			if clusterStatus.State != constants.Succeeded && clusterStatus.ClusterID != DefaultSourceClusterID {
+				log.Infof("driver not running on cluster %s", clusterStatus.ClusterID)
+				newStatus.State = constants.Failed
+				if running {
+					setClusterNotReadyConditions(instance, clusterStatus)
+				}
+				running = false
+				break
+			}
+		}
+	} else {
+		newStatus.Clusters = nil
+	}
+
+	if running {
+		SetCondition(instance, csmv1.ConditionReady, metav1.ConditionTrue, csmv1.ReasonAllComponentsAvailable, "all driver and module pods are available")
+		SetCondition(instance, csmv1.ConditionProgressing, metav1.ConditionFalse, csmv1.ReasonAllComponentsAvailable, "")
+		SetCondition(instance, csmv1.ConditionDegraded, metav1.ConditionFalse, csmv1.ReasonAllComponentsAvailable, "")
 	}
+	instance.Status.ObservedGeneration = instance.Generation
 
 	log.Infof("setting new status to [%v]", newStatus)
 	SetStatus(ctx, r, instance, newStatus)
@@ -282,6 +343,277 @@ func calculateState(ctx context.Context, instance *csmv1.ContainerStorageModule,
 	return running, err
 }
 
+// calculateClusterStatuses - returns the status of the driver on the local cluster, followed by each target cluster
+func calculateClusterStatuses(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, newStatus *csmv1.ContainerStorageModuleStatus) []csmv1.ClusterStatus {
+	log := logger.GetLogger(ctx)
+	statuses := []csmv1.ClusterStatus{{
+		ClusterID:        DefaultSourceClusterID,
+		State:            newStatus.State,
+		ControllerStatus: newStatus.ControllerStatus,
+		NodeStatus:       newStatus.NodeStatus,
+	}}
+
+	for _, clusterID := range instance.Spec.TargetClusters {
+		status := csmv1.ClusterStatus{ClusterID: clusterID, State: constants.Failed}
+		cluster, err := GetRemoteClusterWrapper(ctx, clusterID, r.GetClient())
+		if err != nil {
+			status.LastError = err.Error()
+			statuses = append(statuses, status)
+			continue
+		}
+
+		status.ControllerStatus, err = getClusterDeploymentStatus(ctx, instance, cluster)
+		if err != nil {
+			status.LastError = err.Error()
+			statuses = append(statuses, status)
+			continue
+		}
+
+		nodeStatusGood := true
+		if instance.Spec.Driver.CSIDriverType != csmv1.Cosi {
+			expected, nodeStatus, daemonSetErr := getClusterDaemonSetStatus(ctx, instance, cluster)
+			status.NodeStatus = nodeStatus
+			if daemonSetErr != nil {
+				status.LastError = strings.TrimSpace(daemonSetErr.Error())
+			}
+			nodeStatusGood = fmt.Sprintf("%d", expected) == nodeStatus.Available
+		}
+
+		if status.ControllerStatus.Desired == status.ControllerStatus.Available && nodeStatusGood {
+			status.State = constants.Succeeded
+		}
+		log.Infow("cluster status", "clusterID", clusterID, "state", status.State)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// calculateModuleStatus - records the status of each enabled module and returns the first module that is not running
+func calculateModuleStatus(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, newStatus *csmv1.ContainerStorageModuleStatus, op OperatorConfig) (csmv1.ModuleType, error) {
+	log := logger.GetLogger(ctx)
+	var failedModule csmv1.ModuleType
+	var failedErr error
+
+	moduleStatuses := []csmv1.ModuleStatus{}
+	for _, module := range instance.Spec.Modules {
+		if !module.Enabled {
+			continue
+		}
+
+		moduleStatus := csmv1.ModuleStatus{
+			Name:  module.Name,
+			State: constants.Succeeded,
+		}
+		configVersion, err := getModuleConfigVersion(ctx, instance, module, op)
+		if err != nil {
+			log.Infof("config version for module %s err msg [%s]", module.Name, err.Error())
+		}
+		moduleStatus.ConfigVersion = configVersion
+
+		moduleRunning := true
+		if statusCheck, exists := checkModuleStatus[module.Name]; exists {
+			moduleRunning, err = statusCheck(ctx, instance, r, &moduleStatus, op)
+			if err != nil {
+				log.Infof("status for module err msg [%s]", err.Error())
+				moduleStatus.LastError = err.Error()
+			}
+		} else {
+			moduleRunning, err = sidecarModuleStatusCheck(ctx, instance, r, module, newStatus, &moduleStatus)
+			if err != nil {
+				log.Infof("status for module err msg [%s]", err.Error())
+				moduleStatus.LastError = err.Error()
+			}
+		}
+
+		if !moduleRunning {
+			moduleStatus.State = constants.Failed
+			if failedModule == "" {
+				failedModule = module.Name
+				if moduleStatus.LastError != "" {
+					failedErr = errors.New(moduleStatus.LastError)
+				}
+			}
+		} else {
+			log.Infof("%s module running", module.Name)
+		}
+		moduleStatuses = append(moduleStatuses, moduleStatus)
+	}
+
+	newStatus.Modules = moduleStatuses
+	return failedModule, failedErr
+}
+
+// getModuleConfigVersion - returns the config version of the module, defaulting to the one matching the driver version
+func getModuleConfigVersion(ctx context.Context, instance *csmv1.ContainerStorageModule, module csmv1.Module, op OperatorConfig) (string, error) {
+	if module.ConfigVersion != "" {
+		return module.ConfigVersion, nil
+	}
+
+	version, err := GetVersion(ctx, instance, op)
+	if err != nil {
+		return "", err
+	}
+	if module.Name == csmv1.AuthorizationServer {
+		return version, nil
+	}
+	return GetModuleDefaultVersion(version, instance.Spec.Driver.CSIDriverType, module.Name, op.ConfigDirectory)
+}
+
+// sidecarModuleStatusCheck - calculates the state of a module running as sidecars of the driver pods. Replication also
+// runs its controller manager, resiliency also runs in the node pods and a reverseproxy not deployed as a sidecar only
+// runs its own deployment.
+func sidecarModuleStatusCheck(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, module csmv1.Module, driverStatus *csmv1.ContainerStorageModuleStatus, moduleStatus *csmv1.ModuleStatus) (bool, error) {
+	counts := &moduleDeploymentCounts{}
+	switch module.Name {
+	case csmv1.ReverseProxy:
+		if !isReverseProxySidecar(module) {
+			err := counts.addDeployment(ctx, r.GetClient(), reverseProxyDeployment, instance.GetNamespace())
+			return counts.setModuleStatus(moduleStatus), err
+		}
+	case csmv1.Replication:
+		if err := counts.addDeployment(ctx, r.GetClient(), ReplicationControllerManager, ReplicationControllerNameSpace); err != nil {
+			return false, err
+		}
+	case csmv1.Resiliency:
+		counts.addPods(instance.GetNodeName(), driverStatus.NodeStatus)
+	}
+	counts.addPods(instance.GetControllerName(), driverStatus.ControllerStatus)
+	return counts.setModuleStatus(moduleStatus), nil
+}
+
+// isReverseProxySidecar - checks if the reverseproxy runs in the driver controller pods, which is the default
+func isReverseProxySidecar(module csmv1.Module) bool {
+	for _, component := range module.Components {
+		for _, env := range component.Envs {
+			if env.Name == "DeployAsSidecar" {
+				deployAsSidecar, err := strconv.ParseBool(env.Value)
+				return err != nil || deployAsSidecar
+			}
+		}
+	}
+	return true
+}
+
+// moduleDeploymentCounts - accumulates the pod counts of the deployments of a module
+type moduleDeploymentCounts struct {
+	desired      int32
+	available    int32
+	notReady     []string
+	notReadyPods []string
+}
+
+// addDeployment - reads the deployment and adds it to the counts, a missing deployment is not ready
+func (c *moduleDeploymentCounts) addDeployment(ctx context.Context, ctrlClient client.Client, name, namespace string) error {
+	deployment := &appsv1.Deployment{}
+	err := ctrlClient.Get(ctx, t1.NamespacedName{Name: name, Namespace: namespace}, deployment)
+	if k8serrors.IsNotFound(err) {
+		c.notReady = append(c.notReady, name)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	c.add(deployment)
+	return nil
+}
+
+// addPods - adds the pod counts of a driver deployment or daemonset running sidecars of the module
+func (c *moduleDeploymentCounts) addPods(name string, status csmv1.PodStatus) {
+	desired, _ := strconv.Atoi(status.Desired)
+	available, _ := strconv.Atoi(status.Available)
+	// #nosec G115
+	c.desired += int32(desired)
+	// #nosec G115
+	c.available += int32(available)
+	if desired != available {
+		c.notReadyPods = append(c.notReadyPods, name)
+	}
+}
+
+// add - adds the deployment to the counts and returns true if all its replicas are ready
+func (c *moduleDeploymentCounts) add(deployment *appsv1.Deployment) bool {
+	c.desired += *deployment.Spec.Replicas
+	c.available += deployment.Status.ReadyReplicas
+	if deployment.Status.ReadyReplicas != *deployment.Spec.Replicas {
+		c.notReady = append(c.notReady, deployment.Name)
+		return false
+	}
+	return true
+}
+
+// setModuleStatus - copies the counts into the module status and returns true if all deployments are ready
+func (c *moduleDeploymentCounts) setModuleStatus(moduleStatus *csmv1.ModuleStatus) bool {
+	if moduleStatus != nil {
+		moduleStatus.Desired = fmt.Sprintf("%d", c.desired)
+		moduleStatus.Available = fmt.Sprintf("%d", c.available)
+		var lastErrors []string
+		if len(c.notReady) > 0 {
+			lastErrors = append(lastErrors, fmt.Sprintf("deployments not ready: %s", strings.Join(c.notReady, ", ")))
+		}
+		if len(c.notReadyPods) > 0 {
+			lastErrors = append(lastErrors, fmt.Sprintf("driver pods not ready: %s", strings.Join(c.notReadyPods, ", ")))
+		}
+		moduleStatus.LastError = strings.Join(lastErrors, "; ")
+	}
+	return len(c.notReady) == 0 && len(c.notReadyPods) == 0
+}
+
+// SetCondition - sets a condition on the csm status for the current generation
+func SetCondition(instance *csmv1.ContainerStorageModule, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: instance.Generation,
+	})
+}
+
+// setPodsNotReadyConditions - reports pods that are still starting as progressing and pods that fail to start as degraded
+func setPodsNotReadyConditions(instance *csmv1.ContainerStorageModule, controllerStatus, nodeStatus csmv1.PodStatus, podErr error) {
+	message := fmt.Sprintf("controller pods available %s/%s", controllerStatus.Available, controllerStatus.Desired)
+	if nodeStatus.Desired != "" {
+		message += fmt.Sprintf(", node pods available %s/%s", nodeStatus.Available, nodeStatus.Desired)
+	}
+
+	// pods waiting in ContainerCreating are reported by getDaemonSetStatus but are not failing
+	failing := podErr != nil && strings.Contains(strings.ReplaceAll(podErr.Error(), constants.ContainerCreating+"="+constants.PendingCreate, ""), "=")
+	if failing {
+		message += ": " + strings.TrimSpace(podErr.Error())
+		SetCondition(instance, csmv1.ConditionReady, metav1.ConditionFalse, csmv1.ReasonPodsFailing, message)
+		SetCondition(instance, csmv1.ConditionProgressing, metav1.ConditionFalse, csmv1.ReasonPodsFailing, message)
+		SetCondition(instance, csmv1.ConditionDegraded, metav1.ConditionTrue, csmv1.ReasonPodsFailing, message)
+		return
+	}
+	SetCondition(instance, csmv1.ConditionReady, metav1.ConditionFalse, csmv1.ReasonPodsStarting, message)
+	SetCondition(instance, csmv1.ConditionProgressing, metav1.ConditionTrue, csmv1.ReasonPodsStarting, message)
+	SetCondition(instance, csmv1.ConditionDegraded, metav1.ConditionFalse, csmv1.ReasonPodsStarting, "")
+}
+
+// setClusterNotReadyConditions - reports a target cluster where the driver is not running as degraded
+func setClusterNotReadyConditions(instance *csmv1.ContainerStorageModule, clusterStatus csmv1.ClusterStatus) {
+	message := fmt.Sprintf("driver not running on cluster %s: controller pods available %s/%s", clusterStatus.ClusterID, clusterStatus.ControllerStatus.Available, clusterStatus.ControllerStatus.Desired)
+	if clusterStatus.NodeStatus.Desired != "" {
+		message += fmt.Sprintf(", node pods available %s/%s", clusterStatus.NodeStatus.Available, clusterStatus.NodeStatus.Desired)
+	}
+	if clusterStatus.LastError != "" {
+		message += ": " + clusterStatus.LastError
+	}
+	SetCondition(instance, csmv1.ConditionReady, metav1.ConditionFalse, csmv1.ReasonClusterNotReady, message)
+	SetCondition(instance, csmv1.ConditionProgressing, metav1.ConditionFalse, csmv1.ReasonClusterNotReady, message)
+	SetCondition(instance, csmv1.ConditionDegraded, metav1.ConditionTrue, csmv1.ReasonClusterNotReady, message)
+}
+
+// setModuleNotReadyConditions - reports a module that is not running as degraded
+func setModuleNotReadyConditions(instance *csmv1.ContainerStorageModule, moduleName csmv1.ModuleType, moduleErr error) {
+	message := fmt.Sprintf("%s module not running", moduleName)
+	if moduleErr != nil {
+		message += ": " + moduleErr.Error()
+	}
+	SetCondition(instance, csmv1.ConditionReady, metav1.ConditionFalse, csmv1.ReasonModuleNotReady, message)
+	SetCondition(instance, csmv1.ConditionProgressing, metav1.ConditionFalse, csmv1.ReasonModuleNotReady, message)
+	SetCondition(instance, csmv1.ConditionDegraded, metav1.ConditionTrue, csmv1.ReasonModuleNotReady, message)
+}
+
 // SetStatus of csm
 func SetStatus(ctx context.Context, _ ReconcileCSM, instance *csmv1.ContainerStorageModule, newStatus *csmv1.ContainerStorageModuleStatus) {
 	log := logger.GetLogger(ctx)
@@ -290,12 +622,23 @@ func SetStatus(ctx context.Context, _ ReconcileCSM, instance *csmv1.ContainerSto
 		newStatus.ControllerStatus, "Node", newStatus.NodeStatus)
 	instance.GetCSMStatus().ControllerStatus = newStatus.ControllerStatus
 	instance.GetCSMStatus().NodeStatus = newStatus.NodeStatus
+	instance.GetCSMStatus().Modules = newStatus.Modules
+	instance.GetCSMStatus().Clusters = newStatus.Clusters
+}
+
+// UpdateCSMStatus - writes the status of the CSM, keeping its in-memory spec which can hold defaults that are not stored
+func UpdateCSMStatus(ctx context.Context, cr *csmv1.ContainerStorageModule, ctrlClient client.Client) error {
+	updated := cr.DeepCopy()
+	if err := ctrlClient.Status().Update(ctx, updated); err != nil {
+		return err
+	}
+	cr.ResourceVersion = updated.ResourceVersion
+	return nil
 }
 
 // UpdateStatus of csm
-func UpdateStatus(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, newStatus *csmv1.ContainerStorageModuleStatus, op OperatorConfig, deploymentStatusOverride ...csmv1.PodStatus) error {
-	dMutex.Lock()
-	defer dMutex.Unlock()
+func UpdateStatus(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, newStatus *csmv1.ContainerStorageModuleStatus, op OperatorConfig) error {
+	defer lockCSM(instance)()
 
 	log := logger.GetLogger(ctx)
 	log.Infow("update current csm status", "status", instance.Status.State)
@@ -305,12 +648,13 @@ func UpdateStatus(ctx context.Context, instance *csmv1.ContainerStorageModule, r
 	log.Infow("Update State", "Controller",
 		newStatus.ControllerStatus, "Node", newStatus.NodeStatus)
 
-	running, merr := calculateState(ctx, instance, r, newStatus, op, deploymentStatusOverride...)
+	running, merr := calculateState(ctx, instance, r, newStatus, op)
 
 	// Add last successful configuration into status if deployment is running
-	// and controller has desired replicas to handle the last successful configuration change
+	// and controller has desired replicas to handle the last successful configuration change.
+	// A rolled back spec is running the last successful configuration already
 	replicas := instance.Spec.Driver.Replicas
-	if newStatus.ControllerStatus.Desired == strconv.Itoa(int(replicas)) && running {
+	if newStatus.ControllerStatus.Desired == strconv.Itoa(int(replicas)) && running && !instance.IsRolledBack() {
 		if lastAnnotations := instance.GetAnnotations(); lastAnnotations != nil {
 			if lastApplied := lastAnnotations["storage.dell.com/PreviouslyAppliedConfiguration"]; lastApplied != "" {
 				instance.Status.LastSuccessfulConfiguration = lastApplied
@@ -347,6 +691,9 @@ func UpdateStatus(ctx context.Context, instance *csmv1.ContainerStorageModule, r
 	}
 
 	log.Info("Update done")
+	if err := updateRevisionState(ctx, *instance, r.GetClient()); err != nil {
+		log.Warnw("Failed to record the state on the revision", "revision", instance.Status.Revision, "error", err.Error())
+	}
 	// if CSM is not running, we want to requeue
 	if !running {
 		return fmt.Errorf("calculateState returned CSM not running")
@@ -359,13 +706,19 @@ func UpdateStatus(ctx context.Context, instance *csmv1.ContainerStorageModule, r
 func HandleValidationError(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM,
 	validationError error,
 ) (reconcile.Result, error) {
-	dMutex.Lock()
-	defer dMutex.Unlock()
+	defer lockCSM(instance)()
 	log := logger.GetLogger(ctx)
 
 	newStatus := instance.GetCSMStatus()
 	// Update the status
 	newStatus.State = constants.Failed
+	newStatus.ObservedGeneration = instance.Generation
+	SetCondition(instance, csmv1.ConditionPrecheckPassed, metav1.ConditionFalse, csmv1.ReasonPrecheckFailed, validationError.Error())
+	if errors.Is(validationError, ErrUpgradeBlocked) {
+		SetCondition(instance, csmv1.ConditionUpgradeBlocked, metav1.ConditionTrue, csmv1.ReasonUpgradePathInvalid, validationError.Error())
+	}
+	SetCondition(instance, csmv1.ConditionReady, metav1.ConditionFalse, csmv1.ReasonPrecheckFailed, validationError.Error())
+	SetCondition(instance, csmv1.ConditionProgressing, metav1.ConditionFalse, csmv1.ReasonPrecheckFailed, validationError.Error())
 	err := r.GetClient().Status().Update(ctx, instance)
 	if err != nil {
 		log.Error(err, "Failed to update CR status HandleValidationError")
@@ -378,8 +731,7 @@ func HandleValidationError(ctx context.Context, instance *csmv1.ContainerStorage
 
 // HandleSuccess for csm
 func HandleSuccess(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, newStatus, oldStatus *csmv1.ContainerStorageModuleStatus, op OperatorConfig) reconcile.Result {
-	dMutex.Lock()
-	defer dMutex.Unlock()
+	defer lockCSM(instance)()
 
 	log := logger.GetLogger(ctx)
 
@@ -494,7 +846,7 @@ func WaitForGatewayController(ctx context.Context, instance csmv1.ContainerStora
 }
 
 // observabilityStatusCheck - calculate success state for observability module
-func observabilityStatusCheck(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, _ *csmv1.ContainerStorageModuleStatus, op OperatorConfig) (bool, error) {
+func observabilityStatusCheck(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, moduleStatus *csmv1.ModuleStatus, op OperatorConfig) (bool, error) {
 	log := logger.GetLogger(ctx)
 	topologyEnabled := false
 	otelEnabled := false
@@ -548,32 +900,27 @@ func observabilityStatusCheck(ctx context.Context, instance *csmv1.ContainerStor
 		return false, err
 	}
 
-	checkFn := func(deployment *appsv1.Deployment) bool {
-		return deployment.Status.ReadyReplicas == *deployment.Spec.Replicas
-	}
+	counts := &moduleDeploymentCounts{}
 
 	for _, deployment := range deploymentList.Items {
 		deployment := deployment
 		switch deployment.Name {
 		case "otel-collector":
 			if otelEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in observability deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		case fmt.Sprintf("karavi-metrics-%s", driverName):
 			if metricsEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in observability deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		case "karavi-topology":
 			if topologyEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in observability deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		}
@@ -594,33 +941,30 @@ func observabilityStatusCheck(ctx context.Context, instance *csmv1.ContainerStor
 		switch deployment.Name {
 		case "cert-manager":
 			if certEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in observability deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		case "cert-manager-cainjector":
 			if certEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in observability deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		case "cert-manager-webhook":
 			if certEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in observability deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		}
 	}
 
-	return true, nil
+	return counts.setModuleStatus(moduleStatus), nil
 }
 
 // authProxyStatusCheck - calculate success state for auth proxy
-func authProxyStatusCheck(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, _ *csmv1.ContainerStorageModuleStatus, _ OperatorConfig) (bool, error) {
+func authProxyStatusCheck(ctx context.Context, instance *csmv1.ContainerStorageModule, r ReconcileCSM, moduleStatus *csmv1.ModuleStatus, _ OperatorConfig) (bool, error) {
 	log := logger.GetLogger(ctx)
 	certEnabled := false
 	nginxEnabled := false
@@ -669,87 +1013,76 @@ func authProxyStatusCheck(ctx context.Context, instance *csmv1.ContainerStorageM
 		return false, err
 	}
 
-	checkFn := func(deployment *appsv1.Deployment) bool {
-		return deployment.Status.ReadyReplicas == *deployment.Spec.Replicas
-	}
+	counts := &moduleDeploymentCounts{}
 
 	for _, deployment := range deploymentList.Items {
 		deployment := deployment
 		switch deployment.Name {
 		case fmt.Sprintf("%s-ingress-nginx-controller", authNamespace):
 			if nginxEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		case fmt.Sprintf("%s-nginx-gateway-controller", authNamespace):
 			if gatewayEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		case "cert-manager":
 			if certEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		case "cert-manager-cainjector":
 			if certEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		case "cert-manager-webhook":
 			if certEnabled {
-				if !checkFn(&deployment) {
+				if !counts.add(&deployment) {
 					log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-					return false, nil
 				}
 			}
 		case "proxy-server":
-			if !checkFn(&deployment) {
+			if !counts.add(&deployment) {
 				log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-				return false, nil
 			}
 		case "redis-commander":
-			if !checkFn(&deployment) {
+			if !counts.add(&deployment) {
 				log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-				return false, nil
 			}
 		case "redis-primary":
-			if !checkFn(&deployment) {
+			if !counts.add(&deployment) {
 				log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-				return false, nil
 			}
 		case "role-service":
-			if !checkFn(&deployment) {
+			if !counts.add(&deployment) {
 				log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-				return false, nil
 			}
 		case "storage-service":
-			if !checkFn(&deployment) {
+			if !counts.add(&deployment) {
 				log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-				return false, nil
 			}
 		case "tenant-service":
-			if !checkFn(&deployment) {
+			if !counts.add(&deployment) {
 				log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-				return false, nil
 			}
 		case "authorization-controller":
-			if !checkFn(&deployment) {
+			if !counts.add(&deployment) {
 				log.Infof("%s component not running in auth proxy deployment", deployment.Name)
-				return false, nil
 			}
 		}
 
 	}
 
+	if !counts.setModuleStatus(moduleStatus) {
+		return false, nil
+	}
 	log.Info("auth proxy deployment successful")
 
 	return true, nil
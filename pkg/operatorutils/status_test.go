@@ -29,7 +29,9 @@ import (
 	"github.com/dell/csm-operator/pkg/constants"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrlClientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -184,11 +186,11 @@ func TestGetDaemonSetStatus(t *testing.T) {
 			name: "Test getDaemonSetStatus when GetCluster fails",
 			args: args{
 				ctx: context.Background(),
-				instance: createCSM("powerflex", "powerflex", csmv1.PowerFlex, csmv1.Replication, true, []csmv1.ContainerTemplate{
-					{
+				instance: createCSM("powerflex", "powerflex", csmv1.PowerFlex, csmv1.Replication, true, []csmv1.PodTemplate{
+					{ContainerTemplate: csmv1.ContainerTemplate{
 						Name: "dell-replication-controller-manager",
 						Envs: []corev1.EnvVar{{Name: "REPLICATION_CTRL_LOG_LEVEL", Value: "debug"}},
-					},
+					}},
 				}),
 				r: &FakeReconcileCSM{
 					Client:    ctrlClientFake.NewClientBuilder().Build(),
@@ -754,23 +756,23 @@ func TestObservabilityStatusCheck(t *testing.T) {
 				{
 					Name:    csmv1.Observability,
 					Enabled: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "topology",
 							Enabled: &[]bool{true}[0],
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "cert-manager",
 							Enabled: &[]bool{true}[0],
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "otel-collector",
 							Enabled: &[]bool{true}[0],
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "metrics-powerflex",
 							Enabled: &[]bool{true}[0],
-						},
+						}},
 					},
 				},
 			},
@@ -890,6 +892,166 @@ func TestObservabilityStatusCheck(t *testing.T) {
 	assert.Equal(t, true, status)
 }
 
+func TestCalculateModuleStatus(t *testing.T) {
+	ctx := context.Background()
+	ctrlClient := fullFakeClient()
+	i32One := int32(1)
+
+	csm := createCSM("test-name", "test-namespace", csmv1.PowerFlex, csmv1.Observability, true, []csmv1.PodTemplate{
+		{ContainerTemplate: csmv1.ContainerTemplate{
+			Name:    "otel-collector",
+			Enabled: &[]bool{true}[0],
+		}},
+		{ContainerTemplate: csmv1.ContainerTemplate{
+			Name:    "metrics-powerflex",
+			Enabled: &[]bool{true}[0],
+		}},
+	})
+	csm.Spec.Driver.ConfigVersion = "v2.15.0"
+	csm.Spec.Modules = append(csm.Spec.Modules,
+		csmv1.Module{Name: csmv1.Replication, Enabled: true, ConfigVersion: "v1.13.0"},
+		csmv1.Module{Name: csmv1.Resiliency, Enabled: false})
+
+	for _, deployment := range []*appsv1.Deployment{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "otel-collector", Namespace: "test-namespace"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &i32One},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 0},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "karavi-metrics-powerflex", Namespace: "test-namespace"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &i32One},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: ReplicationControllerManager, Namespace: ReplicationControllerNameSpace},
+			Spec:       appsv1.DeploymentSpec{Replicas: &i32One},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+	} {
+		err := ctrlClient.Create(ctx, deployment)
+		assert.NoError(t, err, "failed to create client object during test setup")
+	}
+
+	fakeReconcile := FakeReconcileCSM{
+		Client:    ctrlClient,
+		K8sClient: fake.NewSimpleClientset(),
+	}
+	opConfig := OperatorConfig{
+		ConfigDirectory: "../../operatorconfig",
+	}
+
+	newStatus := &csmv1.ContainerStorageModuleStatus{ControllerStatus: csmv1.PodStatus{Available: "2", Desired: "2"}}
+	failedModule, err := calculateModuleStatus(ctx, csm, &fakeReconcile, newStatus, opConfig)
+	assert.Equal(t, csmv1.Observability, failedModule)
+	assert.ErrorContains(t, err, "deployments not ready: otel-collector")
+
+	assert.Equal(t, []csmv1.ModuleStatus{
+		{
+			Name:          csmv1.Observability,
+			State:         constants.Failed,
+			ConfigVersion: "v1.13.0",
+			Desired:       "2",
+			Available:     "1",
+			LastError:     "deployments not ready: otel-collector",
+		},
+		{
+			Name:          csmv1.Replication,
+			State:         constants.Succeeded,
+			ConfigVersion: "v1.13.0",
+			Desired:       "3",
+			Available:     "3",
+		},
+	}, newStatus.Modules)
+}
+
+func TestSidecarModuleStatusCheck(t *testing.T) {
+	ctx := context.Background()
+	i32One := int32(1)
+	ready := csmv1.PodStatus{Available: "2", Desired: "2"}
+	notReady := csmv1.PodStatus{Available: "1", Desired: "2"}
+	standalone := []csmv1.PodTemplate{{ContainerTemplate: csmv1.ContainerTemplate{
+		Name: "csipowermax-reverseproxy",
+		Envs: []corev1.EnvVar{{Name: "DeployAsSidecar", Value: "false"}},
+	}}}
+	newDeployment := func(name, namespace string, readyReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: &i32One},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: readyReplicas},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		module      csmv1.Module
+		objects     []client.Object
+		driver      csmv1.ContainerStorageModuleStatus
+		wantRunning bool
+		wantStatus  csmv1.ModuleStatus
+	}{
+		{
+			name:        "sidecar reverseproxy follows the controller pods",
+			module:      csmv1.Module{Name: csmv1.ReverseProxy},
+			driver:      csmv1.ContainerStorageModuleStatus{ControllerStatus: notReady},
+			wantRunning: false,
+			wantStatus:  csmv1.ModuleStatus{Desired: "2", Available: "1", LastError: "driver pods not ready: powermax-controller"},
+		},
+		{
+			name:        "standalone reverseproxy follows its deployment",
+			module:      csmv1.Module{Name: csmv1.ReverseProxy, Components: standalone},
+			objects:     []client.Object{newDeployment("csipowermax-reverseproxy", "test-namespace", 1)},
+			driver:      csmv1.ContainerStorageModuleStatus{ControllerStatus: notReady},
+			wantRunning: true,
+			wantStatus:  csmv1.ModuleStatus{Desired: "1", Available: "1"},
+		},
+		{
+			name:        "standalone reverseproxy without deployment",
+			module:      csmv1.Module{Name: csmv1.ReverseProxy, Components: standalone},
+			driver:      csmv1.ContainerStorageModuleStatus{ControllerStatus: ready},
+			wantRunning: false,
+			wantStatus:  csmv1.ModuleStatus{Desired: "0", Available: "0", LastError: "deployments not ready: csipowermax-reverseproxy"},
+		},
+		{
+			name:        "replication controller manager not ready",
+			module:      csmv1.Module{Name: csmv1.Replication},
+			objects:     []client.Object{newDeployment(ReplicationControllerManager, ReplicationControllerNameSpace, 0)},
+			driver:      csmv1.ContainerStorageModuleStatus{ControllerStatus: ready},
+			wantRunning: false,
+			wantStatus:  csmv1.ModuleStatus{Desired: "3", Available: "2", LastError: "deployments not ready: dell-replication-controller-manager"},
+		},
+		{
+			name:        "resiliency node pods not ready",
+			module:      csmv1.Module{Name: csmv1.Resiliency},
+			driver:      csmv1.ContainerStorageModuleStatus{ControllerStatus: ready, NodeStatus: notReady},
+			wantRunning: false,
+			wantStatus:  csmv1.ModuleStatus{Desired: "4", Available: "3", LastError: "driver pods not ready: powermax-node"},
+		},
+		{
+			name:        "resiliency running",
+			module:      csmv1.Module{Name: csmv1.Resiliency},
+			driver:      csmv1.ContainerStorageModuleStatus{ControllerStatus: ready, NodeStatus: ready},
+			wantRunning: true,
+			wantStatus:  csmv1.ModuleStatus{Desired: "4", Available: "4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csm := createCSM("powermax", "test-namespace", csmv1.PowerMax, tt.module.Name, true, tt.module.Components)
+			fakeReconcile := FakeReconcileCSM{
+				Client:    ctrlClientFake.NewClientBuilder().WithObjects(tt.objects...).Build(),
+				K8sClient: fake.NewSimpleClientset(),
+			}
+
+			moduleStatus := csmv1.ModuleStatus{}
+			running, err := sidecarModuleStatusCheck(ctx, csm, &fakeReconcile, tt.module, &tt.driver, &moduleStatus)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRunning, running)
+			assert.Equal(t, tt.wantStatus, moduleStatus)
+		})
+	}
+}
+
 func TestObservabilityStatusCheckError(t *testing.T) {
 	// Create a fake context.Context
 	ctx := context.Background()
@@ -914,23 +1076,23 @@ func TestObservabilityStatusCheckError(t *testing.T) {
 				{
 					Name:    csmv1.Observability,
 					Enabled: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "topology",
 							Enabled: &[]bool{true}[0],
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "cert-manager",
 							Enabled: &[]bool{true}[0],
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "otel-collector",
 							Enabled: &[]bool{true}[0],
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "metrics-powermax",
 							Enabled: &[]bool{true}[0],
-						},
+						}},
 					},
 				},
 			},
@@ -1115,15 +1277,15 @@ func TestAuthProxyStatusCheck(t *testing.T) {
 				{
 					Name:    csmv1.AuthorizationServer,
 					Enabled: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "ingress-nginx",
 							Enabled: &[]bool{true}[0],
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "cert-manager",
 							Enabled: &[]bool{true}[0],
-						},
+						}},
 					},
 				},
 			},
@@ -1321,15 +1483,15 @@ func TestAuthProxyStatusCheckError(t *testing.T) {
 				{
 					Name:    csmv1.AuthorizationServer,
 					Enabled: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "ingress-nginx",
 							Enabled: &[]bool{true}[0],
-						},
-						{
+						}},
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "cert-manager",
 							Enabled: &[]bool{true}[0],
-						},
+						}},
 					},
 				},
 			},
@@ -1604,6 +1766,47 @@ func TestSetStatus(t *testing.T) {
 	assert.Equal(t, newStatus, instance.GetCSMStatus())
 }
 
+func TestLockCSM(t *testing.T) {
+	powerflex := createCSM("powerflex", "powerflex", csmv1.PowerFlex, csmv1.Replication, true, nil)
+	powerstore := createCSM("powerstore", "powerstore", csmv1.PowerStore, csmv1.Replication, true, nil)
+	powerflexName := types.NamespacedName{Name: "powerflex", Namespace: "powerflex"}
+	refs := func(name types.NamespacedName) int {
+		csmLocksMutex.Lock()
+		defer csmLocksMutex.Unlock()
+		if lock, ok := csmLocks[name]; ok {
+			return lock.refs
+		}
+		return 0
+	}
+
+	unlock := lockCSM(powerflex)
+	assert.Equal(t, 1, refs(powerflexName))
+
+	// another CSM is not blocked, and its lock is dropped once released
+	lockCSM(powerstore)()
+	assert.Equal(t, 0, refs(types.NamespacedName{Name: "powerstore", Namespace: "powerstore"}))
+
+	// the same CSM waits for the lock, which is kept while it is waited for
+	locked, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		unlockPowerflex := lockCSM(powerflex)
+		close(locked)
+		unlockPowerflex()
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return refs(powerflexName) == 2 }, 10*time.Second, time.Millisecond)
+	select {
+	case <-locked:
+		t.Fatal("the status of powerflex is updated concurrently")
+	default:
+	}
+
+	unlock()
+	<-locked
+	<-done
+	assert.Equal(t, 0, refs(powerflexName))
+}
+
 func TestHandleValidationError(t *testing.T) {
 	type args struct {
 		ctx             context.Context
@@ -1613,10 +1816,11 @@ func TestHandleValidationError(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		args           args
-		expectedResult reconcile.Result
-		wantErr        bool
+		name               string
+		args               args
+		expectedResult     reconcile.Result
+		wantErr            bool
+		wantUpgradeBlocked bool
 	}{
 		{
 			name: "Test HandleValidationError ",
@@ -1649,6 +1853,38 @@ func TestHandleValidationError(t *testing.T) {
 			expectedResult: reconcile.Result{Requeue: false},
 			wantErr:        true,
 		},
+		{
+			name: "Test HandleValidationError upgrade blocked",
+			args: args{
+				ctx:      context.Background(),
+				instance: createCSMWithStatus("powerflex", "powerflex", csmv1.PowerFlex, csmv1.Replication, true, nil, csmv1.ContainerStorageModuleStatus{State: constants.Creating}),
+				r: &FakeReconcileCSM{
+					Client: ctrlClientFake.NewClientBuilder().WithObjects(&corev1.Namespace{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "Namespace",
+							APIVersion: "v1",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "powerflex",
+						},
+					}).WithObjects(&appsv1.DaemonSet{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "DaemonSet",
+							APIVersion: "apps/v1",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "powerflex-node",
+							Namespace: "powerflex",
+						},
+					}).Build(),
+					K8sClient: fake.NewSimpleClientset(),
+				},
+				validationError: fmt.Errorf("%w: upgrade not valid", ErrUpgradeBlocked),
+			},
+			expectedResult:     reconcile.Result{Requeue: false},
+			wantErr:            true,
+			wantUpgradeBlocked: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -1660,10 +1896,142 @@ func TestHandleValidationError(t *testing.T) {
 			}
 			assert.Equal(t, test.expectedResult, result)
 			assert.Equal(t, constants.Failed, test.args.instance.GetCSMStatus().State)
+			assert.True(t, meta.IsStatusConditionFalse(test.args.instance.Status.Conditions, csmv1.ConditionPrecheckPassed))
+			assert.True(t, meta.IsStatusConditionFalse(test.args.instance.Status.Conditions, csmv1.ConditionReady))
+			assert.Equal(t, test.wantUpgradeBlocked, meta.IsStatusConditionTrue(test.args.instance.Status.Conditions, csmv1.ConditionUpgradeBlocked))
 		})
 	}
 }
 
+func TestSetNotReadyConditions(t *testing.T) {
+	tests := []struct {
+		name            string
+		setConditions   func(instance *csmv1.ContainerStorageModule)
+		wantReason      string
+		wantProgressing bool
+		wantDegraded    bool
+		wantMessage     string
+	}{
+		{
+			name: "pods starting",
+			setConditions: func(instance *csmv1.ContainerStorageModule) {
+				setPodsNotReadyConditions(instance, csmv1.PodStatus{Available: "0", Desired: "1"}, csmv1.PodStatus{Available: "0", Desired: "2"},
+					fmt.Errorf("error message for default \n%s=%s", constants.ContainerCreating, constants.PendingCreate))
+			},
+			wantReason:      csmv1.ReasonPodsStarting,
+			wantProgressing: true,
+			wantMessage:     "controller pods available 0/1, node pods available 0/2",
+		},
+		{
+			name: "pods failing",
+			setConditions: func(instance *csmv1.ContainerStorageModule) {
+				setPodsNotReadyConditions(instance, csmv1.PodStatus{Available: "1", Desired: "1"}, csmv1.PodStatus{Available: "1", Desired: "2"},
+					fmt.Errorf("error message for default \nImagePullBackOff=Back-off pulling image"))
+			},
+			wantReason:   csmv1.ReasonPodsFailing,
+			wantDegraded: true,
+			wantMessage:  "ImagePullBackOff=Back-off pulling image",
+		},
+		{
+			name: "module not running",
+			setConditions: func(instance *csmv1.ContainerStorageModule) {
+				setModuleNotReadyConditions(instance, csmv1.Observability, fmt.Errorf("otel-collector not ready"))
+			},
+			wantReason:   csmv1.ReasonModuleNotReady,
+			wantDegraded: true,
+			wantMessage:  "observability module not running: otel-collector not ready",
+		},
+		{
+			name: "cluster not ready",
+			setConditions: func(instance *csmv1.ContainerStorageModule) {
+				setClusterNotReadyConditions(instance, csmv1.ClusterStatus{
+					ClusterID:        "target-1",
+					ControllerStatus: csmv1.PodStatus{Available: "0", Desired: "1"},
+					LastError:        "deployments.apps \"powerflex-controller\" not found",
+				})
+			},
+			wantReason:   csmv1.ReasonClusterNotReady,
+			wantDegraded: true,
+			wantMessage:  "driver not running on cluster target-1: controller pods available 0/1: deployments.apps",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := createCSM("powerflex", "powerflex", csmv1.PowerFlex, csmv1.Observability, true, nil)
+			instance.Generation = 3
+			tt.setConditions(instance)
+
+			ready := meta.FindStatusCondition(instance.Status.Conditions, csmv1.ConditionReady)
+			assert.NotNil(t, ready)
+			assert.Equal(t, metav1.ConditionFalse, ready.Status)
+			assert.Equal(t, tt.wantReason, ready.Reason)
+			assert.Contains(t, ready.Message, tt.wantMessage)
+			assert.Equal(t, int64(3), ready.ObservedGeneration)
+			assert.Equal(t, tt.wantProgressing, meta.IsStatusConditionTrue(instance.Status.Conditions, csmv1.ConditionProgressing))
+			assert.Equal(t, tt.wantDegraded, meta.IsStatusConditionTrue(instance.Status.Conditions, csmv1.ConditionDegraded))
+		})
+	}
+}
+
+func TestCalculateClusterStatuses(t *testing.T) {
+	ctx := context.Background()
+	instance := createCSM("powerflex", "powerflex", csmv1.PowerFlex, csmv1.Replication, false, nil)
+	instance.Spec.TargetClusters = []string{"target-ready", "target-missing", "target-unreachable"}
+
+	readyCluster := ctrlClientFake.NewClientBuilder().WithObjects(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "powerflex-controller", Namespace: "powerflex"},
+			Status:     appsv1.DeploymentStatus{Replicas: 1, AvailableReplicas: 1, ReadyReplicas: 1},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "powerflex-node", Namespace: "powerflex"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 1},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "powerflex-node-1", Namespace: "powerflex", Labels: map[string]string{"app": "powerflex-node"}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		},
+	).Build()
+
+	defer func(orig func(context.Context, string, client.Client) (ClusterConfig, error)) {
+		GetRemoteClusterWrapper = orig
+	}(GetRemoteClusterWrapper)
+	GetRemoteClusterWrapper = func(_ context.Context, clusterID string, _ client.Client) (ClusterConfig, error) {
+		switch clusterID {
+		case "target-ready":
+			return ClusterConfig{ClusterID: clusterID, ClusterCTRLClient: readyCluster}, nil
+		case "target-missing":
+			return ClusterConfig{ClusterID: clusterID, ClusterCTRLClient: ctrlClientFake.NewClientBuilder().Build()}, nil
+		}
+		return ClusterConfig{}, fmt.Errorf("secrets \"%s\" not found", clusterID)
+	}
+
+	r := &FakeReconcileCSM{Client: ctrlClientFake.NewClientBuilder().Build(), K8sClient: fake.NewSimpleClientset()}
+	newStatus := &csmv1.ContainerStorageModuleStatus{State: constants.Succeeded}
+	statuses := calculateClusterStatuses(ctx, instance, r, newStatus)
+
+	assert.Len(t, statuses, 4)
+	assert.Equal(t, DefaultSourceClusterID, statuses[0].ClusterID)
+	assert.Equal(t, csmv1.CSMStateType(constants.Succeeded), statuses[0].State)
+
+	assert.Equal(t, "target-ready", statuses[1].ClusterID)
+	assert.Equal(t, csmv1.CSMStateType(constants.Succeeded), statuses[1].State)
+	assert.Equal(t, "1", statuses[1].NodeStatus.Available)
+	assert.Empty(t, statuses[1].LastError)
+
+	assert.Equal(t, "target-missing", statuses[2].ClusterID)
+	assert.Equal(t, csmv1.CSMStateType(constants.Failed), statuses[2].State)
+	assert.Contains(t, statuses[2].LastError, "not found")
+
+	assert.Equal(t, "target-unreachable", statuses[3].ClusterID)
+	assert.Equal(t, csmv1.CSMStateType(constants.Failed), statuses[3].State)
+	assert.Equal(t, `secrets "target-unreachable" not found`, statuses[3].LastError)
+}
+
 func TestHandleSuccess(t *testing.T) {
 	type args struct {
 		ctx       context.Context
@@ -1732,6 +2100,14 @@ func TestHandleSuccess(t *testing.T) {
 									},
 								},
 							},
+						},
+						&appsv1.Deployment{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      ReplicationControllerManager,
+								Namespace: ReplicationControllerNameSpace,
+							},
+							Spec:   appsv1.DeploymentSpec{Replicas: &[]int32{1}[0]},
+							Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
 						}).Build(),
 					K8sClient: fake.NewSimpleClientset(),
 				},
@@ -1908,7 +2284,7 @@ func getSecret(namespace, secretName string) *corev1.Secret {
 	}
 }
 
-func createCSM(name string, namespace string, driverType csmv1.DriverType, moduleType csmv1.ModuleType, moduleEnabled bool, components []csmv1.ContainerTemplate) *csmv1.ContainerStorageModule {
+func createCSM(name string, namespace string, driverType csmv1.DriverType, moduleType csmv1.ModuleType, moduleEnabled bool, components []csmv1.PodTemplate) *csmv1.ContainerStorageModule {
 	return &csmv1.ContainerStorageModule{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -1929,7 +2305,7 @@ func createCSM(name string, namespace string, driverType csmv1.DriverType, modul
 	}
 }
 
-func createCSMWithStatus(name string, namespace string, driverType csmv1.DriverType, moduleType csmv1.ModuleType, moduleEnabled bool, components []csmv1.ContainerTemplate, status csmv1.ContainerStorageModuleStatus) *csmv1.ContainerStorageModule {
+func createCSMWithStatus(name string, namespace string, driverType csmv1.DriverType, moduleType csmv1.ModuleType, moduleEnabled bool, components []csmv1.PodTemplate, status csmv1.ContainerStorageModuleStatus) *csmv1.ContainerStorageModule {
 	return &csmv1.ContainerStorageModule{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -2144,6 +2520,16 @@ func TestUpdateStatusSetsLastSuccessfulConfiguration(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.Equal(t, `{"driver":"replicas=1"}`, instance.Status.LastSuccessfulConfiguration)
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, csmv1.ConditionReady))
+	assert.True(t, meta.IsStatusConditionFalse(instance.Status.Conditions, csmv1.ConditionDegraded))
+	assert.Equal(t, instance.Generation, instance.Status.ObservedGeneration)
+
+	// a rolled back spec keeps the last successful configuration it is running
+	instance.Generation = 2
+	instance.Status.RolledBackGeneration = 2
+	instance.Status.LastSuccessfulConfiguration = `{"driver":"replicas=2"}`
+	_ = UpdateStatus(ctx, instance, r, newStatus, OperatorConfig{})
+	assert.Equal(t, `{"driver":"replicas=2"}`, instance.Status.LastSuccessfulConfiguration)
 }
 
 func TestUpdateStatusAuthorizationProxyServer(t *testing.T) {
@@ -0,0 +1,371 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package operatorutils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+)
+
+// templateRepositories - holds the *TemplateRepository of each config directory
+var templateRepositories sync.Map
+
+// TemplateRepository - serves the files of an operatorconfig tree (driverconfig, moduleconfig and common) from memory.
+// The tree is loaded once, and a file or directory is read again when it changes on disk.
+// The files of template bundles are merged over the tree
+type TemplateRepository struct {
+	root  string
+	mu    sync.RWMutex
+	files map[string]*templateFile
+	dirs  map[string]*templateDir
+
+	// bundles - the files of each bundle by path
+	bundles map[string]map[string][]byte
+	// overlay - the files of all bundles, the bundle whose name sorts last wins
+	overlay map[string]*templateFile
+	// overlayDirs - the subdirectories the bundles add to each directory
+	overlayDirs map[string][]string
+}
+
+// templateFile - the content of a file, and its last unmarshalled form
+type templateFile struct {
+	data    []byte
+	modTime time.Time
+	size    int64
+	parsed  any
+}
+
+// templateDir - the subdirectories of a directory, which are the versions of a component directory
+type templateDir struct {
+	modTime time.Time
+	subdirs []string
+}
+
+// Templates - returns the template repository of the config directory, loading the tree on first use
+func Templates(configDirectory string) *TemplateRepository {
+	root := filepath.Clean(configDirectory)
+	if repo, ok := templateRepositories.Load(root); ok {
+		return repo.(*TemplateRepository)
+	}
+	repo, loaded := templateRepositories.LoadOrStore(root, &TemplateRepository{
+		root:    root,
+		files:   map[string]*templateFile{},
+		dirs:    map[string]*templateDir{},
+		bundles: map[string]map[string][]byte{},
+	})
+	if !loaded {
+		repo.(*TemplateRepository).load()
+	}
+	return repo.(*TemplateRepository)
+}
+
+// load - reads every file and directory of the tree, lookups of what cannot be read go to the disk
+func (r *TemplateRepository) load() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = filepath.WalkDir(r.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(r.root, path)
+		if err != nil {
+			return nil
+		}
+		if !entry.IsDir() {
+			data, err := os.ReadFile(path) // #nosec G304 -- path is within the config directory
+			if err == nil {
+				r.files[rel] = &templateFile{data: data, modTime: info.ModTime(), size: info.Size()}
+			}
+			return nil
+		}
+		entries, err := os.ReadDir(path)
+		if err == nil {
+			r.dirs[rel] = &templateDir{modTime: info.ModTime(), subdirs: subdirs(entries)}
+		}
+		return nil
+	})
+}
+
+// ReadFile - returns the content of the file at the path relative to the config directory
+func (r *TemplateRepository) ReadFile(path string) ([]byte, error) {
+	file, err := r.file(path)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Clone(file.data), nil
+}
+
+// SetBundle - merges the files of a verified bundle, by path relative to the config directory, over the tree.
+// The files replace the ones of a previous bundle with the same name
+func (r *TemplateRepository) SetBundle(name string, files map[string][]byte) error {
+	bundle := map[string][]byte{}
+	for path, data := range files {
+		clean, err := bundlePath(path)
+		if err != nil {
+			return err
+		}
+		bundle[clean] = bytes.Clone(data)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bundles[name] = bundle
+	r.mergeBundles()
+	return nil
+}
+
+// RemoveBundle - drops the files of the bundle
+func (r *TemplateRepository) RemoveBundle(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.bundles[name]; !ok {
+		return
+	}
+	delete(r.bundles, name)
+	r.mergeBundles()
+}
+
+// Bundles - returns the names of the merged bundles, sorted
+func (r *TemplateRepository) Bundles() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := slices.Collect(maps.Keys(r.bundles))
+	slices.Sort(names)
+	return names
+}
+
+// BundlePaths - returns the paths of the files of the bundle, sorted
+func (r *TemplateRepository) BundlePaths(name string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	paths := slices.Collect(maps.Keys(r.bundles[name]))
+	slices.Sort(paths)
+	return paths
+}
+
+// mergeBundles - rebuilds the overlay from the bundles, r.mu must be locked
+func (r *TemplateRepository) mergeBundles() {
+	names := slices.Collect(maps.Keys(r.bundles))
+	slices.Sort(names)
+	overlay := map[string]*templateFile{}
+	overlayDirs := map[string][]string{}
+	for _, name := range names {
+		for path, data := range r.bundles[name] {
+			overlay[path] = &templateFile{data: data, size: int64(len(data))}
+			for dir := filepath.Dir(path); dir != "."; dir = filepath.Dir(dir) {
+				parent := filepath.Dir(dir)
+				if !slices.Contains(overlayDirs[parent], filepath.Base(dir)) {
+					overlayDirs[parent] = append(overlayDirs[parent], filepath.Base(dir))
+				}
+			}
+		}
+	}
+	r.overlay = overlay
+	r.overlayDirs = overlayDirs
+}
+
+// bundlePath - returns the cleaned path of a bundle file, which must be within driverconfig, moduleconfig or common
+func bundlePath(path string) (string, error) {
+	clean := filepath.Clean(path)
+	if filepath.IsAbs(clean) || !filepath.IsLocal(clean) {
+		return "", fmt.Errorf("template bundle file %s is not within the config directory", path)
+	}
+	switch strings.SplitN(filepath.ToSlash(clean), "/", 2)[0] {
+	case "driverconfig", "moduleconfig", "common":
+		if strings.Contains(filepath.ToSlash(clean), "/") {
+			return clean, nil
+		}
+	}
+	return "", fmt.Errorf("template bundle file %s is not within driverconfig, moduleconfig or common", path)
+}
+
+// DriverFile - returns the content of a file of the config version of the driver, by the name of its config directory
+func (r *TemplateRepository) DriverFile(driverType csmv1.DriverType, version, filename string) ([]byte, error) {
+	return r.ReadFile(filepath.Join("driverconfig", string(driverType), version, filename))
+}
+
+// DriverVersionSupported - checks if the config version of the driver, by the name of its config directory, is shipped
+// with the operator or merged from a template bundle
+func DriverVersionSupported(driverType csmv1.DriverType, version string, operatorConfig OperatorConfig) bool {
+	_, err := Templates(operatorConfig.ConfigDirectory).DriverFile(driverType, version, "upgrade-path.yaml")
+	return !os.IsNotExist(err)
+}
+
+// ModuleFile - returns the content of a file of the config version of the module
+func (r *TemplateRepository) ModuleFile(moduleType csmv1.ModuleType, version, filename string) ([]byte, error) {
+	return r.ReadFile(filepath.Join("moduleconfig", string(moduleType), version, filename))
+}
+
+// DriverVersions - returns the config versions of the driver, by the name of its config directory, sorted by name
+func (r *TemplateRepository) DriverVersions(driverType csmv1.DriverType) ([]string, error) {
+	return r.versions(filepath.Join("driverconfig", string(driverType)))
+}
+
+// ModuleVersions - returns the config versions of the module, sorted by name
+func (r *TemplateRepository) ModuleVersions(moduleType csmv1.ModuleType) ([]string, error) {
+	return r.versions(filepath.Join("moduleconfig", string(moduleType)))
+}
+
+// CSMVersionMapping - returns the config version of each CSM version, by driver
+func (r *TemplateRepository) CSMVersionMapping() (map[csmv1.DriverType]map[string]string, error) {
+	return parsedTemplate[map[csmv1.DriverType]map[string]string](r, filepath.Join("common", "csm-version-mapping.yaml"))
+}
+
+// ModuleVersionValues - returns the default module versions of each driver config version, by driver
+func (r *TemplateRepository) ModuleVersionValues() (map[csmv1.DriverType]map[string]map[csmv1.ModuleType]string, error) {
+	return parsedTemplate[map[csmv1.DriverType]map[string]map[csmv1.ModuleType]string](r, filepath.Join("moduleconfig", "common", "version-values.yaml"))
+}
+
+// parsedTemplate - returns the file unmarshalled into T, which is only unmarshalled again when the file changes.
+// The result is shared with the other callers and must not be modified
+func parsedTemplate[T any](r *TemplateRepository, path string) (T, error) {
+	var parsed T
+	file, err := r.file(path)
+	if err != nil {
+		return parsed, err
+	}
+	r.mu.RLock()
+	cached, ok := file.parsed.(T)
+	r.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+	if err := yamlUnmarshal(file.data, &parsed); err != nil {
+		return parsed, err
+	}
+	r.mu.Lock()
+	file.parsed = parsed
+	r.mu.Unlock()
+	return parsed, nil
+}
+
+// file - returns the entry of the file, reading it again if its modification time or size changed
+func (r *TemplateRepository) file(path string) (*templateFile, error) {
+	path = filepath.Clean(path)
+	r.mu.RLock()
+	bundled, ok := r.overlay[path]
+	r.mu.RUnlock()
+	if ok {
+		return bundled, nil
+	}
+
+	fullPath := filepath.Join(r.root, path)
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		r.mu.Lock()
+		delete(r.files, path)
+		r.mu.Unlock()
+		return nil, asOpenError(err)
+	}
+
+	r.mu.RLock()
+	file, ok := r.files[path]
+	r.mu.RUnlock()
+	if ok && file.modTime.Equal(info.ModTime()) && file.size == info.Size() {
+		return file, nil
+	}
+
+	data, err := os.ReadFile(fullPath) // #nosec G304 -- path is within the config directory
+	if err != nil {
+		return nil, err
+	}
+	file = &templateFile{data: data, modTime: info.ModTime(), size: info.Size()}
+	r.mu.Lock()
+	r.files[path] = file
+	r.mu.Unlock()
+	return file, nil
+}
+
+// versions - returns the subdirectories of the directory and the ones the bundles add to it, sorted by name
+func (r *TemplateRepository) versions(dir string) ([]string, error) {
+	dir = filepath.Clean(dir)
+	r.mu.RLock()
+	bundled := slices.Clone(r.overlayDirs[dir])
+	r.mu.RUnlock()
+
+	onDisk, err := r.diskVersions(dir)
+	if err != nil {
+		if len(bundled) > 0 && os.IsNotExist(err) {
+			slices.Sort(bundled)
+			return bundled, nil
+		}
+		return nil, err
+	}
+	for _, version := range bundled {
+		if !slices.Contains(onDisk, version) {
+			onDisk = append(onDisk, version)
+		}
+	}
+	slices.Sort(onDisk)
+	return onDisk, nil
+}
+
+// diskVersions - returns the subdirectories of the directory on disk, listing it again if its modification time changed
+func (r *TemplateRepository) diskVersions(dir string) ([]string, error) {
+	fullPath := filepath.Join(r.root, dir)
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return nil, asOpenError(err)
+	}
+
+	r.mu.RLock()
+	cached, ok := r.dirs[dir]
+	r.mu.RUnlock()
+	if ok && cached.modTime.Equal(info.ModTime()) {
+		return slices.Clone(cached.subdirs), nil
+	}
+
+	entries, err := os.ReadDir(fullPath)
+	if err != nil {
+		return nil, err
+	}
+	cached = &templateDir{modTime: info.ModTime(), subdirs: subdirs(entries)}
+	r.mu.Lock()
+	r.dirs[dir] = cached
+	r.mu.Unlock()
+	return slices.Clone(cached.subdirs), nil
+}
+
+// subdirs - returns the names of the entries that are directories
+func subdirs(entries []fs.DirEntry) []string {
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// asOpenError - reports a failed stat like the failed read the callers of the repository used to get
+func asOpenError(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		pathErr.Op = "open"
+	}
+	return err
+}
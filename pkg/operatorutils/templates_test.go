@@ -0,0 +1,188 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package operatorutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTemplate - writes a file of the config tree with the given modification time
+func writeTemplate(t *testing.T, configDir, path, content string, modTime time.Time) {
+	fullPath := filepath.Join(configDir, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o750))
+	require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o600))
+	require.NoError(t, os.Chtimes(fullPath, modTime, modTime))
+}
+
+func TestTemplates(t *testing.T) {
+	configDir := t.TempDir()
+	modTime := time.Now().Add(-time.Hour)
+	writeTemplate(t, configDir, "driverconfig/powerstore/v2.16.0/controller.yaml", "kind: Deployment", modTime)
+	writeTemplate(t, configDir, "common/csm-version-mapping.yaml", "powerstore:\n  v1.16.0: v2.16.0\n", modTime)
+	writeTemplate(t, configDir, "moduleconfig/observability/default-components.yaml", "", modTime)
+	writeTemplate(t, configDir, "moduleconfig/observability/v1.14.0/karavi-otel-collector.yaml", "", modTime)
+
+	templates := Templates(configDir)
+	assert.Same(t, templates, Templates(configDir+"/"))
+
+	t.Run("driver file", func(t *testing.T) {
+		buf, err := templates.DriverFile(csmv1.PowerStore, "v2.16.0", "controller.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "kind: Deployment", string(buf))
+
+		// the content is a copy of the cached one
+		buf[0] = 'K'
+		buf, err = templates.DriverFile(csmv1.PowerStore, "v2.16.0", "controller.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "kind: Deployment", string(buf))
+
+		writeTemplate(t, configDir, "driverconfig/powerstore/v2.16.0/controller.yaml", "kind: DaemonSet", modTime.Add(time.Minute))
+		buf, err = templates.DriverFile(csmv1.PowerStore, "v2.16.0", "controller.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "kind: DaemonSet", string(buf))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := templates.ModuleFile(csmv1.Observability, "v1.14.0", "missing.yaml")
+		assert.EqualError(t, err, "open "+filepath.Join(configDir, "moduleconfig/observability/v1.14.0/missing.yaml")+": no such file or directory")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("versions", func(t *testing.T) {
+		versions, err := templates.ModuleVersions(csmv1.Observability)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v1.14.0"}, versions)
+
+		require.NoError(t, os.MkdirAll(filepath.Join(configDir, "moduleconfig/observability/v1.15.0"), 0o750))
+		dirTime := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(filepath.Join(configDir, "moduleconfig/observability"), dirTime, dirTime))
+		versions, err = templates.ModuleVersions(csmv1.Observability)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v1.14.0", "v1.15.0"}, versions)
+
+		_, err = templates.DriverVersions(csmv1.PowerMax)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("parsed file", func(t *testing.T) {
+		mapping, err := templates.CSMVersionMapping()
+		require.NoError(t, err)
+		assert.Equal(t, "v2.16.0", mapping[csmv1.PowerStore]["v1.16.0"])
+
+		again, err := templates.CSMVersionMapping()
+		require.NoError(t, err)
+		// an unchanged file is not unmarshalled again
+		mapping[csmv1.PowerStore]["marker"] = "cached"
+		assert.Equal(t, "cached", again[csmv1.PowerStore]["marker"])
+
+		writeTemplate(t, configDir, "common/csm-version-mapping.yaml", "powerstore:\n  v1.17.0: v2.17.0\n", modTime.Add(time.Minute))
+		mapping, err = templates.CSMVersionMapping()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"v1.17.0": "v2.17.0"}, mapping[csmv1.PowerStore])
+
+		_, err = templates.ModuleVersionValues()
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestTemplateBundles(t *testing.T) {
+	configDir := t.TempDir()
+	modTime := time.Now().Add(-time.Hour)
+	writeTemplate(t, configDir, "driverconfig/powerflex/v2.17.0/controller.yaml", "kind: Deployment", modTime)
+	writeTemplate(t, configDir, "driverconfig/powerflex/v2.17.0/node.yaml", "kind: DaemonSet", modTime)
+	templates := Templates(configDir)
+
+	err := templates.SetBundle("configmap/powerflex-v2.17.2", map[string][]byte{
+		"driverconfig/powerflex/v2.17.2/controller.yaml": []byte("version: v2.17.2"),
+		"./driverconfig/powerflex/v2.17.0/node.yaml":     []byte("kind: Pod"),
+		"moduleconfig/resiliency/v1.16.0/container.yaml": []byte("name: podmon"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"configmap/powerflex-v2.17.2"}, templates.Bundles())
+	assert.Equal(t, []string{
+		"driverconfig/powerflex/v2.17.0/node.yaml",
+		"driverconfig/powerflex/v2.17.2/controller.yaml",
+		"moduleconfig/resiliency/v1.16.0/container.yaml",
+	}, templates.BundlePaths("configmap/powerflex-v2.17.2"))
+	assert.Empty(t, templates.BundlePaths("configmap/unknown"))
+
+	buf, err := templates.DriverFile(csmv1.PowerFlex, "v2.17.2", "controller.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "version: v2.17.2", string(buf))
+	buf, err = templates.DriverFile(csmv1.PowerFlex, "v2.17.0", "node.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Pod", string(buf))
+	buf, err = templates.DriverFile(csmv1.PowerFlex, "v2.17.0", "controller.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Deployment", string(buf))
+
+	versions, err := templates.DriverVersions(csmv1.PowerFlex)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v2.17.0", "v2.17.2"}, versions)
+	versions, err = templates.ModuleVersions(csmv1.Resiliency)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.16.0"}, versions)
+
+	t.Run("the bundle that sorts last wins", func(t *testing.T) {
+		require.NoError(t, templates.SetBundle("oci/registry.local/csm-templates:v2.17.2", map[string][]byte{
+			"driverconfig/powerflex/v2.17.0/node.yaml": []byte("kind: ReplicaSet"),
+		}))
+		buf, err := templates.DriverFile(csmv1.PowerFlex, "v2.17.0", "node.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "kind: ReplicaSet", string(buf))
+		templates.RemoveBundle("oci/registry.local/csm-templates:v2.17.2")
+	})
+
+	t.Run("files outside of the templates", func(t *testing.T) {
+		for _, path := range []string{"../etc/passwd", "/driverconfig/powerflex/v2.17.2/node.yaml", "k8s/v1.34.yaml", "driverconfig", "driverconfig/../../x"} {
+			err := templates.SetBundle("configmap/bad", map[string][]byte{path: []byte("x")})
+			assert.Error(t, err, path)
+		}
+		assert.Equal(t, []string{"configmap/powerflex-v2.17.2"}, templates.Bundles())
+	})
+
+	templates.RemoveBundle("configmap/powerflex-v2.17.2")
+	assert.Empty(t, templates.Bundles())
+	_, err = templates.DriverFile(csmv1.PowerFlex, "v2.17.2", "controller.yaml")
+	assert.True(t, os.IsNotExist(err))
+	buf, err = templates.DriverFile(csmv1.PowerFlex, "v2.17.0", "node.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "kind: DaemonSet", string(buf))
+	versions, err = templates.DriverVersions(csmv1.PowerFlex)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v2.17.0"}, versions)
+	_, err = templates.ModuleVersions(csmv1.Resiliency)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestDriverVersionSupported(t *testing.T) {
+	configDir := t.TempDir()
+	writeTemplate(t, configDir, "driverconfig/powerflex/v2.17.0/upgrade-path.yaml", "minUpgradePath: v2.16.0", time.Now())
+	operatorConfig := OperatorConfig{ConfigDirectory: configDir}
+
+	assert.True(t, DriverVersionSupported(csmv1.PowerFlex, "v2.17.0", operatorConfig))
+	assert.False(t, DriverVersionSupported(csmv1.PowerFlex, "v2.17.2", operatorConfig))
+
+	// a version merged from a template bundle is supported
+	require.NoError(t, Templates(configDir).SetBundle("configmap/powerflex-v2.17.2", map[string][]byte{
+		"driverconfig/powerflex/v2.17.2/upgrade-path.yaml": []byte("minUpgradePath: v2.17.0"),
+	}))
+	assert.True(t, DriverVersionSupported(csmv1.PowerFlex, "v2.17.2", operatorConfig))
+}
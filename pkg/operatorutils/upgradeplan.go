@@ -0,0 +1,185 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package operatorutils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/logger"
+)
+
+// PlanUpgrade - returns the shortest list of config versions to install one after the other to go from oldVersion
+// to newVersion, which is the last one. Each hop must be a valid upgrade or downgrade according to the
+// upgrade-path.yaml files of the component, and for a driver, of the default versions of the given modules
+func PlanUpgrade[T CSMComponentType](ctx context.Context, oldVersion, newVersion string, csmComponentType T, modules []csmv1.ModuleType, operatorConfig OperatorConfig) ([]string, error) {
+	log := logger.GetLogger(ctx)
+	if oldVersion == newVersion {
+		return nil, nil
+	}
+
+	csmCompConfigDir := "moduleconfig"
+	driverType, isDriver := any(csmComponentType).(csmv1.DriverType)
+	if isDriver {
+		csmCompConfigDir = "driverconfig"
+	}
+	minUpgradePaths, err := readUpgradePaths(operatorConfig, csmCompConfigDir, string(csmComponentType))
+	if err != nil {
+		return nil, err
+	}
+
+	moduleUpgradePaths := map[csmv1.ModuleType]map[string]string{}
+	if isDriver {
+		for _, module := range modules {
+			paths, err := readUpgradePaths(operatorConfig, "moduleconfig", string(module))
+			if err != nil {
+				return nil, err
+			}
+			if len(paths) > 0 {
+				moduleUpgradePaths[module] = paths
+			}
+		}
+	}
+
+	isValidHop := func(from, to string) bool {
+		if !isValidVersionChange(from, to, minUpgradePaths) {
+			return false
+		}
+		for module, paths := range moduleUpgradePaths {
+			moduleTo, err := GetModuleDefaultVersion(to, driverType, module, operatorConfig.ConfigDirectory)
+			if err != nil {
+				return false
+			}
+			// the module versions of a config version that is no longer shipped are unknown
+			moduleFrom, err := GetModuleDefaultVersion(from, driverType, module, operatorConfig.ConfigDirectory)
+			if err == nil && moduleFrom != moduleTo && !isValidVersionChange(moduleFrom, moduleTo, paths) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// hops move in the direction of newVersion, the farthest ones are tried first
+	upgrade := compareVersions(oldVersion, newVersion) < 0
+	candidates := []string{}
+	for version := range minUpgradePaths {
+		if upgrade && compareVersions(version, oldVersion) > 0 && compareVersions(version, newVersion) <= 0 ||
+			!upgrade && compareVersions(version, oldVersion) < 0 && compareVersions(version, newVersion) >= 0 {
+			candidates = append(candidates, version)
+		}
+	}
+	slices.SortFunc(candidates, compareVersions)
+	if upgrade {
+		slices.Reverse(candidates)
+	}
+
+	previous := map[string]string{}
+	queue := []string{oldVersion}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, version := range candidates {
+			if _, visited := previous[version]; visited {
+				continue
+			}
+			if upgrade && compareVersions(version, current) <= 0 || !upgrade && compareVersions(version, current) >= 0 {
+				continue
+			}
+			if !isValidHop(current, version) {
+				continue
+			}
+			previous[version] = current
+			if version == newVersion {
+				path := []string{}
+				for hop := newVersion; hop != oldVersion; hop = previous[hop] {
+					path = append([]string{hop}, path...)
+				}
+				log.Infow("Planned upgrade", "component", csmComponentType, "from", oldVersion, "path", path)
+				return path, nil
+			}
+			queue = append(queue, version)
+		}
+	}
+	return nil, fmt.Errorf("no upgrade path of %s from %s to %s", csmComponentType, oldVersion, newVersion)
+}
+
+// ModulesFollowingDriverVersion - returns the enabled modules of the CSM whose config version is the default one of the driver version
+func ModulesFollowingDriverVersion(cr csmv1.ContainerStorageModule) []csmv1.ModuleType {
+	modules := []csmv1.ModuleType{}
+	for _, m := range cr.Spec.Modules {
+		if m.Enabled && m.ConfigVersion == "" {
+			modules = append(modules, m.Name)
+		}
+	}
+	return modules
+}
+
+// readUpgradePaths - returns the minUpgradePath of each config version of the component that has an upgrade-path.yaml
+func readUpgradePaths(operatorConfig OperatorConfig, csmCompConfigDir, csmComponent string) (map[string]string, error) {
+	templates := Templates(operatorConfig.ConfigDirectory)
+	versions, err := templates.versions(filepath.Join(csmCompConfigDir, csmComponent))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	paths := map[string]string{}
+	for _, version := range versions {
+		upgradePath, err := parsedTemplate[UpgradePaths](templates, filepath.Join(csmCompConfigDir, csmComponent, version, "upgrade-path.yaml"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading the upgrade path of %s %s: %v", csmComponent, version, err)
+		}
+		paths[version] = upgradePath.MinUpgradePath
+	}
+	return paths, nil
+}
+
+// isValidVersionChange - applies the rule of IsValidUpgrade to a single version change
+func isValidVersionChange(from, to string, minUpgradePaths map[string]string) bool {
+	isUpgrade, _ := MinVersionCheck(from, to)
+	if isUpgrade {
+		minUpgradePath, ok := minUpgradePaths[to]
+		valid, _ := MinVersionCheck(minUpgradePath, from)
+		return ok && valid
+	}
+	minDowngradePath, ok := minUpgradePaths[from]
+	valid, _ := MinVersionCheck(minDowngradePath, to)
+	return ok && valid
+}
+
+// compareVersions - compares two vX.Y.Z versions, a version that cannot be parsed sorts first
+func compareVersions(a, b string) int {
+	parse := func(version string) []int {
+		parts := []int{}
+		for _, part := range strings.Split(strings.TrimPrefix(version, "v"), ".") {
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return []int{-1}
+			}
+			parts = append(parts, n)
+		}
+		return parts
+	}
+	return slices.Compare(parse(a), parse(b))
+}
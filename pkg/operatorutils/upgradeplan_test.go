@@ -0,0 +1,112 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package operatorutils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeUpgradePaths - writes an upgrade-path.yaml for each version of the component
+func writeUpgradePaths(t *testing.T, dir string, minUpgradePaths map[string]string) {
+	for version, minUpgradePath := range minUpgradePaths {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, version), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, version, "upgrade-path.yaml"), []byte("minUpgradePath: "+minUpgradePath+"\n"), 0o600))
+	}
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	ctx := context.Background()
+	configDir := t.TempDir()
+	writeUpgradePaths(t, filepath.Join(configDir, "driverconfig", "powerstore"), map[string]string{
+		"v2.13.0": "v2.11.0",
+		"v2.14.0": "v2.12.0",
+		"v2.15.0": "v2.13.0",
+		"v2.16.0": "v2.14.0",
+	})
+	writeUpgradePaths(t, filepath.Join(configDir, "moduleconfig", "resiliency"), map[string]string{
+		"v1.11.0": "v1.9.0",
+		"v1.12.0": "v1.10.0",
+		"v1.13.0": "v1.11.0",
+		"v1.14.0": "v1.13.0",
+	})
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, "moduleconfig", "common"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "moduleconfig", "common", "version-values.yaml"), []byte(`powerstore:
+  v2.12.0:
+    resiliency: v1.10.0
+  v2.13.0:
+    resiliency: v1.11.0
+  v2.14.0:
+    resiliency: v1.12.0
+  v2.15.0:
+    resiliency: v1.13.0
+  v2.16.0:
+    resiliency: v1.14.0
+`), 0o600))
+	operatorConfig := OperatorConfig{ConfigDirectory: configDir}
+
+	tests := []struct {
+		name       string
+		oldVersion string
+		newVersion string
+		modules    []csmv1.ModuleType
+		want       []string
+		wantErr    string
+	}{
+		{"same version", "v2.14.0", "v2.14.0", nil, nil, ""},
+		{"direct upgrade", "v2.14.0", "v2.16.0", nil, []string{"v2.16.0"}, ""},
+		{"upgrade through the farthest versions", "v2.12.0", "v2.16.0", nil, []string{"v2.14.0", "v2.16.0"}, ""},
+		{"downgrade", "v2.16.0", "v2.13.0", nil, []string{"v2.14.0", "v2.13.0"}, ""},
+		{"upgrade constrained by a module", "v2.14.0", "v2.16.0", []csmv1.ModuleType{csmv1.Resiliency}, []string{"v2.15.0", "v2.16.0"}, ""},
+		{"no path", "v2.10.0", "v2.16.0", nil, nil, "no upgrade path of powerstore from v2.10.0 to v2.16.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := PlanUpgrade(ctx, tt.oldVersion, tt.newVersion, csmv1.PowerStore, tt.modules, operatorConfig)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, path)
+		})
+	}
+
+	t.Run("module", func(t *testing.T) {
+		path, err := PlanUpgrade(ctx, "v1.10.0", "v1.14.0", csmv1.Resiliency, nil, operatorConfig)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v1.12.0", "v1.13.0", "v1.14.0"}, path)
+	})
+}
+
+func TestModulesFollowingDriverVersion(t *testing.T) {
+	cr := csmv1.ContainerStorageModule{Spec: csmv1.ContainerStorageModuleSpec{Modules: []csmv1.Module{
+		{Name: csmv1.Resiliency, Enabled: true},
+		{Name: csmv1.Replication, Enabled: true, ConfigVersion: "v1.13.0"},
+		{Name: csmv1.Observability},
+	}}}
+	assert.Equal(t, []csmv1.ModuleType{csmv1.Resiliency}, ModulesFollowingDriverVersion(cr))
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, compareVersions("v2.14.0", "v2.14.0"))
+	assert.Equal(t, -1, compareVersions("v2.9.0", "v2.14.0"))
+	assert.Equal(t, 1, compareVersions("v2.14.1", "v2.14.0"))
+	assert.Equal(t, -1, compareVersions("latest", "v1.0.0"))
+}
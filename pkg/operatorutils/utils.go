@@ -22,11 +22,16 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
 	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/constants"
 	"github.com/dell/csm-operator/pkg/logger"
+	"github.com/dell/csm-operator/pkg/resources/contenthash"
 	goYAML "gopkg.in/yaml.v3"
 
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -83,6 +88,7 @@ type OperatorConfig struct {
 	IsOpenShift     bool
 	K8sVersion      K8sImagesConfig
 	ConfigDirectory string
+	OperatorVersion string
 }
 
 // RbacYAML -
@@ -159,6 +165,8 @@ const (
 	ReplicationSideCarName = "dell-csi-replicator"
 	// ResiliencySideCarName -
 	ResiliencySideCarName = "podmon"
+	// VgSnapshotterSideCarName -
+	VgSnapshotterSideCarName = "csi-volumegroup-snapshotter"
 	// DefaultSourceClusterID -
 	DefaultSourceClusterID = "default-source-cluster"
 	// AuthorizationNamespace - authorization
@@ -179,6 +187,12 @@ const (
 	ObservabilityNamespace = "karavi"
 	// configmap
 	CSMImages = "csm-images"
+	// ConfigVersionAnnotation - config version that was last installed by the operator
+	ConfigVersionAnnotation = "storage.dell.com/CSMOperatorConfigVersion"
+	// ResolvedCSMVersionAnnotation - spec.version that the pinned config version was resolved from
+	ResolvedCSMVersionAnnotation = "storage.dell.com/ResolvedCSMVersion"
+	// ResolvedConfigVersionAnnotation - config version pinned for spec.version at admission
+	ResolvedConfigVersionAnnotation = "storage.dell.com/ResolvedConfigVersion"
 )
 
 var configMapPath string
@@ -209,11 +223,12 @@ func SplitYaml(gaintYAML []byte) ([][]byte, error) {
 }
 
 // UpdateSideCarApply -
-func UpdateSideCarApply(ctx context.Context, sideCars []csmv1.ContainerTemplate, c *acorev1.ContainerApplyConfiguration, cr csmv1.ContainerStorageModule, matched VersionSpec) {
-	UpdateContainerApply(ctx, sideCars, c, cr, matched)
+func UpdateSideCarApply(ctx context.Context, sideCars []csmv1.ContainerTemplate, c *acorev1.ContainerApplyConfiguration, cr csmv1.ContainerStorageModule, matched VersionSpec) error {
+	return UpdateContainerApply(ctx, sideCars, c, cr, matched)
 }
 
-func UpdateContainerApply(ctx context.Context, toBeApplied []csmv1.ContainerTemplate, c *acorev1.ContainerApplyConfiguration, cr csmv1.ContainerStorageModule, matched VersionSpec) {
+// UpdateContainerApply - applies the templates in toBeApplied that are named after the container to it
+func UpdateContainerApply(ctx context.Context, toBeApplied []csmv1.ContainerTemplate, c *acorev1.ContainerApplyConfiguration, cr csmv1.ContainerStorageModule, matched VersionSpec) error {
 	sidecarInSpec := false
 	// Apples to sidecars referenced in the spec
 	for _, ctr := range toBeApplied {
@@ -241,6 +256,9 @@ func UpdateContainerApply(ctx context.Context, toBeApplied []csmv1.ContainerTemp
 			emptyEnv := make([]corev1.EnvVar, 0)
 			c.Env = ReplaceAllApplyCustomEnvs(c.Env, emptyEnv, ctr.Envs)
 			c.Args = ReplaceAllArgs(c.Args, ctr.Args)
+			if err := UpdateContainerResourcesApply(ctr, c); err != nil {
+				return fmt.Errorf("applying resources of container %s: %v", ctr.Name, err)
+			}
 		}
 	}
 
@@ -249,13 +267,71 @@ func UpdateContainerApply(ctx context.Context, toBeApplied []csmv1.ContainerTemp
 		if matched.Version != "" {
 			if img := matched.Images[*c.Name]; img != "" {
 				*c.Image = img
-				return
+				return nil
 			}
 		}
 		if cr.Spec.CustomRegistry != "" {
 			*c.Image = ResolveImage(ctx, string(*c.Image), cr)
 		}
 	}
+	return nil
+}
+
+// UpdateContainerResourcesApply - applies the resources and security context of the template to the container
+func UpdateContainerResourcesApply(ctr csmv1.ContainerTemplate, c *acorev1.ContainerApplyConfiguration) error {
+	if ctr.Resources != nil {
+		resources := &acorev1.ResourceRequirementsApplyConfiguration{}
+		if err := toApplyConfiguration(ctr.Resources, resources); err != nil {
+			return err
+		}
+		c.Resources = resources
+	}
+	if ctr.SecurityContext != nil {
+		securityContext := &acorev1.SecurityContextApplyConfiguration{}
+		if err := toApplyConfiguration(ctr.SecurityContext, securityContext); err != nil {
+			return err
+		}
+		c.SecurityContext = securityContext
+	}
+	return nil
+}
+
+// UpdatePodSchedulingApply - applies the affinity, topology spread constraints and priority class of the template to the pod
+func UpdatePodSchedulingApply(ctr *csmv1.PodTemplate, spec *acorev1.PodSpecApplyConfiguration) error {
+	if ctr == nil {
+		return nil
+	}
+	if ctr.Affinity != nil {
+		affinity := &acorev1.AffinityApplyConfiguration{}
+		if err := toApplyConfiguration(ctr.Affinity, affinity); err != nil {
+			return err
+		}
+		spec.Affinity = affinity
+	}
+	if len(ctr.TopologySpreadConstraints) != 0 {
+		constraints := make([]acorev1.TopologySpreadConstraintApplyConfiguration, 0, len(ctr.TopologySpreadConstraints))
+		if err := toApplyConfiguration(ctr.TopologySpreadConstraints, &constraints); err != nil {
+			return err
+		}
+		spec.TopologySpreadConstraints = constraints
+	}
+	if ctr.PriorityClassName != "" {
+		priorityClassName := ctr.PriorityClassName
+		spec.PriorityClassName = &priorityClassName
+	}
+	return nil
+}
+
+// toApplyConfiguration - converts a core API object into its apply configuration
+func toApplyConfiguration(in interface{}, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("marshalling %T: %v", in, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("converting %T to apply configuration: %v", in, err)
+	}
+	return nil
 }
 
 // ReplaceAllContainerImageApply -
@@ -283,8 +359,8 @@ func ReplaceAllContainerImageApply(img K8sImagesConfig, c *acorev1.ContainerAppl
 }
 
 // UpdateInitContainerApply -
-func UpdateInitContainerApply(ctx context.Context, initContainers []csmv1.ContainerTemplate, c *acorev1.ContainerApplyConfiguration, cr csmv1.ContainerStorageModule, matched VersionSpec) {
-	UpdateContainerApply(ctx, initContainers, c, cr, matched)
+func UpdateInitContainerApply(ctx context.Context, initContainers []csmv1.ContainerTemplate, c *acorev1.ContainerApplyConfiguration, cr csmv1.ContainerStorageModule, matched VersionSpec) error {
+	return UpdateContainerApply(ctx, initContainers, c, cr, matched)
 }
 
 // ReplaceAllApplyCustomEnvs -
@@ -820,6 +896,7 @@ func DeleteObject(ctx context.Context, obj crclient.Object, ctrlClient crclient.
 	if err != nil && !k8serror.IsNotFound(err) {
 		return err
 	}
+	contenthash.Forget(obj)
 	return nil
 }
 
@@ -900,13 +977,7 @@ func LogEndReconcile() {
 // GetModuleDefaultVersion -
 func GetModuleDefaultVersion(driverConfigVersion string, driverType csmv1.DriverType, moduleType csmv1.ModuleType, path string) (string, error) {
 	configMapPath := fmt.Sprintf("%s/moduleconfig/common/version-values.yaml", path)
-	buf, err := os.ReadFile(filepath.Clean(configMapPath))
-	if err != nil {
-		return "", err
-	}
-
-	support := map[csmv1.DriverType]map[string]map[csmv1.ModuleType]string{}
-	err = yamlUnmarshal(buf, &support)
+	support, err := Templates(path).ModuleVersionValues()
 	if err != nil {
 		return "", err
 	}
@@ -1045,6 +1116,36 @@ func GetCluster(_ context.Context, r ReconcileCSM) ClusterConfig {
 	return clusterClient
 }
 
+// GetRemoteClusterWrapper - returns the clients of a remote cluster from its kubeconfig secret
+var GetRemoteClusterWrapper = func(ctx context.Context, clusterID string, ctrlClient crclient.Client) (ClusterConfig, error) {
+	clusterCtrlClient, err := getClusterCtrlClient(ctx, clusterID, ctrlClient)
+	if err != nil {
+		return ClusterConfig{}, err
+	}
+	clusterK8sClient, err := getClusterK8SClient(ctx, clusterID, ctrlClient)
+	if err != nil {
+		return ClusterConfig{}, err
+	}
+	return ClusterConfig{
+		ClusterID:         clusterID,
+		ClusterCTRLClient: clusterCtrlClient,
+		ClusterK8sClient:  clusterK8sClient,
+	}, nil
+}
+
+// GetClusters - returns the cluster the operator is running on, followed by the target clusters of the CSM
+func GetClusters(ctx context.Context, cr csmv1.ContainerStorageModule, r ReconcileCSM) ([]ClusterConfig, error) {
+	clusters := []ClusterConfig{GetCluster(ctx, r)}
+	for _, clusterID := range cr.Spec.TargetClusters {
+		cluster, err := GetRemoteClusterWrapper(ctx, clusterID, r.GetClient())
+		if err != nil {
+			return nil, fmt.Errorf("getting the clients of cluster %s: %v", clusterID, err)
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
+}
+
 // GetSecret - check if the secret is present
 func GetSecret(ctx context.Context, name, namespace string, ctrlClient crclient.Client) (*corev1.Secret, error) {
 	found := &corev1.Secret{}
@@ -1095,7 +1196,7 @@ func HasModuleComponent(instance csmv1.ContainerStorageModule, mod csmv1.ModuleT
 }
 
 // AddModuleComponent - add a module component in the cr
-func AddModuleComponent(instance *csmv1.ContainerStorageModule, mod csmv1.ModuleType, component csmv1.ContainerTemplate) {
+func AddModuleComponent(instance *csmv1.ContainerStorageModule, mod csmv1.ModuleType, component csmv1.PodTemplate) {
 	for i := range instance.Spec.Modules {
 		if instance.Spec.Modules[i].Name == mod {
 			instance.Spec.Modules[i].Components = append(instance.Spec.Modules[i].Components, component)
@@ -1184,19 +1285,11 @@ func getUpgradeInfo[T CSMComponentType](ctx context.Context, operatorConfig Oper
 	upgradeInfoPath := fmt.Sprintf("%s/%s/%s/%s/upgrade-path.yaml", operatorConfig.ConfigDirectory, csmCompConfigDir, csmCompType, oldVersion)
 	log.Debugw("getUpgradeInfo", "upgradeInfoPath", upgradeInfoPath)
 
-	buf, err := os.ReadFile(filepath.Clean(upgradeInfoPath))
+	upgradePath, err := parsedTemplate[UpgradePaths](Templates(operatorConfig.ConfigDirectory), filepath.Join(csmCompConfigDir, fmt.Sprint(csmCompType), oldVersion, "upgrade-path.yaml"))
 	if err != nil {
 		log.Errorw("getUpgradeInfo failed", "Error", err.Error())
 		return "", err
 	}
-	YamlString := string(buf)
-
-	var upgradePath UpgradePaths
-	err = yamlUnmarshal([]byte(YamlString), &upgradePath)
-	if err != nil {
-		log.Errorw("getUpgradeInfo yaml marshall failed", "Error", err.Error())
-		return "", err
-	}
 
 	// Example return value: "v2.2.0"
 	return upgradePath.MinUpgradePath, nil
@@ -1254,20 +1347,21 @@ func LoadDefaultComponents(ctx context.Context, cr *csmv1.ContainerStorageModule
 	return nil
 }
 
-func getDefaultComponents(driverType csmv1.DriverType, module csmv1.ModuleType, op OperatorConfig) ([]csmv1.ContainerTemplate, error) {
+func getDefaultComponents(driverType csmv1.DriverType, module csmv1.ModuleType, op OperatorConfig) ([]csmv1.PodTemplate, error) {
 	file := fmt.Sprintf("%s/moduleconfig/%s/default-components.yaml", op.ConfigDirectory, module)
-	buf, err := os.ReadFile(filepath.Clean(file))
-	if err != nil {
+	templates := Templates(op.ConfigDirectory)
+	path := filepath.Join("moduleconfig", string(module), "default-components.yaml")
+	if _, err := templates.file(path); err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %s", file, err.Error())
 	}
 
-	defaultCsm := new(csmv1.ContainerStorageModule)
-	err = yamlUnmarshal(buf, &defaultCsm)
+	cached, err := parsedTemplate[*csmv1.ContainerStorageModule](templates, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal default-components.yaml for %s: %s", module, err.Error())
 	}
 
-	defaultComps := defaultCsm.GetModule(module).Components
+	// the components are renamed below, the cached ones are shared
+	defaultComps := cached.DeepCopy().GetModule(module).Components
 	if module == csmv1.Observability {
 		if driverType == csmv1.PowerScale {
 			driverType = csmv1.PowerScaleName
@@ -1302,6 +1396,247 @@ func SetContainerImage(objects []crclient.Object, deploymentName, containerName,
 	}
 }
 
+// GetImagePullSecrets - returns the image pull secrets of the module, or of the cr when the module does not override them
+func GetImagePullSecrets(cr csmv1.ContainerStorageModule, moduleType csmv1.ModuleType) []corev1.LocalObjectReference {
+	for _, m := range cr.Spec.Modules {
+		if m.Name == moduleType && len(m.ImagePullSecrets) > 0 {
+			return m.ImagePullSecrets
+		}
+	}
+	return cr.Spec.ImagePullSecrets
+}
+
+// ContainerTemplates - returns the container templates of the pod templates
+func ContainerTemplates(templates []csmv1.PodTemplate) []csmv1.ContainerTemplate {
+	containers := make([]csmv1.ContainerTemplate, 0, len(templates))
+	for _, template := range templates {
+		containers = append(containers, template.ContainerTemplate)
+	}
+	return containers
+}
+
+// GetModuleComponents - returns the components of the module of type moduleType in the cr
+func GetModuleComponents(cr csmv1.ContainerStorageModule, moduleType csmv1.ModuleType) []csmv1.PodTemplate {
+	for _, m := range cr.Spec.Modules {
+		if m.Name == moduleType {
+			return m.Components
+		}
+	}
+	return nil
+}
+
+// mergeImagePullSecrets - appends the secrets that are not yet in existing
+func mergeImagePullSecrets(existing, secrets []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	for _, secret := range secrets {
+		found := false
+		for _, e := range existing {
+			if e.Name == secret.Name {
+				found = true
+				break
+			}
+		}
+		if !found && secret.Name != "" {
+			existing = append(existing, secret)
+		}
+	}
+	return existing
+}
+
+// SetImagePullSecrets - adds the image pull secrets to the pod templates of the workloads in objects
+func SetImagePullSecrets(objects []crclient.Object, secrets []corev1.LocalObjectReference) {
+	if len(secrets) == 0 {
+		return
+	}
+	for _, object := range objects {
+		if podSpec := workloadPodSpec(object); podSpec != nil {
+			podSpec.ImagePullSecrets = mergeImagePullSecrets(podSpec.ImagePullSecrets, secrets)
+		}
+	}
+}
+
+// workloadPodSpec - returns the pod template spec of the workload, nil if object is not a workload
+func workloadPodSpec(object crclient.Object) *corev1.PodSpec {
+	switch obj := object.(type) {
+	case *appsv1.Deployment:
+		return &obj.Spec.Template.Spec
+	case *appsv1.DaemonSet:
+		return &obj.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		return &obj.Spec.Template.Spec
+	case *batchv1.Job:
+		return &obj.Spec.Template.Spec
+	}
+	return nil
+}
+
+// SetContainerTemplates - applies the resources and security context of the templates to the containers named after
+// them in the pod templates of the workloads in objects, and their scheduling to the pods running those containers
+func SetContainerTemplates(objects []crclient.Object, templates []csmv1.PodTemplate) {
+	for _, object := range objects {
+		podSpec := workloadPodSpec(object)
+		if podSpec == nil {
+			continue
+		}
+		for _, ctr := range templates {
+			for i := range podSpec.Containers {
+				if podSpec.Containers[i].Name != ctr.Name {
+					continue
+				}
+				if ctr.Resources != nil {
+					podSpec.Containers[i].Resources = *ctr.Resources.DeepCopy()
+				}
+				if ctr.SecurityContext != nil {
+					podSpec.Containers[i].SecurityContext = ctr.SecurityContext.DeepCopy()
+				}
+				if ctr.Affinity != nil {
+					podSpec.Affinity = ctr.Affinity.DeepCopy()
+				}
+				if len(ctr.TopologySpreadConstraints) != 0 {
+					podSpec.TopologySpreadConstraints = make([]corev1.TopologySpreadConstraint, len(ctr.TopologySpreadConstraints))
+					for j := range ctr.TopologySpreadConstraints {
+						ctr.TopologySpreadConstraints[j].DeepCopyInto(&podSpec.TopologySpreadConstraints[j])
+					}
+				}
+				if ctr.PriorityClassName != "" {
+					podSpec.PriorityClassName = ctr.PriorityClassName
+				}
+			}
+		}
+	}
+}
+
+// SetContainerTemplatesApply - applies the resources and security context of the templates to the containers named
+// after them in the pod spec apply configuration, and their scheduling to the pod when it runs one of those containers
+func SetContainerTemplatesApply(templates []csmv1.PodTemplate, spec *acorev1.PodSpecApplyConfiguration) error {
+	if spec == nil {
+		return nil
+	}
+	for i := range templates {
+		for j := range spec.Containers {
+			if spec.Containers[j].Name == nil || *spec.Containers[j].Name != templates[i].Name {
+				continue
+			}
+			if err := UpdateContainerResourcesApply(templates[i].ContainerTemplate, &spec.Containers[j]); err != nil {
+				return fmt.Errorf("applying resources of container %s: %v", templates[i].Name, err)
+			}
+			if err := UpdatePodSchedulingApply(&templates[i], spec); err != nil {
+				return fmt.Errorf("applying scheduling of container %s: %v", templates[i].Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SetImagePullSecretsApply - adds the image pull secrets to the pod spec apply configuration
+func SetImagePullSecretsApply(secrets []corev1.LocalObjectReference, spec *acorev1.PodSpecApplyConfiguration) {
+	if spec == nil {
+		return
+	}
+	for _, secret := range secrets {
+		found := false
+		for _, e := range spec.ImagePullSecrets {
+			if e.Name != nil && *e.Name == secret.Name {
+				found = true
+				break
+			}
+		}
+		if !found && secret.Name != "" {
+			spec.WithImagePullSecrets(acorev1.LocalObjectReference().WithName(secret.Name))
+		}
+	}
+}
+
+// GetOwnerLabels - returns the csm and csmNamespace labels marking an object as managed by cr.
+// Cluster scoped objects and copies in other namespaces cannot carry an owner reference to the CSM, so labels are used instead.
+func GetOwnerLabels(cr csmv1.ContainerStorageModule) map[string]string {
+	return map[string]string{
+		constants.CsmLabel:          cr.Name,
+		constants.CsmNamespaceLabel: cr.Namespace,
+	}
+}
+
+// CopyImagePullSecrets - copies the image pull secrets from the namespace of the cr into namespace
+// when spec.copyImagePullSecrets is set. The copies are labelled with ownerLabels and the ones the cr
+// no longer references are removed. A secret of the same name that the cr did not copy is left alone.
+func CopyImagePullSecrets(ctx context.Context, cr csmv1.ContainerStorageModule, secrets []corev1.LocalObjectReference, namespace string, ownerLabels map[string]string, ctrlClient crclient.Client) error {
+	if namespace == cr.Namespace {
+		return nil
+	}
+	log := logger.GetLogger(ctx)
+
+	keep := make(map[string]bool, len(secrets))
+	if cr.Spec.CopyImagePullSecrets {
+		for _, secret := range secrets {
+			keep[secret.Name] = true
+
+			existing := &corev1.Secret{}
+			err := ctrlClient.Get(ctx, t1.NamespacedName{Name: secret.Name, Namespace: namespace}, existing)
+			if err == nil && !hasLabels(existing.Labels, ownerLabels) {
+				log.Warnw("Image pull secret already exists and is not a copy of this CSM, not replacing it", "Name", secret.Name, "Namespace", namespace)
+				continue
+			} else if err != nil && !k8serror.IsNotFound(err) {
+				return fmt.Errorf("reading image pull secret %s in namespace %s: %v", secret.Name, namespace, err)
+			}
+
+			found := &corev1.Secret{}
+			err = ctrlClient.Get(ctx, t1.NamespacedName{Name: secret.Name, Namespace: cr.Namespace}, found)
+			if err != nil {
+				return fmt.Errorf("reading image pull secret %s: %v", secret.Name, err)
+			}
+			newSecret := &corev1.Secret{
+				TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      found.Name,
+					Namespace: namespace,
+					Labels:    ownerLabels,
+				},
+				Data: found.Data,
+				Type: found.Type,
+			}
+			if err := ApplyObject(ctx, newSecret, ctrlClient); err != nil {
+				return fmt.Errorf("copying image pull secret %s to namespace %s: %v", secret.Name, namespace, err)
+			}
+		}
+	}
+	return pruneImagePullSecrets(ctx, namespace, keep, ownerLabels, ctrlClient)
+}
+
+// DeleteImagePullSecrets - removes the image pull secrets copied into namespace for the cr labelled with ownerLabels
+func DeleteImagePullSecrets(ctx context.Context, namespace string, ownerLabels map[string]string, ctrlClient crclient.Client) error {
+	return pruneImagePullSecrets(ctx, namespace, map[string]bool{}, ownerLabels, ctrlClient)
+}
+
+// pruneImagePullSecrets - deletes the copied secrets labelled with ownerLabels in namespace that are not in keep
+func pruneImagePullSecrets(ctx context.Context, namespace string, keep map[string]bool, ownerLabels map[string]string, ctrlClient crclient.Client) error {
+	if len(ownerLabels) == 0 {
+		return nil
+	}
+	existing := &corev1.SecretList{}
+	if err := ctrlClient.List(ctx, existing, crclient.InNamespace(namespace), crclient.MatchingLabels(ownerLabels)); err != nil {
+		return fmt.Errorf("listing image pull secrets in namespace %s: %v", namespace, err)
+	}
+	for i := range existing.Items {
+		if keep[existing.Items[i].Name] {
+			continue
+		}
+		logger.GetLogger(ctx).Infow("Deleting copied image pull secret", "Name", existing.Items[i].Name, "Namespace", namespace)
+		if err := ctrlClient.Delete(ctx, &existing.Items[i]); err != nil && !k8serror.IsNotFound(err) {
+			return fmt.Errorf("deleting image pull secret %s in namespace %s: %v", existing.Items[i].Name, namespace, err)
+		}
+	}
+	return nil
+}
+
+// hasLabels - checks if labels contains all the entries of want
+func hasLabels(labels, want map[string]string) bool {
+	for k, v := range want {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
 // fetch an environment variable's value by name
 // or return an error if it is not defined/empty string
 func GetEnvironmentVariable(varName string) (string, error) {
@@ -1312,22 +1647,23 @@ func GetEnvironmentVariable(varName string) (string, error) {
 	return value, nil
 }
 
+// pinnedVersionSupported - checks if the config version pinned for the driver, by the name of its config directory,
+// or for the authorization proxy server is one of the config versions of the operator
+func pinnedVersionSupported(driverType csmv1.DriverType, version string, op OperatorConfig) bool {
+	var versions []string
+	var err error
+	if driverType == csmv1.DriverType(csmv1.AuthorizationServer) {
+		versions, err = Templates(op.ConfigDirectory).ModuleVersions(csmv1.Authorization)
+	} else {
+		versions, err = Templates(op.ConfigDirectory).DriverVersions(driverType)
+	}
+	return err == nil && slices.Contains(versions, version)
+}
+
 // GetVersion returns the corresponding config version of the CSM version
 func GetVersion(ctx context.Context, cr *csmv1.ContainerStorageModule, op OperatorConfig) (string, error) {
 	if cr.Spec.Version != "" {
 		log := logger.GetLogger(ctx)
-		file := fmt.Sprintf("%s/common/csm-version-mapping.yaml", op.ConfigDirectory)
-		buf, err := os.ReadFile(filepath.Clean(file))
-		if err != nil {
-			return "", fmt.Errorf("failed to read file %s: %s", file, err.Error())
-		}
-
-		support := map[csmv1.DriverType]map[string]string{}
-		err = yamlUnmarshal(buf, &support)
-		if err != nil {
-			return "", err
-		}
-
 		driverType := cr.Spec.Driver.CSIDriverType
 		if driverType == csmv1.PowerScale {
 			// use powerscale instead of isilon as the folder name is powerscale
@@ -1343,6 +1679,22 @@ func GetVersion(ctx context.Context, cr *csmv1.ContainerStorageModule, op Operat
 			}
 		}
 
+		// a config version pinned at admission takes precedence over the current mapping file. The annotations can be
+		// written by anyone, so the pin is only kept when the operator ships that config version
+		annotations := cr.GetAnnotations()
+		if pinned := annotations[ResolvedConfigVersionAnnotation]; pinned != "" && annotations[ResolvedCSMVersionAnnotation] == cr.Spec.Version {
+			if pinnedVersionSupported(driverType, pinned, op) {
+				return pinned, nil
+			}
+			log.Warnw("Ignoring pinned config version that is not supported", "csmVersion", cr.Spec.Version, "configVersion", pinned)
+		}
+
+		file := fmt.Sprintf("%s/common/csm-version-mapping.yaml", op.ConfigDirectory)
+		support, err := Templates(op.ConfigDirectory).CSMVersionMapping()
+		if err != nil {
+			return "", fmt.Errorf("failed to read file %s: %s", file, err.Error())
+		}
+
 		if csmVersion, ok := support[driverType]; ok {
 			if configVersion, ok := csmVersion[cr.Spec.Version]; ok {
 				return configVersion, nil
@@ -1578,3 +1930,89 @@ func GetFinalImage(ctx context.Context, cr csmv1.ContainerStorageModule, matched
 	finalImage = GetImageField(YamlString)
 	return finalImage
 }
+
+// envValuePath - matches the value of an environment variable, which may hold a credential
+var envValuePath = regexp.MustCompile(`\.envs\[\d+\]\.value$`)
+
+// DiffSpecs - returns the fields that differ between two specs, each as "path: old -> new", with the values of the
+// environment variables redacted
+func DiffSpecs(oldSpec, newSpec csmv1.ContainerStorageModuleSpec) ([]string, error) {
+	oldFields, err := flattenSpec(oldSpec)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := flattenSpec(newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(oldFields)+len(newFields))
+	for path := range oldFields {
+		paths = append(paths, path)
+	}
+	for path := range newFields {
+		if _, ok := oldFields[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	diff := []string{}
+	for _, path := range paths {
+		oldValue, ok := oldFields[path]
+		if !ok {
+			oldValue = "<unset>"
+		}
+		newValue, ok := newFields[path]
+		if !ok {
+			newValue = "<unset>"
+		}
+		if oldValue != newValue {
+			if envValuePath.MatchString(path) {
+				oldValue, newValue = redactValue(oldValue), redactValue(newValue)
+			}
+			diff = append(diff, fmt.Sprintf("%s: %s -> %s", path, oldValue, newValue))
+		}
+	}
+	return diff, nil
+}
+
+// redactValue - hides a field value in a diff, but keeps whether it is set
+func redactValue(value string) string {
+	if value == "<unset>" {
+		return value
+	}
+	return "<redacted>"
+}
+
+// flattenSpec - returns the JSON value of each leaf field of the spec by its path
+func flattenSpec(spec csmv1.ContainerStorageModuleSpec) (map[string]string, error) {
+	out, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling spec: %v", err)
+	}
+	var content interface{}
+	if err := json.Unmarshal(out, &content); err != nil {
+		return nil, fmt.Errorf("error unmarshalling spec: %v", err)
+	}
+
+	fields := map[string]string{}
+	var walk func(path string, value interface{})
+	walk = func(path string, value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, item := range v {
+				walk(path+"."+key, item)
+			}
+		case []interface{}:
+			for i, item := range v {
+				walk(fmt.Sprintf("%s[%d]", path, i), item)
+			}
+		default:
+			leaf, _ := json.Marshal(v)
+			fields[path] = string(leaf)
+		}
+	}
+	walk("spec", content)
+	return fields, nil
+}
@@ -35,6 +35,8 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -95,7 +97,7 @@ func fullFakeClient() crclient.WithWatch {
 }
 
 // createCR is a helper function to create ContainerStorageModule object
-func createCR(driverType csmv1.DriverType, moduleType csmv1.ModuleType, moduleEnabled bool, components []csmv1.ContainerTemplate) *csmv1.ContainerStorageModule {
+func createCR(driverType csmv1.DriverType, moduleType csmv1.ModuleType, moduleEnabled bool, components []csmv1.PodTemplate) *csmv1.ContainerStorageModule {
 	return &csmv1.ContainerStorageModule{
 		Spec: csmv1.ContainerStorageModuleSpec{
 			Driver: csmv1.Driver{
@@ -223,8 +225,8 @@ func TestHasModuleComponent(t *testing.T) {
 		},
 		{
 			name: "Module exist and component does not exist",
-			instance: *createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.ContainerTemplate{
-				{Name: "topology"},
+			instance: *createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "topology"}},
 			}),
 			mod:            csmv1.Observability,
 			componentType:  "metrics-powerflex",
@@ -232,9 +234,9 @@ func TestHasModuleComponent(t *testing.T) {
 		},
 		{
 			name: "Module exist and component exists",
-			instance: *createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.ContainerTemplate{
-				{Name: "metrics-powerflex"},
-				{Name: "topology"},
+			instance: *createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "metrics-powerflex"}},
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "topology"}},
 			}),
 			mod:            csmv1.Observability,
 			componentType:  "metrics-powerflex",
@@ -261,11 +263,11 @@ func TestIsModuleComponentEnabled(t *testing.T) {
 				{
 					Name:    csmv1.Observability,
 					Enabled: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "topology",
 							Enabled: &[]bool{true}[0],
-						},
+						}},
 					},
 				},
 			},
@@ -284,11 +286,11 @@ func TestIsModuleComponentEnabled(t *testing.T) {
 				{
 					Name:    csmv1.Observability,
 					Enabled: false,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "topology",
 							Enabled: &[]bool{true}[0],
-						},
+						}},
 					},
 				},
 			},
@@ -307,11 +309,11 @@ func TestIsModuleComponentEnabled(t *testing.T) {
 				{
 					Name:    csmv1.Observability,
 					Enabled: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "topology",
 							Enabled: &[]bool{false}[0],
-						},
+						}},
 					},
 				},
 			},
@@ -330,11 +332,11 @@ func TestIsModuleComponentEnabled(t *testing.T) {
 				{
 					Name:    csmv1.Observability,
 					Enabled: true,
-					Components: []csmv1.ContainerTemplate{
-						{
+					Components: []csmv1.PodTemplate{
+						{ContainerTemplate: csmv1.ContainerTemplate{
 							Name:    "otel-collector",
 							Enabled: &[]bool{true}[0],
-						},
+						}},
 					},
 				},
 			},
@@ -407,29 +409,29 @@ func TestAddModuleComponent(t *testing.T) {
 				mod:       csmv1.Observability,
 				component: csmv1.ContainerTemplate{Name: "topology"},
 			},
-			want: createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.ContainerTemplate{
-				{Name: "topology"},
+			want: createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "topology"}},
 			}),
 		},
 		{
 			name: "Module exists and component is not empty",
 			args: args{
-				instance: createCR(csmv1.PowerFlex, csmv1.Observability, true, []csmv1.ContainerTemplate{
-					{Name: "metrics-powerflex"},
+				instance: createCR(csmv1.PowerFlex, csmv1.Observability, true, []csmv1.PodTemplate{
+					{ContainerTemplate: csmv1.ContainerTemplate{Name: "metrics-powerflex"}},
 				}),
 				mod:       csmv1.Observability,
 				component: csmv1.ContainerTemplate{Name: "topology"},
 			},
-			want: createCR(csmv1.PowerFlex, csmv1.Observability, true, []csmv1.ContainerTemplate{
-				{Name: "metrics-powerflex"},
-				{Name: "topology"},
+			want: createCR(csmv1.PowerFlex, csmv1.Observability, true, []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "metrics-powerflex"}},
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "topology"}},
 			}),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			AddModuleComponent(tt.args.instance, tt.args.mod, tt.args.component)
+			AddModuleComponent(tt.args.instance, tt.args.mod, csmv1.PodTemplate{ContainerTemplate: tt.args.component})
 			assert.Equal(t, tt.want, tt.args.instance)
 		})
 	}
@@ -493,16 +495,16 @@ func TestLoadDefaultComponents(t *testing.T) {
 			name: "Module disabled and topology component missing",
 			args: args{
 				ctx: context.Background(),
-				cr: createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.ContainerTemplate{
-					{Name: "otel-collector", Enabled: enabled},
-					{Name: "metrics-powerflex", Enabled: enabled},
+				cr: createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.PodTemplate{
+					{ContainerTemplate: csmv1.ContainerTemplate{Name: "otel-collector", Enabled: enabled}},
+					{ContainerTemplate: csmv1.ContainerTemplate{Name: "metrics-powerflex", Enabled: enabled}},
 				}),
 				op: validOp,
 			},
 			// if module is disabled, no components should be added
-			want: createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.ContainerTemplate{
-				{Name: "otel-collector", Enabled: enabled},
-				{Name: "metrics-powerflex", Enabled: enabled},
+			want: createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "otel-collector", Enabled: enabled}},
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "metrics-powerflex", Enabled: enabled}},
 			}),
 			wantErr: false,
 		},
@@ -510,18 +512,18 @@ func TestLoadDefaultComponents(t *testing.T) {
 			name: "Module enabled and cert-manager component missing",
 			args: args{
 				ctx: context.Background(),
-				cr: createCR(csmv1.PowerFlex, csmv1.Observability, true, []csmv1.ContainerTemplate{
-					{Name: "topology", Enabled: enabled},
-					{Name: "otel-collector", Enabled: enabled},
-					{Name: "metrics-powerflex", Enabled: enabled},
+				cr: createCR(csmv1.PowerFlex, csmv1.Observability, true, []csmv1.PodTemplate{
+					{ContainerTemplate: csmv1.ContainerTemplate{Name: "topology", Enabled: enabled}},
+					{ContainerTemplate: csmv1.ContainerTemplate{Name: "otel-collector", Enabled: enabled}},
+					{ContainerTemplate: csmv1.ContainerTemplate{Name: "metrics-powerflex", Enabled: enabled}},
 				}),
 				op: validOp,
 			},
-			want: createCR(csmv1.PowerFlex, csmv1.Observability, true, []csmv1.ContainerTemplate{
-				{Name: "topology", Enabled: enabled},
-				{Name: "otel-collector", Enabled: enabled},
-				{Name: "metrics-powerflex", Enabled: enabled},
-				{Name: "cert-manager", Enabled: disabled},
+			want: createCR(csmv1.PowerFlex, csmv1.Observability, true, []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "topology", Enabled: enabled}},
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "otel-collector", Enabled: enabled}},
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "metrics-powerflex", Enabled: enabled}},
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "cert-manager", Enabled: disabled}},
 			}),
 			wantErr: false,
 		},
@@ -529,19 +531,19 @@ func TestLoadDefaultComponents(t *testing.T) {
 			name: "Module disabled and all components exist",
 			args: args{
 				ctx: context.Background(),
-				cr: createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.ContainerTemplate{
-					{Name: "topology", Enabled: enabled},
-					{Name: "otel-collector", Enabled: enabled},
-					{Name: "cert-manager", Enabled: disabled},
-					{Name: "metrics-powerflex", Enabled: enabled},
+				cr: createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.PodTemplate{
+					{ContainerTemplate: csmv1.ContainerTemplate{Name: "topology", Enabled: enabled}},
+					{ContainerTemplate: csmv1.ContainerTemplate{Name: "otel-collector", Enabled: enabled}},
+					{ContainerTemplate: csmv1.ContainerTemplate{Name: "cert-manager", Enabled: disabled}},
+					{ContainerTemplate: csmv1.ContainerTemplate{Name: "metrics-powerflex", Enabled: enabled}},
 				}),
 				op: validOp,
 			},
-			want: createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.ContainerTemplate{
-				{Name: "topology", Enabled: enabled},
-				{Name: "otel-collector", Enabled: enabled},
-				{Name: "cert-manager", Enabled: disabled},
-				{Name: "metrics-powerflex", Enabled: enabled},
+			want: createCR(csmv1.PowerFlex, csmv1.Observability, false, []csmv1.PodTemplate{
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "topology", Enabled: enabled}},
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "otel-collector", Enabled: enabled}},
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "cert-manager", Enabled: disabled}},
+				{ContainerTemplate: csmv1.ContainerTemplate{Name: "metrics-powerflex", Enabled: enabled}},
 			}),
 			wantErr: false,
 		},
@@ -715,6 +717,189 @@ func TestSetContainerImage(t *testing.T) {
 	}
 }
 
+func TestGetImagePullSecrets(t *testing.T) {
+	cr := csmv1.ContainerStorageModule{}
+	cr.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "global"}}
+	cr.Spec.Modules = []csmv1.Module{
+		{Name: csmv1.Observability, ImagePullSecrets: []corev1.LocalObjectReference{{Name: "obs"}}},
+		{Name: csmv1.Replication},
+	}
+
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "obs"}}, GetImagePullSecrets(cr, csmv1.Observability))
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "global"}}, GetImagePullSecrets(cr, csmv1.Replication))
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "global"}}, GetImagePullSecrets(cr, csmv1.AuthorizationServer))
+}
+
+func TestSetImagePullSecrets(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	deployment.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "existing"}}
+	statefulSet := &appsv1.StatefulSet{}
+	job := &batchv1.Job{}
+	configMap := &corev1.ConfigMap{}
+
+	secrets := []corev1.LocalObjectReference{{Name: "existing"}, {Name: "registry"}}
+	SetImagePullSecrets([]client.Object{deployment, statefulSet, job, configMap}, secrets)
+
+	assert.Equal(t, secrets, deployment.Spec.Template.Spec.ImagePullSecrets)
+	assert.Equal(t, secrets, statefulSet.Spec.Template.Spec.ImagePullSecrets)
+	assert.Equal(t, secrets, job.Spec.Template.Spec.ImagePullSecrets)
+}
+
+func TestSetImagePullSecretsApply(t *testing.T) {
+	spec := acorev1.PodSpec().WithImagePullSecrets(acorev1.LocalObjectReference().WithName("existing"))
+	SetImagePullSecretsApply([]corev1.LocalObjectReference{{Name: "existing"}, {Name: "registry"}}, spec)
+
+	assert.Len(t, spec.ImagePullSecrets, 2)
+	assert.Equal(t, "registry", *spec.ImagePullSecrets[1].Name)
+
+	// a nil pod spec is ignored
+	SetImagePullSecretsApply([]corev1.LocalObjectReference{{Name: "registry"}}, nil)
+}
+
+func TestSetContainerTemplates(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "proxy-server"}, {Name: "opa"}}
+	statefulSet := &appsv1.StatefulSet{}
+	statefulSet.Spec.Template.Spec.Containers = []corev1.Container{{Name: "redis"}}
+	configMap := &corev1.ConfigMap{}
+
+	runAsNonRoot := true
+	templates := []csmv1.PodTemplate{{
+		ContainerTemplate: csmv1.ContainerTemplate{
+			Name: "proxy-server",
+			Resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+			SecurityContext: &corev1.SecurityContext{RunAsNonRoot: &runAsNonRoot},
+		},
+		Affinity:                  &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "zone"}},
+		PriorityClassName:         "system-cluster-critical",
+	}}
+	SetContainerTemplates([]client.Object{deployment, statefulSet, configMap}, templates)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, *templates[0].Resources, podSpec.Containers[0].Resources)
+	assert.Equal(t, templates[0].SecurityContext, podSpec.Containers[0].SecurityContext)
+	assert.Empty(t, podSpec.Containers[1].Resources)
+	assert.Equal(t, templates[0].Affinity, podSpec.Affinity)
+	assert.Equal(t, templates[0].TopologySpreadConstraints, podSpec.TopologySpreadConstraints)
+	assert.Equal(t, "system-cluster-critical", podSpec.PriorityClassName)
+
+	// pods not running the component are left alone
+	assert.Nil(t, statefulSet.Spec.Template.Spec.Affinity)
+	assert.Empty(t, statefulSet.Spec.Template.Spec.PriorityClassName)
+}
+
+func TestContainerTemplates(t *testing.T) {
+	templates := []csmv1.PodTemplate{
+		{ContainerTemplate: csmv1.ContainerTemplate{Name: "podmon-controller"}, PriorityClassName: "system-cluster-critical"},
+		{ContainerTemplate: csmv1.ContainerTemplate{Name: "podmon-node"}},
+	}
+	assert.Equal(t, []csmv1.ContainerTemplate{{Name: "podmon-controller"}, {Name: "podmon-node"}}, ContainerTemplates(templates))
+	assert.Empty(t, ContainerTemplates(nil))
+}
+
+func TestSetContainerTemplatesApply(t *testing.T) {
+	spec := acorev1.PodSpec().WithContainers(acorev1.Container().WithName("karavi-topology"), acorev1.Container().WithName("other"))
+	templates := []csmv1.PodTemplate{{
+		ContainerTemplate: csmv1.ContainerTemplate{
+			Name: "karavi-topology",
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			},
+		},
+		PriorityClassName: "system-cluster-critical",
+	}}
+	assert.NoError(t, SetContainerTemplatesApply(templates, spec))
+
+	assert.NotNil(t, spec.Containers[0].Resources)
+	assert.Equal(t, resource.MustParse("100m"), (*spec.Containers[0].Resources.Requests)[corev1.ResourceCPU])
+	assert.Nil(t, spec.Containers[1].Resources)
+	assert.Equal(t, "system-cluster-critical", *spec.PriorityClassName)
+
+	// a nil pod spec is ignored
+	assert.NoError(t, SetContainerTemplatesApply(templates, nil))
+}
+
+func TestCopyImagePullSecrets(t *testing.T) {
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "driver"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+	}
+	cr := csmv1.ContainerStorageModule{ObjectMeta: metav1.ObjectMeta{Name: "csm", Namespace: "driver"}}
+	secrets := []corev1.LocalObjectReference{{Name: "registry"}}
+	ownerLabels := map[string]string{"csm": "csm", "csmNamespace": "driver"}
+	enabled := cr
+	enabled.Spec.CopyImagePullSecrets = true
+
+	t.Run("not copied unless enabled", func(t *testing.T) {
+		ctrlClient := buildFakeClient(t, secret.DeepCopy())
+		assert.NoError(t, CopyImagePullSecrets(ctx, cr, secrets, ObservabilityNamespace, ownerLabels, ctrlClient))
+
+		err := ctrlClient.Get(ctx, client.ObjectKey{Name: "registry", Namespace: ObservabilityNamespace}, &corev1.Secret{})
+		assert.True(t, k8serror.IsNotFound(err))
+	})
+
+	t.Run("copied into the namespace", func(t *testing.T) {
+		ctrlClient := buildFakeClient(t, secret.DeepCopy())
+		assert.NoError(t, CopyImagePullSecrets(ctx, enabled, secrets, ObservabilityNamespace, ownerLabels, ctrlClient))
+
+		copied := &corev1.Secret{}
+		assert.NoError(t, ctrlClient.Get(ctx, client.ObjectKey{Name: "registry", Namespace: ObservabilityNamespace}, copied))
+		assert.Equal(t, secret.Type, copied.Type)
+		assert.Equal(t, secret.Data, copied.Data)
+		assert.Equal(t, ownerLabels, copied.Labels)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		ctrlClient := buildFakeClient(t)
+		assert.Error(t, CopyImagePullSecrets(ctx, enabled, secrets, ObservabilityNamespace, ownerLabels, ctrlClient))
+	})
+
+	t.Run("stale copies removed", func(t *testing.T) {
+		stale := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "old-registry", Namespace: ObservabilityNamespace, Labels: ownerLabels}}
+		other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: ObservabilityNamespace}}
+		ctrlClient := buildFakeClient(t, secret.DeepCopy(), stale, other)
+		assert.NoError(t, CopyImagePullSecrets(ctx, enabled, secrets, ObservabilityNamespace, ownerLabels, ctrlClient))
+
+		err := ctrlClient.Get(ctx, client.ObjectKey{Name: "old-registry", Namespace: ObservabilityNamespace}, &corev1.Secret{})
+		assert.True(t, k8serror.IsNotFound(err))
+		assert.NoError(t, ctrlClient.Get(ctx, client.ObjectKey{Name: "other", Namespace: ObservabilityNamespace}, &corev1.Secret{}))
+		assert.NoError(t, ctrlClient.Get(ctx, client.ObjectKey{Name: "registry", Namespace: ObservabilityNamespace}, &corev1.Secret{}))
+
+		// turning the copy off removes the copies
+		assert.NoError(t, CopyImagePullSecrets(ctx, cr, secrets, ObservabilityNamespace, ownerLabels, ctrlClient))
+		err = ctrlClient.Get(ctx, client.ObjectKey{Name: "registry", Namespace: ObservabilityNamespace}, &corev1.Secret{})
+		assert.True(t, k8serror.IsNotFound(err))
+	})
+
+	t.Run("existing secret not taken over", func(t *testing.T) {
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: ObservabilityNamespace},
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("{\"auths\":{}}")},
+		}
+		ctrlClient := buildFakeClient(t, secret.DeepCopy(), existing)
+		assert.NoError(t, CopyImagePullSecrets(ctx, enabled, secrets, ObservabilityNamespace, ownerLabels, ctrlClient))
+
+		found := &corev1.Secret{}
+		assert.NoError(t, ctrlClient.Get(ctx, client.ObjectKey{Name: "registry", Namespace: ObservabilityNamespace}, found))
+		assert.Equal(t, existing.Data, found.Data)
+		assert.Empty(t, found.Labels)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		copied := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: ObservabilityNamespace, Labels: ownerLabels}}
+		ctrlClient := buildFakeClient(t, copied)
+		assert.NoError(t, DeleteImagePullSecrets(ctx, ObservabilityNamespace, ownerLabels, ctrlClient))
+
+		err := ctrlClient.Get(ctx, client.ObjectKey{Name: "registry", Namespace: ObservabilityNamespace}, &corev1.Secret{})
+		assert.True(t, k8serror.IsNotFound(err))
+	})
+}
+
 func TestUpdateSideCarApply(t *testing.T) {
 	// Test case: update sidecar with matching name
 	ctx := context.Background()
@@ -874,6 +1059,68 @@ func TestUpdateSideCarApply(t *testing.T) {
 	assert.Equal(t, expectedContainer, container)
 }
 
+func TestUpdateContainerResourcesApply(t *testing.T) {
+	privileged := false
+	container := acorev1.Container().WithName("sidecar1")
+
+	err := UpdateContainerResourcesApply(csmv1.ContainerTemplate{Name: "sidecar1"}, container)
+	assert.NoError(t, err)
+	assert.Nil(t, container.Resources)
+	assert.Nil(t, container.SecurityContext)
+
+	err = UpdateContainerResourcesApply(csmv1.ContainerTemplate{
+		Name: "sidecar1",
+		Resources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:   &privileged,
+			Capabilities: &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		},
+	}, container)
+	assert.NoError(t, err)
+	assert.Equal(t, "10m", container.Resources.Requests.Cpu().String())
+	assert.Equal(t, "128Mi", container.Resources.Limits.Memory().String())
+	assert.False(t, *container.SecurityContext.Privileged)
+	assert.Equal(t, []corev1.Capability{"ALL"}, container.SecurityContext.Capabilities.Drop)
+}
+
+func TestUpdatePodSchedulingApply(t *testing.T) {
+	spec := acorev1.PodSpec()
+	assert.NoError(t, UpdatePodSchedulingApply(nil, spec))
+	assert.Nil(t, spec.Affinity)
+
+	err := UpdatePodSchedulingApply(&csmv1.PodTemplate{
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      "kubernetes.io/os",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"linux"},
+						}},
+					}},
+				},
+			},
+		},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "driver"}},
+		}},
+		PriorityClassName: "system-cluster-critical",
+	}, spec)
+	assert.NoError(t, err)
+	terms := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	assert.Equal(t, []string{"linux"}, terms[0].MatchExpressions[0].Values)
+	assert.Equal(t, corev1.DoNotSchedule, *spec.TopologySpreadConstraints[0].WhenUnsatisfiable)
+	assert.Equal(t, map[string]string{"app": "driver"}, spec.TopologySpreadConstraints[0].LabelSelector.MatchLabels)
+	assert.Equal(t, "system-cluster-critical", *spec.PriorityClassName)
+}
+
 func TestReplaceAllContainerImageApply(t *testing.T) {
 	// Create a list of Images that will replace the image names in 'containers', below
 	mockImages := K8sImagesConfig{
@@ -2747,6 +2994,8 @@ func TestGetModuleDefaultVersion(t *testing.T) {
 				yamlUnmarshal = func(_ []byte, _ interface{}) error {
 					return fmt.Errorf("mock error from yamlUnmarshal")
 				}
+				// the parsed templates are cached, drop them so that the mock is called
+				templateRepositories.Clear()
 			}
 			version, err := GetModuleDefaultVersion(tt.driverConfig, tt.driverType, tt.moduleType, tt.path)
 			// Revert to the original function
@@ -3234,6 +3483,44 @@ func TestGetClusterCtrlClient(t *testing.T) {
 	assert.Nil(t, clusterCtrlClient)
 }
 
+func TestGetClusters(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = csmv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	r := &FakeReconcileCSM{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	t.Run("local cluster only", func(t *testing.T) {
+		clusters, err := GetClusters(ctx, csmv1.ContainerStorageModule{}, r)
+		assert.NoError(t, err)
+		assert.Len(t, clusters, 1)
+		assert.Equal(t, DefaultSourceClusterID, clusters[0].ClusterID)
+	})
+
+	t.Run("target cluster without kubeconfig secret", func(t *testing.T) {
+		cr := csmv1.ContainerStorageModule{Spec: csmv1.ContainerStorageModuleSpec{TargetClusters: []string{"target-1"}}}
+		_, err := GetClusters(ctx, cr, r)
+		assert.ErrorContains(t, err, "getting the clients of cluster target-1")
+	})
+
+	t.Run("target clusters", func(t *testing.T) {
+		defer func(orig func(context.Context, string, crclient.Client) (ClusterConfig, error)) {
+			GetRemoteClusterWrapper = orig
+		}(GetRemoteClusterWrapper)
+		GetRemoteClusterWrapper = func(_ context.Context, clusterID string, _ crclient.Client) (ClusterConfig, error) {
+			return ClusterConfig{ClusterID: clusterID}, nil
+		}
+
+		cr := csmv1.ContainerStorageModule{Spec: csmv1.ContainerStorageModuleSpec{TargetClusters: []string{"target-1", "target-2"}}}
+		clusters, err := GetClusters(ctx, cr, r)
+		assert.NoError(t, err)
+		assert.Len(t, clusters, 3)
+		assert.Equal(t, DefaultSourceClusterID, clusters[0].ClusterID)
+		assert.Equal(t, "target-1", clusters[1].ClusterID)
+		assert.Equal(t, "target-2", clusters[2].ClusterID)
+	})
+}
+
 func TestGetClusterK8SClient(t *testing.T) {
 	// Create a fake context.Context
 	ctx := context.Background()
@@ -3442,6 +3729,8 @@ func Test_getUpgradeInfo(t *testing.T) {
 				yamlUnmarshal = func(_ []byte, _ interface{}) error {
 					return fmt.Errorf("mock yamlUnmarshal error")
 				}
+				// the parsed templates are cached, drop them so that the mock is called
+				templateRepositories.Clear()
 			}
 			got, err := getUpgradeInfo(tt.args.ctx, tt.args.operatorConfig, tt.args.csmCompType, tt.args.oldVersion)
 			// Revert to the original function
@@ -3466,7 +3755,7 @@ func Test_getDefaultComponents(t *testing.T) {
 	tests := []struct {
 		name        string
 		args        args
-		want        []csmv1.ContainerTemplate
+		want        []csmv1.PodTemplate
 		expectedErr string
 	}{
 		{
@@ -3490,6 +3779,8 @@ func Test_getDefaultComponents(t *testing.T) {
 				yamlUnmarshal = func(_ []byte, _ interface{}) error {
 					return fmt.Errorf("mock yamlUnmarshal error")
 				}
+				// the parsed templates are cached, drop them so that the mock is called
+				templateRepositories.Clear()
 			}
 			got, err := getDefaultComponents(tt.args.driverType, tt.args.module, tt.args.op)
 			// Revert to the original function
@@ -3593,6 +3884,54 @@ func TestGetVersion(t *testing.T) {
 			want:        "v2.16.0",
 			expectedErr: "",
 		},
+		{
+			name: "version_pinned_by_annotation",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := newCSM("v1.16.0", "", csmv1.PowerScale)
+				cr.Annotations = map[string]string{
+					ResolvedCSMVersionAnnotation:    "v1.16.0",
+					ResolvedConfigVersionAnnotation: "v2.15.1",
+				}
+				return cr
+			}(),
+			op: OperatorConfig{
+				ConfigDirectory: "../../operatorconfig",
+			},
+			want:        "v2.15.1",
+			expectedErr: "",
+		},
+		{
+			name: "unsupported_pin_is_ignored",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := newCSM("v1.16.0", "", csmv1.PowerScale)
+				cr.Annotations = map[string]string{
+					ResolvedCSMVersionAnnotation:    "v1.16.0",
+					ResolvedConfigVersionAnnotation: "v9.9.9",
+				}
+				return cr
+			}(),
+			op: OperatorConfig{
+				ConfigDirectory: "../../operatorconfig",
+			},
+			want:        "v2.16.0",
+			expectedErr: "",
+		},
+		{
+			name: "stale_pin_is_ignored",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := newCSM("v1.16.0", "", csmv1.PowerScale)
+				cr.Annotations = map[string]string{
+					ResolvedCSMVersionAnnotation:    "v1.15.1",
+					ResolvedConfigVersionAnnotation: "v2.15.1",
+				}
+				return cr
+			}(),
+			op: OperatorConfig{
+				ConfigDirectory: "../../operatorconfig",
+			},
+			want:        "v2.16.0",
+			expectedErr: "",
+		},
 		{
 			name: "config_version_present",
 			cr:   newCSM("", "v2.16.0", csmv1.PowerStore),
@@ -4841,3 +5180,44 @@ func TestUpdateSideCarApply_ImageResolutionOrder(t *testing.T) {
 		t.Errorf("CustomRegistry should be applied to original image: expected 'my-registry/original-image:old', got %q", actual)
 	}
 }
+
+func TestDiffSpecs(t *testing.T) {
+	oldSpec := csmv1.ContainerStorageModuleSpec{
+		Driver: csmv1.Driver{
+			CSIDriverType: csmv1.PowerStore,
+			Replicas:      2,
+			Common:        &csmv1.ContainerTemplate{Image: "quay.io/dell/container-storage-modules/csi-powerstore:v2.16.0"},
+		},
+		Modules: []csmv1.Module{{Name: csmv1.Resiliency, Enabled: true}},
+	}
+	newSpec := *oldSpec.DeepCopy()
+	newSpec.Driver.Common.Image = "quay.io/dell/container-storage-modules/csi-powerstore:v2.99.0"
+	newSpec.Driver.Replicas = 1
+	newSpec.Modules = nil
+
+	diff, err := DiffSpecs(oldSpec, newSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`spec.driver.common.image: "quay.io/dell/container-storage-modules/csi-powerstore:v2.16.0" -> "quay.io/dell/container-storage-modules/csi-powerstore:v2.99.0"`,
+		"spec.driver.replicas: 2 -> 1",
+		"spec.modules[0].enabled: true -> <unset>",
+		`spec.modules[0].name: "resiliency" -> <unset>`,
+	}, diff)
+
+	diff, err = DiffSpecs(oldSpec, oldSpec)
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+
+	// the values of environment variables are not disclosed
+	oldSpec.Driver.Common.Envs = []corev1.EnvVar{{Name: "X_CSI_PASSWORD", Value: "old-secret"}}
+	newSpec = *oldSpec.DeepCopy()
+	newSpec.Driver.Common.Envs[0].Value = "new-secret"
+	newSpec.Driver.Common.Envs = append(newSpec.Driver.Common.Envs, corev1.EnvVar{Name: "X_CSI_TOKEN", Value: "token"})
+	diff, err = DiffSpecs(oldSpec, newSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"spec.driver.common.envs[0].value: <redacted> -> <redacted>",
+		`spec.driver.common.envs[1].name: <unset> -> "X_CSI_TOKEN"`,
+		"spec.driver.common.envs[1].value: <unset> -> <redacted>",
+	}, diff)
+}
@@ -0,0 +1,205 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package adoption
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/dell/csm-operator/pkg/logger"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ManagedByLabel - label of the tool that manages an object
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// HelmManagedBy - value of the managed-by label on the objects installed by Helm
+	HelmManagedBy = "Helm"
+	// HelmChartLabel - label of the chart on the objects installed by Helm
+	HelmChartLabel = "helm.sh/chart"
+	// HelmReleaseNameAnnotation - annotation of the release on the objects installed by Helm
+	HelmReleaseNameAnnotation = "meta.helm.sh/release-name"
+	// HelmReleaseNamespaceAnnotation - annotation of the release namespace on the objects installed by Helm
+	HelmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+	// HelmFieldManager - field manager of the changes made by Helm
+	HelmFieldManager = "helm"
+	// ApplyFieldManager - field manager of the server side applies of the driver workloads
+	ApplyFieldManager = "application/apply-patch"
+	// AdoptedFromAnnotation - annotation recording the Helm release an object was adopted from
+	AdoptedFromAnnotation = "storage.dell.com/adopted-from"
+	// HelmReleaseOwnerLabel - label of the tool owning the secrets Helm stores the release revisions in
+	HelmReleaseOwnerLabel = "owner"
+	// HelmReleaseNameLabel - label of the release on the secrets Helm stores the release revisions in
+	HelmReleaseNameLabel = "name"
+	// HelmReleaseOwner - value of the owner label on the release secrets of Helm
+	HelmReleaseOwner = "helm"
+	// RetiredHelmReleaseOwner - value of the owner label on the release secrets of an adopted release
+	RetiredHelmReleaseOwner = "dell-csm-operator"
+)
+
+// Target - an object synced for a CSM, and the pod selector it must already have if it is a workload
+type Target struct {
+	Kind     string
+	Object   client.Object
+	Selector map[string]string
+}
+
+// IsHelmManaged - returns true if the object was installed by Helm
+func IsHelmManaged(obj metav1.Object) bool {
+	return obj.GetLabels()[ManagedByLabel] == HelmManagedBy || obj.GetAnnotations()[HelmReleaseNameAnnotation] != ""
+}
+
+// AdoptHelmObjects - takes over the Helm-managed targets in place, and returns how many were adopted
+// Objects that do not exist or are not managed by Helm are left alone. The records of the Helm releases the objects
+// belong to are retired first, so that a later helm uninstall does not delete the adopted objects.
+func AdoptHelmObjects(ctx context.Context, targets []Target, ownerLabels map[string]string, ctrlClient client.Client) (int, error) {
+	log := logger.GetLogger(ctx)
+
+	var found []Target
+	releases := map[types.NamespacedName]bool{}
+	for _, t := range targets {
+		obj := t.Object
+		err := ctrlClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return 0, fmt.Errorf("getting %s %s: %v", t.Kind, obj.GetName(), err)
+		}
+		if !IsHelmManaged(obj) {
+			continue
+		}
+
+		// a selector is immutable, a different one would need the workload to be recreated
+		if err := checkSelector(t, obj); err != nil {
+			return 0, err
+		}
+		release := types.NamespacedName{Name: obj.GetAnnotations()[HelmReleaseNameAnnotation], Namespace: obj.GetAnnotations()[HelmReleaseNamespaceAnnotation]}
+		if release.Name != "" && release.Namespace != "" {
+			releases[release] = true
+		}
+		found = append(found, t)
+	}
+
+	for release := range releases {
+		if err := retireHelmRelease(ctx, release, ctrlClient); err != nil {
+			return 0, err
+		}
+	}
+
+	adopted := 0
+	for _, t := range found {
+		obj := t.Object
+		release := obj.GetAnnotations()[HelmReleaseNameAnnotation]
+		stripHelmMetadata(obj)
+
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		for k, v := range ownerLabels {
+			labels[k] = v
+		}
+		obj.SetLabels(labels)
+
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[AdoptedFromAnnotation] = release
+		obj.SetAnnotations(annotations)
+
+		// hand the fields Helm set over to the server side apply of the operator, so that it updates them without conflicts
+		obj.SetManagedFields(transferManagedFields(obj.GetManagedFields()))
+
+		log.Infow("Adopting Helm-managed object", "kind", t.Kind, "name", obj.GetName(), "namespace", obj.GetNamespace(), "release", release)
+		if err := ctrlClient.Update(ctx, obj); err != nil {
+			return adopted, fmt.Errorf("adopting %s %s: %v", t.Kind, obj.GetName(), err)
+		}
+		adopted++
+	}
+	return adopted, nil
+}
+
+// retireHelmRelease - relabels the secrets Helm stores the revisions of the release in, so that Helm no longer finds
+// the release. The revisions are kept, restoring the owner label hands the release back to Helm.
+func retireHelmRelease(ctx context.Context, release types.NamespacedName, ctrlClient client.Client) error {
+	secrets := &corev1.SecretList{}
+	err := ctrlClient.List(ctx, secrets, client.InNamespace(release.Namespace),
+		client.MatchingLabels{HelmReleaseOwnerLabel: HelmReleaseOwner, HelmReleaseNameLabel: release.Name})
+	if err != nil {
+		return fmt.Errorf("listing the records of Helm release %s: %v", release.Name, err)
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		logger.GetLogger(ctx).Infow("Retiring Helm release record", "name", secret.Name, "namespace", secret.Namespace, "release", release.Name)
+		secret.Labels[HelmReleaseOwnerLabel] = RetiredHelmReleaseOwner
+		if err := ctrlClient.Update(ctx, secret); err != nil {
+			return fmt.Errorf("retiring the record %s of Helm release %s: %v", secret.Name, release.Name, err)
+		}
+	}
+	return nil
+}
+
+func checkSelector(t Target, obj client.Object) error {
+	if t.Selector == nil {
+		return nil
+	}
+
+	var selector *metav1.LabelSelector
+	switch live := obj.(type) {
+	case *appsv1.Deployment:
+		selector = live.Spec.Selector
+	case *appsv1.DaemonSet:
+		selector = live.Spec.Selector
+	default:
+		return nil
+	}
+
+	if selector == nil || !reflect.DeepEqual(selector.MatchLabels, t.Selector) || len(selector.MatchExpressions) > 0 {
+		return fmt.Errorf("the selector of %s %s does not match %v, it cannot be adopted in place: name the ContainerStorageModule after the Helm release", t.Kind, obj.GetName(), t.Selector)
+	}
+	return nil
+}
+
+func stripHelmMetadata(obj client.Object) {
+	labels := obj.GetLabels()
+	delete(labels, ManagedByLabel)
+	delete(labels, HelmChartLabel)
+	obj.SetLabels(labels)
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, HelmReleaseNameAnnotation)
+	delete(annotations, HelmReleaseNamespaceAnnotation)
+	obj.SetAnnotations(annotations)
+}
+
+func transferManagedFields(entries []metav1.ManagedFieldsEntry) []metav1.ManagedFieldsEntry {
+	if len(entries) == 0 {
+		return entries
+	}
+	transferred := make([]metav1.ManagedFieldsEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Manager == HelmFieldManager && e.Subresource == "" {
+			e.Manager = ApplyFieldManager
+			e.Operation = metav1.ManagedFieldsOperationApply
+		}
+		transferred = append(transferred, e)
+	}
+	return transferred
+}
@@ -0,0 +1,162 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package adoption
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var ownerLabels = map[string]string{"csm": "vxflexos", "csmNamespace": "vxflexos"}
+
+func helmMeta(name, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   namespace,
+		Labels:      map[string]string{ManagedByLabel: HelmManagedBy, HelmChartLabel: "csi-vxflexos-2.17.0", "app": name},
+		Annotations: map[string]string{HelmReleaseNameAnnotation: "vxflexos", HelmReleaseNamespaceAnnotation: namespace},
+	}
+}
+
+func TestIsHelmManaged(t *testing.T) {
+	assert.True(t, IsHelmManaged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{ManagedByLabel: HelmManagedBy}}}))
+	assert.True(t, IsHelmManaged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{HelmReleaseNameAnnotation: "vxflexos"}}}))
+	assert.False(t, IsHelmManaged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{ManagedByLabel: "csm-operator"}}}))
+	assert.False(t, IsHelmManaged(&corev1.ConfigMap{}))
+}
+
+func TestAdoptHelmObjects(t *testing.T) {
+	ctx := context.TODO()
+	selector := map[string]string{"name": "vxflexos-controller"}
+
+	t.Run("adopts the Helm objects in place", func(t *testing.T) {
+		dp := &appsv1.Deployment{
+			ObjectMeta: helmMeta("vxflexos-controller", "vxflexos"),
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: selector}},
+		}
+		csiDriver := &storagev1.CSIDriver{ObjectMeta: helmMeta("csi-vxflexos.dellemc.com", "")}
+		notHelm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "vxflexos-config-params", Namespace: "vxflexos", Labels: map[string]string{"app": "other"}}}
+		record := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name: "sh.helm.release.v1.vxflexos.v1", Namespace: "vxflexos",
+			Labels: map[string]string{HelmReleaseOwnerLabel: HelmReleaseOwner, HelmReleaseNameLabel: "vxflexos", "status": "deployed"},
+		}}
+		otherRecord := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name: "sh.helm.release.v1.other.v1", Namespace: "vxflexos",
+			Labels: map[string]string{HelmReleaseOwnerLabel: HelmReleaseOwner, HelmReleaseNameLabel: "other"},
+		}}
+		ctrlClient := fake.NewClientBuilder().WithObjects(dp, csiDriver, notHelm, record, otherRecord).Build()
+
+		targets := []Target{
+			{Kind: "Deployment", Object: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "vxflexos-controller", Namespace: "vxflexos"}}, Selector: selector},
+			{Kind: "CSIDriver", Object: &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "csi-vxflexos.dellemc.com"}}},
+			{Kind: "ConfigMap", Object: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "vxflexos-config-params", Namespace: "vxflexos"}}},
+			{Kind: "DaemonSet", Object: &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "vxflexos-node", Namespace: "vxflexos"}}},
+		}
+		adopted, err := AdoptHelmObjects(ctx, targets, ownerLabels, ctrlClient)
+		require.NoError(t, err)
+		assert.Equal(t, 2, adopted)
+
+		gotDp := &appsv1.Deployment{}
+		require.NoError(t, ctrlClient.Get(ctx, client.ObjectKeyFromObject(dp), gotDp))
+		assert.False(t, IsHelmManaged(gotDp))
+		assert.NotContains(t, gotDp.Labels, HelmChartLabel)
+		assert.Equal(t, "vxflexos-controller", gotDp.Labels["app"])
+		assert.Equal(t, "vxflexos", gotDp.Labels["csm"])
+		assert.Equal(t, "vxflexos", gotDp.Annotations[AdoptedFromAnnotation])
+		assert.NotContains(t, gotDp.Annotations, HelmReleaseNamespaceAnnotation)
+
+		gotDriver := &storagev1.CSIDriver{}
+		require.NoError(t, ctrlClient.Get(ctx, client.ObjectKeyFromObject(csiDriver), gotDriver))
+		assert.False(t, IsHelmManaged(gotDriver))
+
+		gotCm := &corev1.ConfigMap{}
+		require.NoError(t, ctrlClient.Get(ctx, client.ObjectKeyFromObject(notHelm), gotCm))
+		assert.NotContains(t, gotCm.Labels, "csm")
+
+		// helm no longer finds the release, so uninstalling it does not delete the adopted objects
+		gotRecord := &corev1.Secret{}
+		require.NoError(t, ctrlClient.Get(ctx, client.ObjectKeyFromObject(record), gotRecord))
+		assert.Equal(t, RetiredHelmReleaseOwner, gotRecord.Labels[HelmReleaseOwnerLabel])
+		assert.Equal(t, "deployed", gotRecord.Labels["status"])
+		require.NoError(t, ctrlClient.Get(ctx, client.ObjectKeyFromObject(otherRecord), gotRecord))
+		assert.Equal(t, HelmReleaseOwner, gotRecord.Labels[HelmReleaseOwnerLabel])
+	})
+
+	t.Run("refuses a workload with another selector", func(t *testing.T) {
+		ds := &appsv1.DaemonSet{
+			ObjectMeta: helmMeta("vxflexos-node", "vxflexos"),
+			Spec:       appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "powerflex-node"}}},
+		}
+		ctrlClient := fake.NewClientBuilder().WithObjects(ds).Build()
+
+		targets := []Target{{Kind: "DaemonSet", Object: &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "vxflexos-node", Namespace: "vxflexos"}}, Selector: map[string]string{"app": "vxflexos-node"}}}
+		adopted, err := AdoptHelmObjects(ctx, targets, ownerLabels, ctrlClient)
+		assert.ErrorContains(t, err, "cannot be adopted in place")
+		assert.Equal(t, 0, adopted)
+
+		// the daemonset is left as it is
+		got := &appsv1.DaemonSet{}
+		require.NoError(t, ctrlClient.Get(ctx, client.ObjectKeyFromObject(ds), got))
+		assert.True(t, IsHelmManaged(got))
+	})
+
+	t.Run("returns the errors of the client", func(t *testing.T) {
+		cm := &corev1.ConfigMap{ObjectMeta: helmMeta("vxflexos-config-params", "vxflexos")}
+		targets := func() []Target {
+			return []Target{{Kind: "ConfigMap", Object: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "vxflexos-config-params", Namespace: "vxflexos"}}}}
+		}
+
+		getErr := fake.NewClientBuilder().WithObjects(cm).WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(_ context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+				return errors.New("get failed")
+			},
+		}).Build()
+		_, err := AdoptHelmObjects(ctx, targets(), ownerLabels, getErr)
+		assert.ErrorContains(t, err, "get failed")
+
+		updateErr := fake.NewClientBuilder().WithObjects(cm).WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(_ context.Context, _ client.WithWatch, _ client.Object, _ ...client.UpdateOption) error {
+				return errors.New("update failed")
+			},
+		}).Build()
+		_, err = AdoptHelmObjects(ctx, targets(), ownerLabels, updateErr)
+		assert.ErrorContains(t, err, "adopting ConfigMap vxflexos-config-params: update failed")
+	})
+}
+
+func TestTransferManagedFields(t *testing.T) {
+	entries := []metav1.ManagedFieldsEntry{
+		{Manager: HelmFieldManager, Operation: metav1.ManagedFieldsOperationUpdate},
+		{Manager: HelmFieldManager, Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status"},
+		{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate},
+	}
+	got := transferManagedFields(entries)
+	require.Len(t, got, 3)
+	assert.Equal(t, ApplyFieldManager, got[0].Manager)
+	assert.Equal(t, metav1.ManagedFieldsOperationApply, got[0].Operation)
+	assert.Equal(t, HelmFieldManager, got[1].Manager)
+	assert.Equal(t, "kube-controller-manager", got[2].Manager)
+
+	assert.Empty(t, transferManagedFields(nil))
+}
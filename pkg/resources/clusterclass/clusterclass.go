@@ -0,0 +1,226 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package clusterclass
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dell/csm-operator/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Kind - describes a kind of cluster scoped class and the objects referencing its classes
+type Kind struct {
+	// ClassGVK - group version kind of the class
+	ClassGVK schema.GroupVersionKind
+
+	// UserListGVKs - group version kinds of the lists of objects that may reference a class
+	UserListGVKs []schema.GroupVersionKind
+
+	// ReferenceField - path of the field holding the class name in those objects
+	ReferenceField []string
+
+	// InUsePhase - if set, only the objects in this status phase count as referencing a class
+	InUsePhase string
+
+	// ImmutableFields - paths of the fields the API server refuses to update, a class changing them is recreated
+	ImmutableFields [][]string
+}
+
+var (
+	// StorageClass - the StorageClass objects, referenced by bound PVCs
+	StorageClass = Kind{
+		ClassGVK:       schema.GroupVersionKind{Group: "storage.k8s.io", Version: "v1", Kind: "StorageClass"},
+		UserListGVKs:   []schema.GroupVersionKind{{Version: "v1", Kind: "PersistentVolumeClaimList"}},
+		ReferenceField: []string{"spec", "storageClassName"},
+		InUsePhase:     string(corev1.ClaimBound),
+		ImmutableFields: [][]string{
+			{"provisioner"},
+			{"parameters"},
+			{"reclaimPolicy"},
+			{"volumeBindingMode"},
+		},
+	}
+
+	// VolumeSnapshotClass - the VolumeSnapshotClass objects, referenced by snapshots and their contents
+	VolumeSnapshotClass = Kind{
+		ClassGVK: schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshotClass"},
+		UserListGVKs: []schema.GroupVersionKind{
+			{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshotList"},
+			{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshotContentList"},
+		},
+		ReferenceField: []string{"spec", "volumeSnapshotClassName"},
+	}
+
+	// VolumeGroupSnapshotClass - the VolumeGroupSnapshotClass objects, referenced by group snapshots and their contents
+	VolumeGroupSnapshotClass = Kind{
+		ClassGVK: schema.GroupVersionKind{Group: "groupsnapshot.storage.k8s.io", Version: "v1beta1", Kind: "VolumeGroupSnapshotClass"},
+		UserListGVKs: []schema.GroupVersionKind{
+			{Group: "groupsnapshot.storage.k8s.io", Version: "v1beta1", Kind: "VolumeGroupSnapshotList"},
+			{Group: "groupsnapshot.storage.k8s.io", Version: "v1beta1", Kind: "VolumeGroupSnapshotContentList"},
+		},
+		ReferenceField: []string{"spec", "volumeGroupSnapshotClassName"},
+	}
+)
+
+// Sync - Syncs the classes of a CSM and prunes the ones of the same kind it no longer declares.
+// A class whose immutable fields changed is recreated, unless objects still reference it.
+func Sync[T client.Object](ctx context.Context, kind Kind, classes []T, ownerLabels map[string]string, ctrlClient client.Client) error {
+	log := logger.GetLogger(ctx)
+	name := strings.ToLower(kind.ClassGVK.Kind)
+
+	keep := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		keep[class.GetName()] = true
+
+		found := &unstructured.Unstructured{}
+		found.SetGroupVersionKind(kind.ClassGVK)
+		err := ctrlClient.Get(ctx, types.NamespacedName{Name: class.GetName()}, found)
+		if err != nil && errors.IsNotFound(err) {
+			log.Infow("Creating a new "+kind.ClassGVK.Kind, "Name:", class.GetName())
+			err = ctrlClient.Create(ctx, class)
+			if err != nil {
+				return fmt.Errorf("creating %s object: %v", name, err)
+			}
+		} else if err != nil {
+			log.Errorw("Unknown error.", "Error", err.Error())
+			return err
+		} else if !labels.SelectorFromSet(ownerLabels).Matches(labels.Set(found.GetLabels())) {
+			// a class of the same name created outside of this CSM is not taken over
+			return fmt.Errorf("%s %s already exists and is not managed by this CSM", name, class.GetName())
+		} else if changed, err := immutableFieldsChanged(kind, found, class); err != nil {
+			return err
+		} else if changed {
+			inUse, err := isInUse(ctx, kind, class.GetName(), ctrlClient)
+			if err != nil {
+				return err
+			}
+			if inUse {
+				return fmt.Errorf("%s %s is still referenced and cannot be recreated with the new parameters", name, class.GetName())
+			}
+
+			log.Infow("Recreating "+kind.ClassGVK.Kind+" Object", "Name:", class.GetName())
+			if err = ctrlClient.Delete(ctx, found); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("deleting %s object: %v", name, err)
+			}
+			if err = ctrlClient.Create(ctx, class); err != nil {
+				return fmt.Errorf("creating %s object: %v", name, err)
+			}
+		} else {
+			log.Infow("Updating existing "+kind.ClassGVK.Kind+" Object", "Name:", class.GetName())
+
+			class.SetResourceVersion(found.GetResourceVersion())
+			err = ctrlClient.Update(ctx, class)
+			if err != nil {
+				return fmt.Errorf("updating %s object: %v", name, err)
+			}
+		}
+	}
+
+	return prune(ctx, kind, keep, ownerLabels, ctrlClient)
+}
+
+// Delete - Deletes the classes of a CSM that are not referenced by any object
+func Delete(ctx context.Context, kind Kind, ownerLabels map[string]string, ctrlClient client.Client) error {
+	return prune(ctx, kind, map[string]bool{}, ownerLabels, ctrlClient)
+}
+
+// immutableFieldsChanged - checks if the immutable fields of the kind differ between the existing and the desired class
+func immutableFieldsChanged(kind Kind, found *unstructured.Unstructured, class client.Object) (bool, error) {
+	if len(kind.ImmutableFields) == 0 {
+		return false, nil
+	}
+	desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(class)
+	if err != nil {
+		return false, fmt.Errorf("converting %s %s: %v", kind.ClassGVK.Kind, class.GetName(), err)
+	}
+	for _, field := range kind.ImmutableFields {
+		foundValue, _, _ := unstructured.NestedFieldNoCopy(found.Object, field...)
+		desiredValue, _, _ := unstructured.NestedFieldNoCopy(desired, field...)
+		if !reflect.DeepEqual(foundValue, desiredValue) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// prune - deletes the classes labelled with ownerLabels that are not in keep, unless objects still reference them
+func prune(ctx context.Context, kind Kind, keep map[string]bool, ownerLabels map[string]string, ctrlClient client.Client) error {
+	log := logger.GetLogger(ctx)
+	name := strings.ToLower(kind.ClassGVK.Kind)
+
+	existing := &unstructured.UnstructuredList{}
+	existing.SetGroupVersionKind(kind.ClassGVK.GroupVersion().WithKind(kind.ClassGVK.Kind + "List"))
+	err := ctrlClient.List(ctx, existing, client.MatchingLabels(ownerLabels))
+	if meta.IsNoMatchError(err) {
+		// the CRD of the class is not installed, so there is nothing to prune
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("listing %s objects: %v", name, err)
+	}
+
+	for i := range existing.Items {
+		class := &existing.Items[i]
+		if keep[class.GetName()] {
+			continue
+		}
+
+		inUse, err := isInUse(ctx, kind, class.GetName(), ctrlClient)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			log.Infow(kind.ClassGVK.Kind+" is still referenced, not deleting", "Name:", class.GetName())
+			continue
+		}
+
+		log.Infow("Deleting "+kind.ClassGVK.Kind, "Name:", class.GetName())
+		if err := ctrlClient.Delete(ctx, class); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting %s object: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// isInUse - checks if any object of the user kinds references the class
+func isInUse(ctx context.Context, kind Kind, name string, ctrlClient client.Client) (bool, error) {
+	for _, gvk := range kind.UserListGVKs {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		if err := ctrlClient.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return false, fmt.Errorf("listing %s objects: %v", gvk.Kind, err)
+		}
+		for _, item := range list.Items {
+			className, _, _ := unstructured.NestedString(item.Object, kind.ReferenceField...)
+			phase, _, _ := unstructured.NestedString(item.Object, "status", "phase")
+			if className == name && (kind.InUsePhase == "" || phase == kind.InUsePhase) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,178 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package clusterclass
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var ownerLabels = map[string]string{"csm": "powerstore", "csmNamespace": "powerstore"}
+
+func newClass(kind Kind, name string, labels map[string]string, parameters map[string]interface{}) *unstructured.Unstructured {
+	class := &unstructured.Unstructured{}
+	class.SetGroupVersionKind(kind.ClassGVK)
+	class.SetName(name)
+	class.SetLabels(labels)
+	class.Object["driver"] = "csi-powerstore.dellemc.com"
+	if parameters != nil {
+		class.Object["parameters"] = parameters
+	}
+	return class
+}
+
+// newUser - returns an object of the first user kind referencing the class
+func newUser(kind Kind, className string) *unstructured.Unstructured {
+	listGVK := kind.UserListGVKs[0]
+	user := &unstructured.Unstructured{}
+	user.SetGroupVersionKind(schema.GroupVersionKind{Group: listGVK.Group, Version: listGVK.Version, Kind: listGVK.Kind[:len(listGVK.Kind)-len("List")]})
+	user.SetName("user")
+	user.SetNamespace("default")
+	_ = unstructured.SetNestedField(user.Object, className, kind.ReferenceField...)
+	if kind.InUsePhase != "" {
+		_ = unstructured.SetNestedField(user.Object, kind.InUsePhase, "status", "phase")
+	}
+	return user
+}
+
+func getClass(ctx context.Context, c client.Client, kind Kind, name string) (*unstructured.Unstructured, error) {
+	class := &unstructured.Unstructured{}
+	class.SetGroupVersionKind(kind.ClassGVK)
+	return class, c.Get(ctx, types.NamespacedName{Name: name}, class)
+}
+
+func TestSyncAndDelete(t *testing.T) {
+	ctx := context.TODO()
+
+	tests := map[string]Kind{
+		"StorageClass":             StorageClass,
+		"VolumeSnapshotClass":      VolumeSnapshotClass,
+		"VolumeGroupSnapshotClass": VolumeGroupSnapshotClass,
+	}
+	for name, kind := range tests {
+		t.Run(name+" create and update classes", func(t *testing.T) {
+			existing := newClass(kind, "updated", ownerLabels, map[string]interface{}{"FsType": "ext4"})
+			ctrlClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()
+
+			classes := []*unstructured.Unstructured{
+				newClass(kind, "created", ownerLabels, nil),
+				newClass(kind, "updated", ownerLabels, map[string]interface{}{"FsType": "xfs"}),
+			}
+			assert.NoError(t, Sync(ctx, kind, classes, ownerLabels, ctrlClient))
+
+			_, err := getClass(ctx, ctrlClient, kind, "created")
+			assert.NoError(t, err)
+			updated, err := getClass(ctx, ctrlClient, kind, "updated")
+			assert.NoError(t, err)
+			fsType, _, _ := unstructured.NestedString(updated.Object, "parameters", "FsType")
+			assert.Equal(t, "xfs", fsType)
+		})
+
+		t.Run(name+" existing class not managed by the csm", func(t *testing.T) {
+			existing := newClass(kind, "user", nil, map[string]interface{}{"FsType": "ext4"})
+			ctrlClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()
+
+			classes := []*unstructured.Unstructured{newClass(kind, "user", ownerLabels, map[string]interface{}{"FsType": "xfs"})}
+			assert.ErrorContains(t, Sync(ctx, kind, classes, ownerLabels, ctrlClient), "not managed by this CSM")
+
+			found, err := getClass(ctx, ctrlClient, kind, "user")
+			assert.NoError(t, err)
+			fsType, _, _ := unstructured.NestedString(found.Object, "parameters", "FsType")
+			assert.Equal(t, "ext4", fsType)
+			assert.Empty(t, found.GetLabels())
+		})
+
+		t.Run(name+" prune removed classes unless in use", func(t *testing.T) {
+			ctrlClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+				newClass(kind, "removed", ownerLabels, nil),
+				newClass(kind, "in-use", ownerLabels, nil),
+				newClass(kind, "other-csm", map[string]string{"csm": "other"}, nil),
+				newUser(kind, "in-use"),
+			).Build()
+
+			assert.NoError(t, Sync[*unstructured.Unstructured](ctx, kind, nil, ownerLabels, ctrlClient))
+
+			_, err := getClass(ctx, ctrlClient, kind, "removed")
+			assert.True(t, apierrors.IsNotFound(err))
+			_, err = getClass(ctx, ctrlClient, kind, "in-use")
+			assert.NoError(t, err)
+			_, err = getClass(ctx, ctrlClient, kind, "other-csm")
+			assert.NoError(t, err)
+		})
+
+		t.Run(name+" delete", func(t *testing.T) {
+			ctrlClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(newClass(kind, "class", ownerLabels, nil)).Build()
+
+			assert.NoError(t, Delete(ctx, kind, ownerLabels, ctrlClient))
+
+			_, err := getClass(ctx, ctrlClient, kind, "class")
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestSyncRecreate(t *testing.T) {
+	ctx := context.TODO()
+	newStorageClass := func(parameters map[string]string) *storagev1.StorageClass {
+		reclaimPolicy := corev1.PersistentVolumeReclaimDelete
+		return &storagev1.StorageClass{
+			ObjectMeta:    metav1.ObjectMeta{Name: "class", Labels: ownerLabels},
+			Provisioner:   "csi-vxflexos.dellemc.com",
+			Parameters:    parameters,
+			ReclaimPolicy: &reclaimPolicy,
+		}
+	}
+	pendingPVC := newUser(StorageClass, "class")
+	_ = unstructured.SetNestedField(pendingPVC.Object, string(corev1.ClaimPending), "status", "phase")
+
+	tests := []struct {
+		name    string
+		users   []client.Object
+		wantErr string
+		want    string
+	}{
+		{name: "recreate class with changed parameters", want: "pool2"},
+		{name: "recreate class only used by pending PVCs", users: []client.Object{pendingPVC}, want: "pool2"},
+		{name: "refuse to recreate class used by bound PVCs", users: []client.Object{newUser(StorageClass, "class")}, wantErr: "still referenced", want: "pool1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := append([]client.Object{newStorageClass(map[string]string{"storagepool": "pool1"})}, tt.users...)
+			ctrlClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+
+			classes := []*storagev1.StorageClass{newStorageClass(map[string]string{"storagepool": "pool2"})}
+			err := Sync(ctx, StorageClass, classes, ownerLabels, ctrlClient)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			found := &storagev1.StorageClass{}
+			assert.NoError(t, ctrlClient.Get(ctx, types.NamespacedName{Name: "class"}, found))
+			assert.Equal(t, tt.want, found.Parameters["storagepool"])
+		})
+	}
+}
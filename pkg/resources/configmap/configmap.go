@@ -19,6 +19,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/dell/csm-operator/pkg/logger"
+	"github.com/dell/csm-operator/pkg/resources/contenthash"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,8 +29,14 @@ import (
 func SyncConfigMap(ctx context.Context, configMap corev1.ConfigMap, client client.Client) error {
 	log := logger.GetLogger(ctx)
 
+	hash, err := contenthash.Compute(configMap)
+	if err != nil {
+		return err
+	}
+	contenthash.Set(&configMap, hash)
+
 	found := &corev1.ConfigMap{}
-	err := client.Get(ctx, types.NamespacedName{Name: configMap.Name, Namespace: configMap.Namespace}, found)
+	err = client.Get(ctx, types.NamespacedName{Name: configMap.Name, Namespace: configMap.Namespace}, found)
 	if err != nil && errors.IsNotFound(err) {
 		log.Infow("Creating a new ConfigMap", "Name", configMap.Name)
 		err = client.Create(ctx, &configMap)
@@ -39,6 +46,9 @@ func SyncConfigMap(ctx context.Context, configMap corev1.ConfigMap, client clien
 	} else if err != nil {
 		log.Errorw("Unknown error.", "Error", err.Error())
 		return err
+	} else if contenthash.Unchanged(found, hash) {
+		log.Infow("ConfigMap is unchanged", "Name:", configMap.Name)
+		return nil
 	} else {
 		log.Infow("Updating ConfigMap", "Name:", configMap.Name)
 
@@ -48,6 +58,7 @@ func SyncConfigMap(ctx context.Context, configMap corev1.ConfigMap, client clien
 			return fmt.Errorf("updating configmap: %v", err)
 		}
 	}
+	contenthash.Record(&configMap, hash)
 
 	return nil
 }
@@ -17,6 +17,7 @@ import (
 	"errors"
 	"testing"
 
+	csmv1 "github.com/dell/csm-operator/api/v1"
 	common "github.com/dell/csm-operator/pkg/operatorutils"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +27,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 func TestSyncConfigMap(t *testing.T) {
@@ -71,6 +73,37 @@ func TestSyncConfigMap(t *testing.T) {
 		assert.Equal(t, updatedConfigMap.Data, foundConfigMap.Data)
 	})
 
+	t.Run("Skip unchanged ConfigMap", func(t *testing.T) {
+		configMap := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "unchanged-configmap", Namespace: "test-namespace"},
+			Data:       map[string]string{"key": "value"},
+		}
+		// the operator records the written revision by UID, which the API server keeps on updates unlike the fake client
+		live := configMap.DeepCopy()
+		live.UID = "unchanged-configmap-uid"
+		client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(live).WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				obj.SetUID(live.UID)
+				return c.Update(ctx, obj, opts...)
+			},
+		}).Build()
+
+		err := SyncConfigMap(ctx, configMap, client)
+		assert.NoError(t, err)
+		created := &corev1.ConfigMap{}
+		err = client.Get(ctx, types.NamespacedName{Name: configMap.Name, Namespace: configMap.Namespace}, created)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created.Annotations[csmv1.ContentHashAnnotation])
+		assert.Empty(t, configMap.Annotations)
+
+		err = SyncConfigMap(ctx, configMap, client)
+		assert.NoError(t, err)
+		found := &corev1.ConfigMap{}
+		err = client.Get(ctx, types.NamespacedName{Name: configMap.Name, Namespace: configMap.Namespace}, found)
+		assert.NoError(t, err)
+		assert.Equal(t, created.ResourceVersion, found.ResourceVersion)
+	})
+
 	t.Run("Handle error on getting ConfigMap", func(t *testing.T) {
 		client := &common.MockClient{
 			GetFunc: func(_ context.Context, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
@@ -0,0 +1,133 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package contenthash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// written - holds the revision of each object right after the operator wrote it, by UID. The entries are dropped when
+// the operator deletes the object, or when the CSM that owns it is deleted
+var written sync.Map
+
+// record - the hash an object was written with, the revision it got, and the UID of its owner
+type record struct {
+	hash     string
+	revision string
+	owner    types.UID
+}
+
+// Compute - returns the hash of a rendered object or apply configuration, without its content hash annotation
+func Compute(obj any) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("marshalling the object to hash: %v", err)
+	}
+	content := map[string]any{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return "", fmt.Errorf("unmarshalling the object to hash: %v", err)
+	}
+	if metadata, ok := content["metadata"].(map[string]any); ok {
+		if annotations, ok := metadata["annotations"].(map[string]any); ok {
+			delete(annotations, csmv1.ContentHashAnnotation)
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+		// set by the API server
+		for _, key := range []string{"resourceVersion", "generation", "uid", "creationTimestamp", "managedFields"} {
+			delete(metadata, key)
+		}
+	}
+	data, err = json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("marshalling the object to hash: %v", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Set - sets the content hash annotation of a rendered object, without changing the annotations it shares with its template
+func Set(obj metav1.Object, hash string) {
+	annotations := maps.Clone(obj.GetAnnotations())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[csmv1.ContentHashAnnotation] = hash
+	obj.SetAnnotations(annotations)
+}
+
+// Record - records the revision of an object the operator wrote with the hash
+func Record(obj metav1.Object, hash string) {
+	if obj.GetUID() == "" {
+		return
+	}
+	written.Store(obj.GetUID(), record{hash: hash, revision: revision(obj), owner: ownerUID(obj)})
+}
+
+// Forget - drops the record of an object the operator deleted
+func Forget(obj metav1.Object) {
+	if obj.GetUID() != "" {
+		written.Delete(obj.GetUID())
+	}
+}
+
+// ForgetOwned - drops the records of the objects owned by a deleted CSM, which are garbage collected
+func ForgetOwned(owner types.UID) {
+	if owner == "" {
+		return
+	}
+	written.Range(func(uid, last any) bool {
+		if last.(record).owner == owner {
+			written.Delete(uid)
+		}
+		return true
+	})
+}
+
+// Unchanged - returns whether the live object holds the hash and has not been changed since the operator wrote it,
+// in which case writing the rendered object again can be skipped
+func Unchanged(live metav1.Object, hash string) bool {
+	if live.GetAnnotations()[csmv1.ContentHashAnnotation] != hash {
+		return false
+	}
+	last, ok := written.Load(live.GetUID())
+	return ok && last.(record).hash == hash && last.(record).revision == revision(live)
+}
+
+// ownerUID - returns the UID of the controller of an object, the CSM for the objects it owns, or ""
+func ownerUID(obj metav1.Object) types.UID {
+	if owner := metav1.GetControllerOfNoCopy(obj); owner != nil {
+		return owner.UID
+	}
+	return ""
+}
+
+// revision - returns the generation of the object, or its resource version for kinds without a generation.
+// The generation does not change with the status, but does with any change of the spec
+func revision(obj metav1.Object) string {
+	if obj.GetGeneration() > 0 {
+		return strconv.FormatInt(obj.GetGeneration(), 10)
+	}
+	return obj.GetResourceVersion()
+}
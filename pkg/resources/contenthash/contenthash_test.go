@@ -0,0 +1,146 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package contenthash
+
+import (
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appsv1 "k8s.io/client-go/applyconfigurations/apps/v1"
+)
+
+func TestCompute(t *testing.T) {
+	configMap := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test-namespace", Annotations: map[string]string{"a": "b"}},
+		Data:       map[string]string{"key": "value"},
+	}
+	hash, err := Compute(configMap)
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	t.Run("ignores the hash annotation and the fields set by the API server", func(t *testing.T) {
+		live := configMap.DeepCopy()
+		live.Annotations[csmv1.ContentHashAnnotation] = hash
+		live.UID = "uid"
+		live.ResourceVersion = "42"
+		live.Generation = 3
+		live.CreationTimestamp = metav1.Now()
+		liveHash, err := Compute(live)
+		require.NoError(t, err)
+		assert.Equal(t, hash, liveHash)
+	})
+
+	t.Run("changes with the content", func(t *testing.T) {
+		changed := configMap.DeepCopy()
+		changed.Data["key"] = "new-value"
+		changedHash, err := Compute(changed)
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, changedHash)
+	})
+
+	t.Run("apply configuration", func(t *testing.T) {
+		deployment := appsv1.Deployment("test-deployment", "test-namespace").WithLabels(map[string]string{"csm": "test"})
+		first, err := Compute(deployment)
+		require.NoError(t, err)
+		deployment.WithAnnotations(map[string]string{csmv1.ContentHashAnnotation: first})
+		second, err := Compute(deployment)
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("unsupported object", func(t *testing.T) {
+		_, err := Compute(func() {})
+		assert.Error(t, err)
+	})
+}
+
+func TestSet(t *testing.T) {
+	template := map[string]string{"a": "b"}
+	configMap := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: template}}
+	Set(&configMap, "hash")
+	assert.Equal(t, map[string]string{"a": "b", csmv1.ContentHashAnnotation: "hash"}, configMap.Annotations)
+	assert.Equal(t, map[string]string{"a": "b"}, template)
+
+	empty := corev1.ConfigMap{}
+	Set(&empty, "hash")
+	assert.Equal(t, "hash", empty.Annotations[csmv1.ContentHashAnnotation])
+}
+
+func TestUnchanged(t *testing.T) {
+	live := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		UID:             "configmap-uid",
+		ResourceVersion: "7",
+		Annotations:     map[string]string{csmv1.ContentHashAnnotation: "hash"},
+	}}
+	assert.False(t, Unchanged(live, "hash"), "not written by the operator")
+
+	Record(live, "hash")
+	assert.True(t, Unchanged(live, "hash"))
+	assert.False(t, Unchanged(live, "other"), "rendered object changed")
+
+	live.ResourceVersion = "8"
+	assert.False(t, Unchanged(live, "hash"), "live object changed")
+
+	t.Run("generation", func(t *testing.T) {
+		live := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			UID:             "pod-uid",
+			ResourceVersion: "7",
+			Generation:      2,
+			Annotations:     map[string]string{csmv1.ContentHashAnnotation: "hash"},
+		}}
+		Record(live, "hash")
+		live.ResourceVersion = "9"
+		assert.True(t, Unchanged(live, "hash"), "status changed")
+		live.Generation = 3
+		assert.False(t, Unchanged(live, "hash"), "spec changed")
+	})
+
+	t.Run("without UID", func(t *testing.T) {
+		live := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{csmv1.ContentHashAnnotation: "hash"}}}
+		Record(live, "hash")
+		assert.False(t, Unchanged(live, "hash"))
+	})
+}
+
+func TestForget(t *testing.T) {
+	controller := true
+	owner := metav1.OwnerReference{Kind: "ContainerStorageModule", Name: "csm", UID: "csm-uid", Controller: &controller}
+	owned := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		UID:             "owned-uid",
+		ResourceVersion: "1",
+		OwnerReferences: []metav1.OwnerReference{owner},
+		Annotations:     map[string]string{csmv1.ContentHashAnnotation: "hash"},
+	}}
+	other := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		UID:             "other-uid",
+		ResourceVersion: "1",
+		Annotations:     map[string]string{csmv1.ContentHashAnnotation: "hash"},
+	}}
+	Record(owned, "hash")
+	Record(other, "hash")
+
+	// the objects of a deleted CSM are dropped
+	ForgetOwned("csm-uid")
+	assert.False(t, Unchanged(owned, "hash"))
+	assert.True(t, Unchanged(other, "hash"))
+
+	// and so is a deleted object
+	Forget(other)
+	assert.False(t, Unchanged(other, "hash"))
+	_, ok := written.Load(other.GetUID())
+	assert.False(t, ok)
+}
@@ -19,6 +19,7 @@ import (
 	storagev1 "k8s.io/api/storage/v1"
 
 	"github.com/dell/csm-operator/pkg/logger"
+	"github.com/dell/csm-operator/pkg/resources/contenthash"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,8 +28,14 @@ import (
 // SyncCSIDriver - Syncs a CSI Driver object
 func SyncCSIDriver(ctx context.Context, csi storagev1.CSIDriver, client client.Client) error {
 	log := logger.GetLogger(ctx)
+	hash, err := contenthash.Compute(csi)
+	if err != nil {
+		return err
+	}
+	contenthash.Set(&csi, hash)
+
 	found := &storagev1.CSIDriver{}
-	err := client.Get(ctx, types.NamespacedName{Name: csi.Name}, found)
+	err = client.Get(ctx, types.NamespacedName{Name: csi.Name}, found)
 	if err != nil && errors.IsNotFound(err) {
 		log.Infow("Creating a new CSIDriver", "Name:", csi.Name)
 		err = client.Create(ctx, &csi)
@@ -38,6 +45,9 @@ func SyncCSIDriver(ctx context.Context, csi storagev1.CSIDriver, client client.C
 	} else if err != nil {
 		log.Errorw("Unknown error.", "Error", err.Error())
 		return err
+	} else if contenthash.Unchanged(found, hash) {
+		log.Infow("CSIDriver is unchanged", "Name:", csi.Name)
+		return nil
 	} else {
 		log.Infow("Updating existing CSIDriver Object", "Name:", csi.Name)
 
@@ -48,5 +58,6 @@ func SyncCSIDriver(ctx context.Context, csi storagev1.CSIDriver, client client.C
 			return fmt.Errorf("updating csidriver object: %v", err)
 		}
 	}
+	contenthash.Record(&csi, hash)
 	return nil
 }
@@ -17,7 +17,9 @@ import (
 	"errors"
 	"testing"
 
+	csmv1 "github.com/dell/csm-operator/api/v1"
 	common "github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/dell/csm-operator/pkg/resources/contenthash"
 	"github.com/stretchr/testify/assert"
 	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -64,7 +66,9 @@ func TestSyncCSIDriver(t *testing.T) {
 		foundCSIDriver := &storagev1.CSIDriver{}
 		err = client.Get(ctx, types.NamespacedName{Name: csiDriver.Name}, foundCSIDriver)
 		assert.NoError(t, err)
-		assert.Equal(t, updatedCSIDriver.Annotations, foundCSIDriver.Annotations)
+		hash, err := contenthash.Compute(updatedCSIDriver)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"key": "test-annotation", csmv1.ContentHashAnnotation: hash}, foundCSIDriver.Annotations)
 	})
 
 	t.Run("Handle error on getting CSIDriver", func(t *testing.T) {
@@ -15,7 +15,9 @@ package daemonset
 import (
 	"context"
 
+	csmv1 "github.com/dell/csm-operator/api/v1"
 	"github.com/dell/csm-operator/pkg/logger"
+	"github.com/dell/csm-operator/pkg/resources/contenthash"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	appsv1 "k8s.io/client-go/applyconfigurations/apps/v1"
@@ -48,11 +50,27 @@ func SyncDaemonset(ctx context.Context, daemonset appsv1.DaemonSetApplyConfigura
 		daemonset.Spec.Template.Labels = make(map[string]string)
 	}
 	daemonset.Spec.Template.Labels["csm"] = csmName
+	// the csm labels of the object select it for the cache of the operator
+	daemonset.WithLabels(map[string]string{"csm": csmName})
+	if csmNamespace, ok := daemonset.Spec.Template.Labels["csmNamespace"]; ok {
+		daemonset.WithLabels(map[string]string{"csmNamespace": csmNamespace})
+	}
+
+	hash, err := contenthash.Compute(daemonset)
+	if err != nil {
+		return err
+	}
+	daemonset.WithAnnotations(map[string]string{csmv1.ContentHashAnnotation: hash})
+	if found != nil && found.Name != "" && contenthash.Unchanged(found, hash) {
+		log.Infow("DaemonSet is unchanged", "Name:", found.Name)
+		return nil
+	}
 
-	_, err = daemonsets.Apply(ctx, &daemonset, opts)
+	set, err := daemonsets.Apply(ctx, &daemonset, opts)
 	if err != nil {
 		log.Errorw("Apply DaemonSet error", "set", err.Error())
 		return err
 	}
+	contenthash.Record(set, hash)
 	return nil
 }
@@ -83,6 +83,7 @@ func TestSyncDaemonset(t *testing.T) {
 			},
 		})
 
+		daemonset.Spec.Template.Labels["csmNamespace"] = "csm-namespace"
 		err := SyncDaemonset(ctx, daemonset, k8sClient, "test-csm")
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
@@ -91,6 +92,8 @@ func TestSyncDaemonset(t *testing.T) {
 		if daemonset.Spec.Template.Labels["csm"] != "test-csm" {
 			t.Fatalf("expected label 'csm' to be 'test-csm', got %v", daemonset.Spec.Template.Labels["csm"])
 		}
+		// the object is labeled with the CSM for the cache of the operator
+		assert.Equal(t, map[string]string{"csm": "test-csm", "csmNamespace": "csm-namespace"}, daemonset.Labels)
 	})
 
 	t.Run("Handle error on getting DaemonSet", func(t *testing.T) {
@@ -0,0 +1,346 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package daemonset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/logger"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NodeRolloutStatus - progress of the batched rollout of a node DaemonSet
+type NodeRolloutStatus struct {
+	// UpdatedNodes - number of nodes with a ready node pod of the current revision that is registered in CSINode
+	UpdatedNodes int
+	// TotalNodes - number of nodes running the node DaemonSet
+	TotalNodes int
+	// Pending - nodes of the current batch that are not updated yet
+	Pending []string
+	// InProgress - true while nodes are left to update
+	InProgress bool
+	// Paused - true when the current batch was not updated within the batch timeout
+	Paused bool
+}
+
+// Message - describes the progress of the rollout
+func (s NodeRolloutStatus) Message() string {
+	message := fmt.Sprintf("%d/%d nodes updated", s.UpdatedNodes, s.TotalNodes)
+	if len(s.Pending) > 0 {
+		message += ", waiting for " + strings.Join(s.Pending, ", ")
+	}
+	return message
+}
+
+// nodePod - state of the node pod on one node
+type nodePod struct {
+	pod     *corev1.Pod
+	updated bool
+}
+
+// RolloutNodes - rolls out the node DaemonSet, which has the OnDelete update strategy, one batch of nodes at a time.
+// The node pods of a batch are deleted once the previous batch is updated, and the rollout is paused when the
+// nodes of a batch are not updated within the batch timeout. The batch is recorded in annotations of the DaemonSet
+func RolloutNodes(ctx context.Context, name, namespace, driverName string, strategy csmv1.NodeRolloutStrategy, k8sClient kubernetes.Interface) (NodeRolloutStatus, error) {
+	log := logger.GetLogger(ctx)
+	status := NodeRolloutStatus{}
+
+	ds, err := k8sClient.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return status, fmt.Errorf("getting DaemonSet %s: %v", name, err)
+	}
+	if ds.Status.ObservedGeneration < ds.Generation {
+		// the DaemonSet controller has not created the revision of the new template yet
+		status.InProgress = true
+		return status, nil
+	}
+
+	hash, err := currentRevisionHash(ctx, ds, k8sClient)
+	if err != nil {
+		return status, err
+	}
+	if hash == "" {
+		status.InProgress = true
+		return status, nil
+	}
+	pods, err := getNodePods(ctx, ds, hash, k8sClient)
+	if err != nil {
+		return status, err
+	}
+
+	batch := []string{}
+	if value := ds.Annotations[csmv1.NodeRolloutBatchAnnotation]; value != "" {
+		batch = strings.Split(value, ",")
+	}
+	for _, node := range batch {
+		if _, ok := pods[node]; !ok {
+			// the node pod was deleted and is not created again yet
+			pods[node] = nodePod{}
+		}
+	}
+	status.TotalNodes = len(pods)
+
+	outdated := []string{}
+	updated := map[string]bool{}
+	for node, p := range pods {
+		if p.pod == nil || p.pod.DeletionTimestamp != nil {
+			continue
+		}
+		if !p.updated {
+			outdated = append(outdated, node)
+			continue
+		}
+		if updated[node], err = isNodeUpdated(ctx, p.pod, driverName, k8sClient); err != nil {
+			return status, err
+		}
+		if updated[node] {
+			status.UpdatedNodes++
+		}
+	}
+	sort.Strings(outdated)
+
+	paused := ds.Annotations[csmv1.NodeRolloutPausedAnnotation]
+	if paused != "" && paused != hash {
+		// the template changed since the rollout paused, start over with the new revision
+		log.Infow("Resuming node rollout", "name", name, "revision", hash)
+		batch = []string{}
+		if err := annotate(ctx, ds, map[string]*string{
+			csmv1.NodeRolloutBatchAnnotation:      nil,
+			csmv1.NodeRolloutBatchStartAnnotation: nil,
+			csmv1.NodeRolloutPausedAnnotation:     nil,
+		}, k8sClient); err != nil {
+			return status, err
+		}
+	}
+
+	for _, node := range batch {
+		if !updated[node] {
+			status.Pending = append(status.Pending, node)
+		}
+	}
+
+	if paused == hash {
+		// the rollout stays paused until the template changes
+		status.InProgress = true
+		status.Paused = true
+		return status, nil
+	}
+
+	if len(status.Pending) > 0 {
+		status.InProgress = true
+		start, err := time.Parse(time.RFC3339, ds.Annotations[csmv1.NodeRolloutBatchStartAnnotation])
+		if err != nil || time.Since(start) <= strategy.GetBatchTimeout() {
+			return status, nil
+		}
+
+		log.Warnw("Pausing node rollout", "name", name, "revision", hash, "pending", status.Pending)
+		status.Paused = true
+		return status, annotate(ctx, ds, map[string]*string{csmv1.NodeRolloutPausedAnnotation: &hash}, k8sClient)
+	}
+
+	if len(outdated) == 0 {
+		if len(batch) > 0 {
+			log.Infow("Node rollout complete", "name", name, "revision", hash)
+			return status, annotate(ctx, ds, map[string]*string{
+				csmv1.NodeRolloutBatchAnnotation:      nil,
+				csmv1.NodeRolloutBatchStartAnnotation: nil,
+			}, k8sClient)
+		}
+		return status, nil
+	}
+
+	next, err := nextBatch(ctx, outdated, strategy, status.TotalNodes, k8sClient)
+	if err != nil {
+		return status, err
+	}
+	log.Infow("Updating node batch", "name", name, "revision", hash, "nodes", next)
+
+	value := strings.Join(next, ",")
+	start := time.Now().UTC().Format(time.RFC3339)
+	if err := annotate(ctx, ds, map[string]*string{
+		csmv1.NodeRolloutBatchAnnotation:      &value,
+		csmv1.NodeRolloutBatchStartAnnotation: &start,
+	}, k8sClient); err != nil {
+		return status, err
+	}
+	for _, node := range next {
+		err := k8sClient.CoreV1().Pods(namespace).Delete(ctx, pods[node].pod.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return status, fmt.Errorf("deleting node pod %s: %v", pods[node].pod.Name, err)
+		}
+	}
+
+	status.Pending = next
+	status.InProgress = true
+	return status, nil
+}
+
+// currentRevisionHash - returns the hash of the latest ControllerRevision of the DaemonSet, which is the
+// controller-revision-hash label of the node pods created from the current template, or "" when there is none yet
+func currentRevisionHash(ctx context.Context, ds *apps.DaemonSet, k8sClient kubernetes.Interface) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("parsing the selector of DaemonSet %s: %v", ds.Name, err)
+	}
+	revisions, err := k8sClient.AppsV1().ControllerRevisions(ds.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", fmt.Errorf("listing the revisions of DaemonSet %s: %v", ds.Name, err)
+	}
+
+	var latest *apps.ControllerRevision
+	for i := range revisions.Items {
+		revision := &revisions.Items[i]
+		if !metav1.IsControlledBy(revision, ds) {
+			continue
+		}
+		if latest == nil || revision.Revision > latest.Revision {
+			latest = revision
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Labels[apps.DefaultDaemonSetUniqueLabelKey], nil
+}
+
+// getNodePods - returns the node pods of the DaemonSet by node name
+func getNodePods(ctx context.Context, ds *apps.DaemonSet, hash string, k8sClient kubernetes.Interface) (map[string]nodePod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the selector of DaemonSet %s: %v", ds.Name, err)
+	}
+	podList, err := k8sClient.CoreV1().Pods(ds.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("listing the pods of DaemonSet %s: %v", ds.Name, err)
+	}
+
+	pods := map[string]nodePod{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !metav1.IsControlledBy(pod, ds) || pod.Spec.NodeName == "" {
+			continue
+		}
+		// a terminating pod is replaced by the pod of the same node that is still running
+		if existing, ok := pods[pod.Spec.NodeName]; ok && pod.DeletionTimestamp != nil && existing.pod.DeletionTimestamp == nil {
+			continue
+		}
+		pods[pod.Spec.NodeName] = nodePod{pod: pod, updated: pod.Labels[apps.DefaultDaemonSetUniqueLabelKey] == hash}
+	}
+	return pods, nil
+}
+
+// isNodeUpdated - returns true if the node pod is ready and the driver is registered in the CSINode of its node
+func isNodeUpdated(ctx context.Context, pod *corev1.Pod, driverName string, k8sClient kubernetes.Interface) (bool, error) {
+	ready := slices.ContainsFunc(pod.Status.Conditions, func(c corev1.PodCondition) bool {
+		return c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue
+	})
+	if !ready {
+		return false, nil
+	}
+
+	csiNode, err := k8sClient.StorageV1().CSINodes().Get(ctx, pod.Spec.NodeName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting CSINode %s: %v", pod.Spec.NodeName, err)
+	}
+	for _, driver := range csiNode.Spec.Drivers {
+		if driver.Name == driverName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// nextBatch - returns the outdated nodes to update next: canary nodes come first, and a batch holds
+// nodes of a single group when groupByLabel is set
+func nextBatch(ctx context.Context, outdated []string, strategy csmv1.NodeRolloutStrategy, totalNodes int, k8sClient kubernetes.Interface) ([]string, error) {
+	candidates := outdated
+	size := 1
+	if strategy.GroupByLabel != "" {
+		size = len(outdated)
+	}
+	if strategy.BatchSize != nil {
+		scaled, err := intstr.GetScaledValueFromIntOrPercent(strategy.BatchSize, totalNodes, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid batch size: %v", err)
+		}
+		size = max(scaled, 1)
+	}
+
+	if len(strategy.CanaryNodeSelector) > 0 || strategy.GroupByLabel != "" {
+		nodeList, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("listing nodes: %v", err)
+		}
+		nodeLabels := map[string]map[string]string{}
+		for _, node := range nodeList.Items {
+			nodeLabels[node.Name] = node.Labels
+		}
+
+		if len(strategy.CanaryNodeSelector) > 0 {
+			selector := labels.SelectorFromSet(strategy.CanaryNodeSelector)
+			canaries := []string{}
+			for _, node := range candidates {
+				if selector.Matches(labels.Set(nodeLabels[node])) {
+					canaries = append(canaries, node)
+				}
+			}
+			if len(canaries) > 0 {
+				candidates = canaries
+			}
+		}
+
+		if strategy.GroupByLabel != "" {
+			group := nodeLabels[candidates[0]][strategy.GroupByLabel]
+			for _, node := range candidates[1:] {
+				group = min(group, nodeLabels[node][strategy.GroupByLabel])
+			}
+			candidates = slices.DeleteFunc(slices.Clone(candidates), func(node string) bool {
+				return nodeLabels[node][strategy.GroupByLabel] != group
+			})
+		}
+	}
+
+	return candidates[:min(size, len(candidates))], nil
+}
+
+// annotate - sets the annotations of the DaemonSet, a nil value removes the annotation
+func annotate(ctx context.Context, ds *apps.DaemonSet, annotations map[string]*string, k8sClient kubernetes.Interface) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = k8sClient.AppsV1().DaemonSets(ds.Namespace).Patch(ctx, ds.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("annotating DaemonSet %s: %v", ds.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package daemonset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testDriverName = "csi-powerstore.dellemc.com"
+	oldHash        = "old"
+	newHash        = "new"
+)
+
+func rolloutDaemonSet(annotations map[string]string) *apps.DaemonSet {
+	return &apps.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "powerstore-node",
+			Namespace:   "powerstore",
+			UID:         types.UID("ds-uid"),
+			Generation:  2,
+			Annotations: annotations,
+		},
+		Spec: apps.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "powerstore-node"}},
+		},
+		Status: apps.DaemonSetStatus{ObservedGeneration: 2},
+	}
+}
+
+func controllerRef() []metav1.OwnerReference {
+	isController := true
+	return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "powerstore-node", UID: "ds-uid", Controller: &isController}}
+}
+
+func revision(hash string, number int64) *apps.ControllerRevision {
+	return &apps.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "powerstore-node-" + hash,
+			Namespace:       "powerstore",
+			Labels:          map[string]string{"app": "powerstore-node", apps.DefaultDaemonSetUniqueLabelKey: hash},
+			OwnerReferences: controllerRef(),
+		},
+		Revision: number,
+	}
+}
+
+func nodePodOn(node, hash string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "powerstore-node-" + node,
+			Namespace:       "powerstore",
+			Labels:          map[string]string{"app": "powerstore-node", apps.DefaultDaemonSetUniqueLabelKey: hash},
+			OwnerReferences: controllerRef(),
+		},
+		Spec:   corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+	}
+}
+
+func registeredNode(node string) *storagev1.CSINode {
+	return &storagev1.CSINode{
+		ObjectMeta: metav1.ObjectMeta{Name: node},
+		Spec:       storagev1.CSINodeSpec{Drivers: []storagev1.CSINodeDriver{{Name: testDriverName, NodeID: node}}},
+	}
+}
+
+func labelledNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func batchStart(ago time.Duration) string {
+	return time.Now().Add(-ago).UTC().Format(time.RFC3339)
+}
+
+func podExists(t *testing.T, k8sClient *fake.Clientset, node string) bool {
+	_, err := k8sClient.CoreV1().Pods("powerstore").Get(context.Background(), "powerstore-node-"+node, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false
+	}
+	require.NoError(t, err)
+	return true
+}
+
+func TestRolloutNodes(t *testing.T) {
+	ctx := context.Background()
+	strategy := csmv1.NodeRolloutStrategy{Enabled: true}
+
+	tests := []struct {
+		name            string
+		strategy        csmv1.NodeRolloutStrategy
+		annotations     map[string]string
+		objects         []runtime.Object
+		want            NodeRolloutStatus
+		wantAnnotations map[string]string
+		wantDeleted     []string
+	}{
+		{
+			name:            "first batch is the first outdated node",
+			strategy:        strategy,
+			objects:         []runtime.Object{nodePodOn("node-b", oldHash, true), nodePodOn("node-a", oldHash, true)},
+			want:            NodeRolloutStatus{TotalNodes: 2, Pending: []string{"node-a"}, InProgress: true},
+			wantAnnotations: map[string]string{csmv1.NodeRolloutBatchAnnotation: "node-a"},
+			wantDeleted:     []string{"node-a"},
+		},
+		{
+			name:        "batch waits for the node pod to be created again",
+			strategy:    strategy,
+			annotations: map[string]string{csmv1.NodeRolloutBatchAnnotation: "node-a", csmv1.NodeRolloutBatchStartAnnotation: batchStart(time.Minute)},
+			objects:     []runtime.Object{nodePodOn("node-b", oldHash, true)},
+			want:        NodeRolloutStatus{TotalNodes: 2, Pending: []string{"node-a"}, InProgress: true},
+		},
+		{
+			name:        "batch waits for the driver to be registered",
+			strategy:    strategy,
+			annotations: map[string]string{csmv1.NodeRolloutBatchAnnotation: "node-a", csmv1.NodeRolloutBatchStartAnnotation: batchStart(time.Minute)},
+			objects:     []runtime.Object{nodePodOn("node-a", newHash, true), nodePodOn("node-b", oldHash, true)},
+			want:        NodeRolloutStatus{TotalNodes: 2, Pending: []string{"node-a"}, InProgress: true},
+		},
+		{
+			name:            "batch not updated within the timeout pauses the rollout",
+			strategy:        strategy,
+			annotations:     map[string]string{csmv1.NodeRolloutBatchAnnotation: "node-a", csmv1.NodeRolloutBatchStartAnnotation: batchStart(time.Hour)},
+			objects:         []runtime.Object{nodePodOn("node-a", newHash, false), nodePodOn("node-b", oldHash, true)},
+			want:            NodeRolloutStatus{TotalNodes: 2, Pending: []string{"node-a"}, InProgress: true, Paused: true},
+			wantAnnotations: map[string]string{csmv1.NodeRolloutPausedAnnotation: newHash},
+		},
+		{
+			name:        "paused rollout does not move on",
+			strategy:    strategy,
+			annotations: map[string]string{csmv1.NodeRolloutBatchAnnotation: "node-a", csmv1.NodeRolloutPausedAnnotation: newHash},
+			objects:     []runtime.Object{nodePodOn("node-a", newHash, true), registeredNode("node-a"), nodePodOn("node-b", oldHash, true)},
+			want:        NodeRolloutStatus{UpdatedNodes: 1, TotalNodes: 2, InProgress: true, Paused: true},
+		},
+		{
+			name:            "paused rollout resumes with a new revision",
+			strategy:        strategy,
+			annotations:     map[string]string{csmv1.NodeRolloutBatchAnnotation: "node-a", csmv1.NodeRolloutPausedAnnotation: oldHash},
+			objects:         []runtime.Object{nodePodOn("node-a", oldHash, false), nodePodOn("node-b", oldHash, true)},
+			want:            NodeRolloutStatus{TotalNodes: 2, Pending: []string{"node-a"}, InProgress: true},
+			wantAnnotations: map[string]string{csmv1.NodeRolloutBatchAnnotation: "node-a", csmv1.NodeRolloutPausedAnnotation: ""},
+			wantDeleted:     []string{"node-a"},
+		},
+		{
+			name:            "updated batch moves on to the next one",
+			strategy:        csmv1.NodeRolloutStrategy{Enabled: true, BatchSize: &intstr.IntOrString{Type: intstr.String, StrVal: "50%"}},
+			annotations:     map[string]string{csmv1.NodeRolloutBatchAnnotation: "node-a", csmv1.NodeRolloutBatchStartAnnotation: batchStart(time.Minute)},
+			objects:         []runtime.Object{nodePodOn("node-a", newHash, true), registeredNode("node-a"), nodePodOn("node-b", oldHash, true), nodePodOn("node-c", oldHash, true)},
+			want:            NodeRolloutStatus{UpdatedNodes: 1, TotalNodes: 3, Pending: []string{"node-b", "node-c"}, InProgress: true},
+			wantAnnotations: map[string]string{csmv1.NodeRolloutBatchAnnotation: "node-b,node-c"},
+			wantDeleted:     []string{"node-b", "node-c"},
+		},
+		{
+			name:            "rollout completes once the last batch is updated",
+			strategy:        strategy,
+			annotations:     map[string]string{csmv1.NodeRolloutBatchAnnotation: "node-a", csmv1.NodeRolloutBatchStartAnnotation: batchStart(time.Minute)},
+			objects:         []runtime.Object{nodePodOn("node-a", newHash, true), registeredNode("node-a")},
+			want:            NodeRolloutStatus{UpdatedNodes: 1, TotalNodes: 1},
+			wantAnnotations: map[string]string{csmv1.NodeRolloutBatchAnnotation: "", csmv1.NodeRolloutBatchStartAnnotation: ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := append([]runtime.Object{rolloutDaemonSet(tt.annotations), revision(oldHash, 1), revision(newHash, 2)}, tt.objects...)
+			k8sClient := fake.NewClientset(objects...)
+
+			got, err := RolloutNodes(ctx, "powerstore-node", "powerstore", testDriverName, tt.strategy, k8sClient)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			ds, err := k8sClient.AppsV1().DaemonSets("powerstore").Get(ctx, "powerstore-node", metav1.GetOptions{})
+			require.NoError(t, err)
+			for key, value := range tt.wantAnnotations {
+				assert.Equal(t, value, ds.Annotations[key], key)
+			}
+			for _, node := range tt.wantDeleted {
+				assert.False(t, podExists(t, k8sClient, node), node)
+			}
+		})
+	}
+
+	t.Run("waits for the DaemonSet controller", func(t *testing.T) {
+		ds := rolloutDaemonSet(nil)
+		ds.Status.ObservedGeneration = 1
+		k8sClient := fake.NewClientset(ds, nodePodOn("node-a", oldHash, true))
+
+		got, err := RolloutNodes(ctx, "powerstore-node", "powerstore", testDriverName, strategy, k8sClient)
+		require.NoError(t, err)
+		assert.True(t, got.InProgress)
+		assert.True(t, podExists(t, k8sClient, "node-a"))
+	})
+
+	t.Run("DaemonSet not found", func(t *testing.T) {
+		_, err := RolloutNodes(ctx, "powerstore-node", "powerstore", testDriverName, strategy, fake.NewClientset())
+		assert.ErrorContains(t, err, "getting DaemonSet powerstore-node")
+	})
+}
+
+func TestNextBatch(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fake.NewClientset(
+		labelledNode("node-a", map[string]string{"zone": "b"}),
+		labelledNode("node-b", map[string]string{"zone": "a"}),
+		labelledNode("node-c", map[string]string{"zone": "a", "canary": "true"}),
+		labelledNode("node-d", map[string]string{"zone": "b", "canary": "true"}),
+	)
+	outdated := []string{"node-a", "node-b", "node-c", "node-d"}
+
+	tests := []struct {
+		name     string
+		outdated []string
+		strategy csmv1.NodeRolloutStrategy
+		want     []string
+		wantErr  string
+	}{
+		{"one node by default", outdated, csmv1.NodeRolloutStrategy{}, []string{"node-a"}, ""},
+		{"batch size", outdated, csmv1.NodeRolloutStrategy{BatchSize: &intstr.IntOrString{IntVal: 3}}, []string{"node-a", "node-b", "node-c"}, ""},
+		{"percentage is rounded up", outdated, csmv1.NodeRolloutStrategy{BatchSize: &intstr.IntOrString{Type: intstr.String, StrVal: "10%"}}, []string{"node-a"}, ""},
+		{"invalid percentage", outdated, csmv1.NodeRolloutStrategy{BatchSize: &intstr.IntOrString{Type: intstr.String, StrVal: "half"}}, nil, "invalid batch size"},
+		{"canary nodes first", outdated, csmv1.NodeRolloutStrategy{BatchSize: &intstr.IntOrString{IntVal: 4}, CanaryNodeSelector: map[string]string{"canary": "true"}}, []string{"node-c", "node-d"}, ""},
+		{"whole group", outdated, csmv1.NodeRolloutStrategy{GroupByLabel: "zone"}, []string{"node-b", "node-c"}, ""},
+		{"group capped by the batch size", outdated, csmv1.NodeRolloutStrategy{GroupByLabel: "zone", BatchSize: &intstr.IntOrString{IntVal: 1}}, []string{"node-b"}, ""},
+		{"canary group", outdated, csmv1.NodeRolloutStrategy{GroupByLabel: "zone", CanaryNodeSelector: map[string]string{"canary": "true"}}, []string{"node-c"}, ""},
+		{"remaining group", []string{"node-a", "node-d"}, csmv1.NodeRolloutStrategy{GroupByLabel: "zone", CanaryNodeSelector: map[string]string{"canary": "true"}}, []string{"node-d"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextBatch(ctx, tt.outdated, tt.strategy, len(outdated), k8sClient)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -18,7 +18,9 @@ import (
 
 	//"fmt"
 
+	csmv1 "github.com/dell/csm-operator/api/v1"
 	"github.com/dell/csm-operator/pkg/logger"
+	"github.com/dell/csm-operator/pkg/resources/contenthash"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	appsv1 "k8s.io/client-go/applyconfigurations/apps/v1"
 	"k8s.io/client-go/kubernetes"
@@ -48,11 +50,26 @@ func SyncDeployment(ctx context.Context, deployment appsv1.DeploymentApplyConfig
 	}
 
 	deployment.Spec.Template.Labels["csm"] = csmName
+	// the csm labels of the object select it for the cache of the operator
+	deployment.WithLabels(map[string]string{"csm": csmName})
+	if csmNamespace, ok := deployment.Spec.Template.Labels["csmNamespace"]; ok {
+		deployment.WithLabels(map[string]string{"csmNamespace": csmNamespace})
+	}
+	hash, err := contenthash.Compute(deployment)
+	if err != nil {
+		return err
+	}
+	deployment.WithAnnotations(map[string]string{csmv1.ContentHashAnnotation: hash})
+	if found != nil && found.Name != "" && contenthash.Unchanged(found, hash) {
+		log.Infow("Deployment is unchanged", "Name:", found.Name)
+		return nil
+	}
 	set, err := deployments.Apply(ctx, &deployment, opts)
 	if err != nil {
 		log.Errorw("Apply Deployment error", "set", err.Error())
 		return err
 	}
+	contenthash.Record(set, hash)
 	log.Infow("deployment apply done", "name", set.Name)
 	return nil
 }
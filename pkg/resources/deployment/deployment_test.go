@@ -71,6 +71,7 @@ func TestSyncDeployment(t *testing.T) {
 			},
 		})
 
+		deployment.Spec.Template.Labels["csmNamespace"] = "csm-namespace"
 		err := SyncDeployment(ctx, deployment, k8sClient, "test-csm")
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
@@ -79,6 +80,8 @@ func TestSyncDeployment(t *testing.T) {
 		if deployment.Spec.Template.Labels["csm"] != "test-csm" {
 			t.Fatalf("expected label 'csm' to be 'test-csm', got %v", deployment.Spec.Template.Labels["csm"])
 		}
+		// the object is labeled with the CSM for the cache of the operator
+		assert.Equal(t, map[string]string{"csm": "test-csm", "csmNamespace": "csm-namespace"}, deployment.Labels)
 	})
 
 	t.Run("Handle error on getting Deployment", func(t *testing.T) {
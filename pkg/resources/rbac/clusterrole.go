@@ -16,6 +16,7 @@ import (
 	"context"
 
 	"github.com/dell/csm-operator/pkg/logger"
+	"github.com/dell/csm-operator/pkg/resources/contenthash"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -25,8 +26,14 @@ import (
 // SyncClusterRole - Syncs a ClusterRole
 func SyncClusterRole(ctx context.Context, clusterRole rbacv1.ClusterRole, client client.Client) error {
 	log := logger.GetLogger(ctx)
+	hash, err := contenthash.Compute(clusterRole)
+	if err != nil {
+		return err
+	}
+	contenthash.Set(&clusterRole, hash)
+
 	found := &rbacv1.ClusterRole{}
-	err := client.Get(ctx, types.NamespacedName{Name: clusterRole.Name, Namespace: clusterRole.Namespace}, found)
+	err = client.Get(ctx, types.NamespacedName{Name: clusterRole.Name, Namespace: clusterRole.Namespace}, found)
 	if err != nil && errors.IsNotFound(err) {
 		log.Infow("Creating a new ClusterRole", "Name", clusterRole.Name)
 		err = client.Create(ctx, &clusterRole)
@@ -41,6 +48,9 @@ func SyncClusterRole(ctx context.Context, clusterRole rbacv1.ClusterRole, client
 	} else if err != nil {
 		log.Info("Unknown error.", "Error", err.Error())
 		return err
+	} else if contenthash.Unchanged(found, hash) {
+		log.Infow("ClusterRole is unchanged", "Name:", clusterRole.Name)
+		return nil
 	} else {
 		log.Infow("Updating ClusterRole", "Name:", clusterRole.Name)
 		err = client.Update(ctx, &clusterRole)
@@ -48,6 +58,7 @@ func SyncClusterRole(ctx context.Context, clusterRole rbacv1.ClusterRole, client
 			return err
 		}
 	}
+	contenthash.Record(&clusterRole, hash)
 
 	return nil
 }
@@ -60,8 +71,14 @@ func SyncRole(ctx context.Context, role rbacv1.Role, client client.Client) error
 		return nil
 	}
 
+	hash, err := contenthash.Compute(role)
+	if err != nil {
+		return err
+	}
+	contenthash.Set(&role, hash)
+
 	found := &rbacv1.Role{}
-	err := client.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, found)
+	err = client.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, found)
 	if err != nil && errors.IsNotFound(err) {
 		log.Info("Creating a new Role", "Name", role.Name)
 		err = client.Create(ctx, &role)
@@ -77,6 +94,9 @@ func SyncRole(ctx context.Context, role rbacv1.Role, client client.Client) error
 	} else if err != nil {
 		log.Info("Unknown error.", "Error", err.Error())
 		return err
+	} else if contenthash.Unchanged(found, hash) {
+		log.Infow("Role is unchanged", "Name:", role.Name)
+		return nil
 	} else {
 		log.Info("Updating Role", "Name:", role.Name)
 		err = client.Update(ctx, &role)
@@ -84,6 +104,7 @@ func SyncRole(ctx context.Context, role rbacv1.Role, client client.Client) error
 			return err
 		}
 	}
+	contenthash.Record(&role, hash)
 
 	return nil
 }
@@ -16,6 +16,7 @@ import (
 	"context"
 
 	"github.com/dell/csm-operator/pkg/logger"
+	"github.com/dell/csm-operator/pkg/resources/contenthash"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -25,8 +26,14 @@ import (
 // SyncClusterRoleBindings - Syncs the ClusterRoleBindings
 func SyncClusterRoleBindings(ctx context.Context, rb rbacv1.ClusterRoleBinding, client client.Client) error {
 	log := logger.GetLogger(ctx)
+	hash, err := contenthash.Compute(rb)
+	if err != nil {
+		return err
+	}
+	contenthash.Set(&rb, hash)
+
 	found := &rbacv1.ClusterRoleBinding{}
-	err := client.Get(ctx, types.NamespacedName{Name: rb.Name, Namespace: rb.Namespace}, found)
+	err = client.Get(ctx, types.NamespacedName{Name: rb.Name, Namespace: rb.Namespace}, found)
 	if err != nil && errors.IsNotFound(err) {
 		log.Infow("Creating a new ClusterRoleBinding", "Namespace", rb.Namespace, "Name", rb.Name)
 		err = client.Create(ctx, &rb)
@@ -36,6 +43,9 @@ func SyncClusterRoleBindings(ctx context.Context, rb rbacv1.ClusterRoleBinding,
 	} else if err != nil {
 		log.Info("Unknown error.", "Error", err.Error())
 		return err
+	} else if contenthash.Unchanged(found, hash) {
+		log.Infow("ClusterRoleBinding is unchanged", "Name:", rb.Name)
+		return nil
 	} else {
 		log.Infow("Updating ClusterRoleBinding", "Name:", rb.Name)
 		err = client.Update(ctx, &rb)
@@ -43,6 +53,7 @@ func SyncClusterRoleBindings(ctx context.Context, rb rbacv1.ClusterRoleBinding,
 			return err
 		}
 	}
+	contenthash.Record(&rb, hash)
 	return nil
 }
 
@@ -54,8 +65,14 @@ func SyncRoleBindings(ctx context.Context, rb rbacv1.RoleBinding, client client.
 		return nil
 	}
 
+	hash, err := contenthash.Compute(rb)
+	if err != nil {
+		return err
+	}
+	contenthash.Set(&rb, hash)
+
 	found := &rbacv1.RoleBinding{}
-	err := client.Get(ctx, types.NamespacedName{Name: rb.Name, Namespace: rb.Namespace}, found)
+	err = client.Get(ctx, types.NamespacedName{Name: rb.Name, Namespace: rb.Namespace}, found)
 	if err != nil && errors.IsNotFound(err) {
 		log.Info("Creating a new RoleBinding", "Namespace", rb.Namespace, "Name", rb.Name)
 		err = client.Create(ctx, &rb)
@@ -65,6 +82,9 @@ func SyncRoleBindings(ctx context.Context, rb rbacv1.RoleBinding, client client.
 	} else if err != nil {
 		log.Info("Unknown error.", "Error", err.Error())
 		return err
+	} else if contenthash.Unchanged(found, hash) {
+		log.Infow("RoleBinding is unchanged", "Name:", rb.Name)
+		return nil
 	} else {
 		log.Info("Updating RoleBinding", "Name:", rb.Name)
 		err = client.Update(ctx, &rb)
@@ -72,5 +92,6 @@ func SyncRoleBindings(ctx context.Context, rb rbacv1.RoleBinding, client client.
 			return err
 		}
 	}
+	contenthash.Record(&rb, hash)
 	return nil
 }
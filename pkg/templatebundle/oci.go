@@ -0,0 +1,171 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package templatebundle
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// manifestMediaType - media type of the OCI image manifest of a bundle artifact
+	manifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+	// maxManifestSize - the maximum size of an OCI manifest
+	maxManifestSize = 4 << 20
+)
+
+// reference - an OCI artifact reference, registry/repository:tag or registry/repository@sha256:digest
+type reference struct {
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+// manifest - the fields of an OCI image manifest a bundle is read from
+type manifest struct {
+	MediaType   string            `json:"mediaType"`
+	Layers      []descriptor      `json:"layers"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// descriptor - an OCI content descriptor
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// Puller - pulls template bundles from an OCI registry, anonymously
+type Puller struct {
+	Client *http.Client
+	// PlainHTTP - pull over http instead of https, for local registries
+	PlainHTTP bool
+	Verifier  Verifier
+}
+
+// Pull - returns the verified files of the bundle in the gzipped tar layer of the artifact.
+// The layer is verified against its digest, and against the signature annotation of the manifest when a public key is set
+func (p Puller) Pull(ctx context.Context, ref string) (map[string][]byte, error) {
+	parsed, err := parseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestRef := parsed.tag
+	if parsed.digest != "" {
+		manifestRef = parsed.digest
+	}
+	body, err := p.get(ctx, parsed, "manifests/"+manifestRef, manifestMediaType, maxManifestSize)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.digest != "" {
+		sum := sha256.Sum256(body)
+		if parsed.digest != "sha256:"+hex.EncodeToString(sum[:]) {
+			return nil, fmt.Errorf("manifest of %s does not match its digest", ref)
+		}
+	}
+	var m manifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("unmarshalling the manifest of %s: %v", ref, err)
+	}
+
+	var layers []descriptor
+	for _, layer := range m.Layers {
+		if strings.HasSuffix(layer.MediaType, "tar+gzip") {
+			layers = append(layers, layer)
+		}
+	}
+	if len(layers) != 1 {
+		return nil, fmt.Errorf("%s has %d gzipped tar layers, expected 1", ref, len(layers))
+	}
+	if layers[0].Size > maxBundleSize {
+		return nil, fmt.Errorf("template bundle layer of %s is larger than %d bytes", ref, maxBundleSize)
+	}
+
+	archive, err := p.get(ctx, parsed, "blobs/"+layers[0].Digest, layers[0].MediaType, layers[0].Size)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Verifier.Verify(archive, layers[0].Digest, m.Annotations[SignatureAnnotation]); err != nil {
+		return nil, fmt.Errorf("%s: %v", ref, err)
+	}
+	return Extract(archive)
+}
+
+// get - returns the body of a registry API path of the repository, read up to limit bytes
+func (p Puller) get(ctx context.Context, ref reference, path, accept string, limit int64) ([]byte, error) {
+	scheme := "https"
+	if p.PlainHTTP {
+		scheme = "http"
+	}
+	url := fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.registry, ref.repository, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pulling %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pulling %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("pulling %s: %v", url, err)
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("pulling %s: larger than %d bytes", url, limit)
+	}
+	return body, nil
+}
+
+// parseReference - splits registry/repository:tag or registry/repository@sha256:digest
+func parseReference(ref string) (reference, error) {
+	registry, rest, ok := strings.Cut(ref, "/")
+	if !ok || registry == "" || rest == "" {
+		return reference{}, fmt.Errorf("OCI reference %s has no registry", ref)
+	}
+	parsed := reference{registry: registry}
+	if repository, digest, ok := strings.Cut(rest, "@"); ok {
+		if !strings.HasPrefix(digest, "sha256:") {
+			return reference{}, fmt.Errorf("OCI reference %s has an unsupported digest", ref)
+		}
+		parsed.repository, parsed.digest = repository, digest
+		return parsed, nil
+	}
+	parsed.repository, parsed.tag = rest, "latest"
+	if i := strings.LastIndex(rest, ":"); i >= 0 && !strings.Contains(rest[i:], "/") {
+		parsed.repository, parsed.tag = rest[:i], rest[i+1:]
+	}
+	if parsed.repository == "" || parsed.tag == "" {
+		return reference{}, fmt.Errorf("OCI reference %s is not valid", ref)
+	}
+	return parsed, nil
+}
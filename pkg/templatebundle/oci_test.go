@@ -0,0 +1,156 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package templatebundle
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveArtifact - serves the manifest under the tag and its digest, and the archive under its digest
+func serveArtifact(t *testing.T, m manifest, archive []byte) (*httptest.Server, string) {
+	body, err := json.Marshal(m)
+	require.NoError(t, err)
+	manifestDigest := checksum(body)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/dell/csm-templates/manifests/v2.17.2", "/v2/dell/csm-templates/manifests/" + manifestDigest:
+			assert.Equal(t, manifestMediaType, r.Header.Get("Accept"))
+			_, _ = w.Write(body)
+		case "/v2/dell/csm-templates/blobs/" + checksum(archive):
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, manifestDigest
+}
+
+func TestPull(t *testing.T) {
+	ctx := context.Background()
+	archive := makeArchive(t, map[string]string{"driverconfig/powerflex/v2.17.2/controller.yaml": "kind: Deployment"})
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	layer := descriptor{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: checksum(archive), Size: int64(len(archive))}
+	m := manifest{
+		MediaType: manifestMediaType,
+		Layers:    []descriptor{{MediaType: "application/vnd.oci.empty.v1+json", Digest: checksum([]byte("{}")), Size: 2}, layer},
+		Annotations: map[string]string{
+			SignatureAnnotation: base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, archive)),
+		},
+	}
+	server, manifestDigest := serveArtifact(t, m, archive)
+	registry := strings.TrimPrefix(server.URL, "http://")
+	want := map[string][]byte{"driverconfig/powerflex/v2.17.2/controller.yaml": []byte("kind: Deployment")}
+
+	t.Run("tag", func(t *testing.T) {
+		files, err := Puller{PlainHTTP: true}.Pull(ctx, registry+"/dell/csm-templates:v2.17.2")
+		require.NoError(t, err)
+		assert.Equal(t, want, files)
+	})
+
+	t.Run("digest and signature", func(t *testing.T) {
+		puller := Puller{Client: server.Client(), PlainHTTP: true, Verifier: Verifier{PublicKey: publicKey}}
+		files, err := puller.Pull(ctx, registry+"/dell/csm-templates@"+manifestDigest)
+		require.NoError(t, err)
+		assert.Equal(t, want, files)
+	})
+
+	t.Run("manifest digest mismatch", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"layers":[]}`))
+		}))
+		defer server.Close()
+		_, err := Puller{PlainHTTP: true}.Pull(ctx, strings.TrimPrefix(server.URL, "http://")+"/dell/csm-templates@"+manifestDigest)
+		assert.ErrorContains(t, err, "does not match its digest")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := Puller{PlainHTTP: true}.Pull(ctx, registry+"/dell/csm-templates:v2.17.1")
+		assert.ErrorContains(t, err, "404 Not Found")
+	})
+
+	t.Run("signature of another key", func(t *testing.T) {
+		otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		_, err = Puller{PlainHTTP: true, Verifier: Verifier{PublicKey: otherKey}}.Pull(ctx, registry+"/dell/csm-templates:v2.17.2")
+		assert.ErrorContains(t, err, "template bundle signature is not valid")
+	})
+
+	t.Run("layer digest mismatch", func(t *testing.T) {
+		tampered := bytes.Clone(archive)
+		tampered[len(tampered)-1] ^= 0xff
+		m := manifest{MediaType: manifestMediaType, Layers: []descriptor{layer}}
+		body, err := json.Marshal(m)
+		require.NoError(t, err)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.URL.Path, "/manifests/") {
+				_, _ = w.Write(body)
+				return
+			}
+			_, _ = w.Write(tampered)
+		}))
+		defer server.Close()
+		_, err = Puller{PlainHTTP: true}.Pull(ctx, strings.TrimPrefix(server.URL, "http://")+"/dell/csm-templates:v2.17.2")
+		assert.ErrorContains(t, err, "template bundle checksum mismatch")
+	})
+
+	t.Run("no bundle layer", func(t *testing.T) {
+		server, _ := serveArtifact(t, manifest{MediaType: manifestMediaType}, archive)
+		_, err := Puller{PlainHTTP: true}.Pull(ctx, strings.TrimPrefix(server.URL, "http://")+"/dell/csm-templates:v2.17.2")
+		assert.ErrorContains(t, err, "has 0 gzipped tar layers, expected 1")
+	})
+
+	t.Run("https", func(t *testing.T) {
+		_, err := Puller{}.Pull(ctx, registry+"/dell/csm-templates:v2.17.2")
+		assert.ErrorContains(t, err, "pulling https://"+registry)
+	})
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    reference
+		wantErr bool
+	}{
+		{"registry.local:5000/dell/csm-templates:v2.17.2", reference{registry: "registry.local:5000", repository: "dell/csm-templates", tag: "v2.17.2"}, false},
+		{"registry.local/csm-templates", reference{registry: "registry.local", repository: "csm-templates", tag: "latest"}, false},
+		{"registry.local/csm-templates@sha256:abc", reference{registry: "registry.local", repository: "csm-templates", digest: "sha256:abc"}, false},
+		{"registry.local/csm-templates@md5:abc", reference{}, true},
+		{"csm-templates", reference{}, true},
+		{"registry.local/:v1", reference{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := parseReference(tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package templatebundle reads and verifies bundles of operatorconfig templates shipped outside of the operator image.
+// A bundle is a gzipped tar archive of files laid out like the config directory, for example
+// driverconfig/powerflex/v2.17.2/controller.yaml
+package templatebundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// Label - label of the ConfigMaps holding a template bundle
+	Label = "storage.dell.com/template-bundle"
+
+	// ChecksumAnnotation - annotation holding the sha256 of the archive of a bundle ConfigMap
+	ChecksumAnnotation = "storage.dell.com/template-bundle-sha256"
+
+	// SignatureAnnotation - annotation holding the base64 ed25519 signature of the archive, on a bundle ConfigMap or OCI manifest
+	SignatureAnnotation = "storage.dell.com/template-bundle-signature"
+
+	// ArchiveKey - binaryData key of the archive in a bundle ConfigMap
+	ArchiveKey = "bundle.tar.gz"
+
+	// maxBundleSize - the maximum size of the extracted files of a bundle
+	maxBundleSize = 64 << 20
+)
+
+// Verifier - verifies the archive of a bundle before its files are used
+type Verifier struct {
+	// PublicKey - when set, bundles must be signed with the matching private key. Without it only the checksum is
+	// verified, which detects a corrupted archive but not who published it
+	PublicKey ed25519.PublicKey
+}
+
+// LoadPublicKey - reads a PEM encoded ed25519 public key
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	buf, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading the template bundle public key: %v", err)
+	}
+	block, _ := pem.Decode(buf)
+	if block == nil {
+		return nil, fmt.Errorf("template bundle public key %s is not PEM encoded", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the template bundle public key: %v", err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("template bundle public key %s is not an ed25519 key", path)
+	}
+	return publicKey, nil
+}
+
+// Verify - checks the archive against its sha256 checksum, and its signature when a public key is set
+func (v Verifier) Verify(archive []byte, checksum, signature string) error {
+	sum := sha256.Sum256(archive)
+	if checksum == "" {
+		return errors.New("template bundle has no checksum")
+	}
+	if !strings.EqualFold(strings.TrimPrefix(checksum, "sha256:"), hex.EncodeToString(sum[:])) {
+		return fmt.Errorf("template bundle checksum mismatch: expected %s, got sha256:%s", checksum, hex.EncodeToString(sum[:]))
+	}
+	if v.PublicKey == nil {
+		return nil
+	}
+	if signature == "" {
+		return errors.New("template bundle is not signed")
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("decoding the template bundle signature: %v", err)
+	}
+	if !ed25519.Verify(v.PublicKey, archive, sig) {
+		return errors.New("template bundle signature is not valid")
+	}
+	return nil
+}
+
+// FromConfigMap - returns the verified files of the bundle held by the ConfigMap
+func (v Verifier) FromConfigMap(cm *corev1.ConfigMap) (map[string][]byte, error) {
+	archive, ok := cm.BinaryData[ArchiveKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s/%s has no %s", cm.Namespace, cm.Name, ArchiveKey)
+	}
+	annotations := cm.GetAnnotations()
+	if err := v.Verify(archive, annotations[ChecksumAnnotation], annotations[SignatureAnnotation]); err != nil {
+		return nil, fmt.Errorf("ConfigMap %s/%s: %v", cm.Namespace, cm.Name, err)
+	}
+	return Extract(archive)
+}
+
+// Extract - returns the regular files of a gzipped tar archive by path
+func Extract(archive []byte) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("reading the template bundle: %v", err)
+	}
+	defer gz.Close()
+
+	files := map[string][]byte{}
+	var size int64
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading the template bundle: %v", err)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+		default:
+			return nil, fmt.Errorf("template bundle entry %s is not a regular file", header.Name)
+		}
+		size += header.Size
+		if size > maxBundleSize {
+			return nil, fmt.Errorf("template bundle is larger than %d bytes", maxBundleSize)
+		}
+		data, err := io.ReadAll(io.LimitReader(reader, header.Size))
+		if err != nil {
+			return nil, fmt.Errorf("reading the template bundle entry %s: %v", header.Name, err)
+		}
+		files[strings.TrimPrefix(header.Name, "./")] = data
+	}
+	if len(files) == 0 {
+		return nil, errors.New("template bundle is empty")
+	}
+	return files, nil
+}
@@ -0,0 +1,183 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package templatebundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// makeArchive - returns a gzipped tar archive of the files, with a directory entry for each file
+func makeArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: filepath.Dir(name) + "/", Typeflag: tar.TypeDir, Mode: 0o755}))
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// checksum - returns the sha256 of the archive as a digest
+func checksum(archive []byte) string {
+	sum := sha256.Sum256(archive)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestVerify(t *testing.T) {
+	archive := makeArchive(t, map[string]string{"driverconfig/powerflex/v2.17.2/controller.yaml": "kind: Deployment"})
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, archive))
+	otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		verifier  Verifier
+		checksum  string
+		signature string
+		wantErr   string
+	}{
+		{"checksum", Verifier{}, checksum(archive), "", ""},
+		{"checksum without prefix", Verifier{}, checksum(archive)[len("sha256:"):], "", ""},
+		{"no checksum", Verifier{}, "", "", "template bundle has no checksum"},
+		{"checksum mismatch", Verifier{}, checksum([]byte("other")), "", "template bundle checksum mismatch"},
+		{"signature", Verifier{PublicKey: publicKey}, checksum(archive), signature, ""},
+		{"not signed", Verifier{PublicKey: publicKey}, checksum(archive), "", "template bundle is not signed"},
+		{"signature not base64", Verifier{PublicKey: publicKey}, checksum(archive), "!", "decoding the template bundle signature"},
+		{"signature of another key", Verifier{PublicKey: otherKey}, checksum(archive), signature, "template bundle signature is not valid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.verifier.Verify(archive, tt.checksum, tt.signature)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFromConfigMap(t *testing.T) {
+	archive := makeArchive(t, map[string]string{"driverconfig/powerflex/v2.17.2/controller.yaml": "kind: Deployment"})
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "powerflex-v2.17.2",
+			Namespace:   "dell-csm-operator",
+			Annotations: map[string]string{ChecksumAnnotation: checksum(archive)},
+		},
+		BinaryData: map[string][]byte{ArchiveKey: archive},
+	}
+
+	files, err := Verifier{}.FromConfigMap(cm)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"driverconfig/powerflex/v2.17.2/controller.yaml": []byte("kind: Deployment")}, files)
+
+	cm.Annotations[ChecksumAnnotation] = checksum([]byte("other"))
+	_, err = Verifier{}.FromConfigMap(cm)
+	assert.ErrorContains(t, err, "ConfigMap dell-csm-operator/powerflex-v2.17.2: template bundle checksum mismatch")
+
+	cm.BinaryData = nil
+	_, err = Verifier{}.FromConfigMap(cm)
+	assert.EqualError(t, err, "ConfigMap dell-csm-operator/powerflex-v2.17.2 has no bundle.tar.gz")
+}
+
+func TestExtract(t *testing.T) {
+	t.Run("files", func(t *testing.T) {
+		files, err := Extract(makeArchive(t, map[string]string{
+			"./driverconfig/powerflex/v2.17.2/node.yaml": "kind: DaemonSet",
+			"common/csm-version-mapping.yaml":            "powerflex: {}",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{
+			"driverconfig/powerflex/v2.17.2/node.yaml": []byte("kind: DaemonSet"),
+			"common/csm-version-mapping.yaml":          []byte("powerflex: {}"),
+		}, files)
+	})
+
+	t.Run("not gzipped", func(t *testing.T) {
+		_, err := Extract([]byte("not an archive"))
+		assert.ErrorContains(t, err, "reading the template bundle")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := Extract(makeArchive(t, nil))
+		assert.EqualError(t, err, "template bundle is empty")
+	})
+
+	t.Run("symlink", func(t *testing.T) {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "driverconfig/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}))
+		require.NoError(t, tw.Close())
+		require.NoError(t, gz.Close())
+		_, err := Extract(buf.Bytes())
+		assert.EqualError(t, err, "template bundle entry driverconfig/link is not a regular file")
+	})
+}
+
+func TestLoadPublicKey(t *testing.T) {
+	dir := t.TempDir()
+	writeKey := func(name string, key any) string {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		require.NoError(t, err)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+		return path
+	}
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	loaded, err := LoadPublicKey(writeKey("ed25519.pem", publicKey))
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, loaded)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, err = LoadPublicKey(writeKey("ecdsa.pem", &ecdsaKey.PublicKey))
+	assert.ErrorContains(t, err, "is not an ed25519 key")
+
+	notPEM := filepath.Join(dir, "key.txt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("key"), 0o600))
+	_, err = LoadPublicKey(notPEM)
+	assert.ErrorContains(t, err, "is not PEM encoded")
+
+	_, err = LoadPublicKey(filepath.Join(dir, "missing.pem"))
+	assert.ErrorContains(t, err, "reading the template bundle public key")
+}
@@ -0,0 +1,331 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	"github.com/dell/csm-operator/pkg/logger"
+	"github.com/dell/csm-operator/pkg/modules"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+)
+
+// +kubebuilder:webhook:path=/mutate-storage-dell-com-v1-containerstoragemodule,mutating=true,failurePolicy=fail,sideEffects=None,groups=storage.dell.com,resources=containerstoragemodules,verbs=create;update,versions=v1,name=mcontainerstoragemodule.storage.dell.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-storage-dell-com-v1-containerstoragemodule,mutating=false,failurePolicy=fail,sideEffects=None,groups=storage.dell.com,resources=containerstoragemodules,verbs=create;update,versions=v1,name=vcontainerstoragemodule.storage.dell.com,admissionReviewVersions=v1
+
+// CSMValidator - validates ContainerStorageModule objects at admission time
+type CSMValidator struct {
+	Config operatorutils.OperatorConfig
+}
+
+var _ admission.Validator[*csmv1.ContainerStorageModule] = &CSMValidator{}
+
+// CSMDefaulter - persists the operator defaults into ContainerStorageModule objects at admission time
+type CSMDefaulter struct {
+	Config operatorutils.OperatorConfig
+}
+
+var _ admission.Defaulter[*csmv1.ContainerStorageModule] = &CSMDefaulter{}
+
+// SetupCSMWebhookWithManager - registers the ContainerStorageModule webhooks with the manager
+func SetupCSMWebhookWithManager(mgr ctrl.Manager, config operatorutils.OperatorConfig) error {
+	return ctrl.NewWebhookManagedBy(mgr, &csmv1.ContainerStorageModule{}).
+		WithDefaulter(&CSMDefaulter{Config: config}).
+		WithValidator(&CSMValidator{Config: config}).
+		Complete()
+}
+
+// Default - fills in the values that the reconciler would otherwise default in memory
+func (d *CSMDefaulter) Default(_ context.Context, cr *csmv1.ContainerStorageModule) error {
+	ctx, log := logger.GetNewContextWithLogger("webhook")
+	log.Infow("default", "name", cr.Name, "namespace", cr.Namespace)
+
+	if cr.IsBeingDeleted() {
+		return nil
+	}
+
+	if cr.Spec.Driver.ForceRemoveDriver == nil {
+		truebool := true
+		cr.Spec.Driver.ForceRemoveDriver = &truebool
+	}
+
+	if err := operatorutils.LoadDefaultComponents(ctx, cr, d.Config); err != nil {
+		return err
+	}
+
+	// version dependent defaults are skipped when the version cannot be resolved,
+	// the validating webhook reports the error instead
+	version, err := d.resolveVersion(ctx, cr)
+	if err != nil {
+		log.Infow("skipping version defaults", "error", err.Error())
+		return nil
+	}
+
+	for i, m := range cr.Spec.Modules {
+		if m.Name == csmv1.AuthorizationServer {
+			cr.Spec.Modules[i].ConfigVersion = version
+			break
+		}
+	}
+
+	switch cr.Spec.Driver.CSIDriverType {
+	case csmv1.PowerScale, csmv1.PowerFlex, csmv1.PowerStore, csmv1.Unity, csmv1.PowerMax:
+		if cr.Spec.Driver.DNSPolicy == "" {
+			cr.Spec.Driver.DNSPolicy = string(corev1.DNSClusterFirstWithHostNet)
+		}
+		fallthrough
+	case csmv1.Cosi:
+		if cr.Spec.Driver.Replicas == 0 {
+			replicas, err := d.getTemplateReplicas(cr.Spec.Driver.CSIDriverType, version)
+			if err != nil {
+				log.Infow("skipping replicas default", "error", err.Error())
+				break
+			}
+			cr.Spec.Driver.Replicas = replicas
+		}
+	}
+
+	return nil
+}
+
+// resolveVersion - resolves the config version and pins it to spec.version with annotations
+func (d *CSMDefaulter) resolveVersion(ctx context.Context, cr *csmv1.ContainerStorageModule) (string, error) {
+	annotations := cr.GetAnnotations()
+	if cr.Spec.Version == "" {
+		delete(annotations, operatorutils.ResolvedCSMVersionAnnotation)
+		delete(annotations, operatorutils.ResolvedConfigVersionAnnotation)
+		return operatorutils.GetVersion(ctx, cr, d.Config)
+	}
+
+	// GetVersion keeps a supported pin of spec.version, and resolves a new or changed spec.version from the mapping file
+	version, err := operatorutils.GetVersion(ctx, cr, d.Config)
+	if err != nil {
+		delete(annotations, operatorutils.ResolvedCSMVersionAnnotation)
+		delete(annotations, operatorutils.ResolvedConfigVersionAnnotation)
+		return "", err
+	}
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[operatorutils.ResolvedCSMVersionAnnotation] = cr.Spec.Version
+	annotations[operatorutils.ResolvedConfigVersionAnnotation] = version
+	cr.SetAnnotations(annotations)
+	return version, nil
+}
+
+// getTemplateReplicas - returns the replica count of the controller deployment in the driver template
+func (d *CSMDefaulter) getTemplateReplicas(driverType csmv1.DriverType, version string) (int32, error) {
+	if driverType == csmv1.PowerScale {
+		// use powerscale instead of isilon as the folder name is powerscale
+		driverType = csmv1.PowerScaleName
+	}
+
+	file := fmt.Sprintf("%s/driverconfig/%s/%s/controller.yaml", d.Config.ConfigDirectory, driverType, version)
+	buf, err := operatorutils.Templates(d.Config.ConfigDirectory).DriverFile(driverType, version, "controller.yaml")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file %s: %v", file, err)
+	}
+
+	docs, err := operatorutils.SplitYaml(buf)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, doc := range docs {
+		var obj struct {
+			Kind string `json:"kind"`
+			Spec struct {
+				Replicas *int32 `json:"replicas"`
+			} `json:"spec"`
+		}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			continue
+		}
+		if obj.Kind == "Deployment" && obj.Spec.Replicas != nil {
+			return *obj.Spec.Replicas, nil
+		}
+	}
+	return 0, fmt.Errorf("no controller deployment replicas found in %s", file)
+}
+
+// ValidateCreate - validates a new ContainerStorageModule
+func (v *CSMValidator) ValidateCreate(_ context.Context, cr *csmv1.ContainerStorageModule) (admission.Warnings, error) {
+	ctx, log := logger.GetNewContextWithLogger("webhook")
+	log.Infow("validate create", "name", cr.Name, "namespace", cr.Namespace)
+
+	return nil, v.validateSpec(ctx, cr)
+}
+
+// ValidateUpdate - validates an update to an existing ContainerStorageModule
+func (v *CSMValidator) ValidateUpdate(_ context.Context, oldCR, newCR *csmv1.ContainerStorageModule) (admission.Warnings, error) {
+	ctx, log := logger.GetNewContextWithLogger("webhook")
+	log.Infow("validate update", "name", newCR.Name, "namespace", newCR.Namespace)
+
+	// never block finalizer removal or metadata-only updates made by the operator itself
+	if newCR.IsBeingDeleted() || reflect.DeepEqual(oldCR.Spec, newCR.Spec) {
+		return nil, nil
+	}
+
+	if err := v.validateSpec(ctx, newCR); err != nil {
+		return nil, err
+	}
+
+	return nil, v.validateUpgrade(ctx, oldCR, newCR)
+}
+
+// ValidateDelete - deletion is never blocked
+func (v *CSMValidator) ValidateDelete(_ context.Context, _ *csmv1.ContainerStorageModule) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSpec - runs the checks from the reconciler prechecks that do not need cluster access
+func (v *CSMValidator) validateSpec(ctx context.Context, cr *csmv1.ContainerStorageModule) error {
+	isAuthorizationServer := cr.HasModule(csmv1.AuthorizationServer)
+
+	switch cr.Spec.Driver.CSIDriverType {
+	case csmv1.PowerScale, csmv1.PowerFlex, csmv1.PowerStore, csmv1.Unity, csmv1.PowerMax, csmv1.Cosi:
+		if err := v.validateDriverVersion(ctx, cr); err != nil {
+			return err
+		}
+	default:
+		if !isAuthorizationServer {
+			return fmt.Errorf("unsupported driver type %s", cr.Spec.Driver.CSIDriverType)
+		}
+		if _, err := operatorutils.GetVersion(ctx, cr, v.Config); err != nil {
+			return err
+		}
+	}
+
+	if err := operatorutils.ValidateCustomRegistry(ctx, cr.Spec.CustomRegistry); err != nil {
+		return fmt.Errorf("failed custom registry validation: %v", err)
+	}
+
+	for _, m := range cr.Spec.Modules {
+		if !m.Enabled {
+			continue
+		}
+		if err := v.validateModule(cr, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateDriverVersion - checks that the resolved driver version has a config folder
+func (v *CSMValidator) validateDriverVersion(ctx context.Context, cr *csmv1.ContainerStorageModule) error {
+	version, err := operatorutils.GetVersion(ctx, cr, v.Config)
+	if err != nil {
+		return err
+	}
+
+	driverType := cr.Spec.Driver.CSIDriverType
+	if driverType == csmv1.PowerScale {
+		// use powerscale instead of isilon as the folder name is powerscale
+		driverType = csmv1.PowerScaleName
+	}
+
+	if _, err := operatorutils.Templates(v.Config.ConfigDirectory).DriverFile(driverType, version, "upgrade-path.yaml"); os.IsNotExist(err) {
+		return fmt.Errorf("%s %s not supported", cr.Spec.Driver.CSIDriverType, version)
+	}
+	return nil
+}
+
+// validateModule - checks that the module is supported for the driver and that its config version exists
+func (v *CSMValidator) validateModule(cr *csmv1.ContainerStorageModule, m csmv1.Module) error {
+	driverType := string(cr.Spec.Driver.CSIDriverType)
+	configFolder := string(m.Name)
+
+	var supportedDrivers map[string]modules.SupportedDriverParam
+	if m.Name == csmv1.AuthorizationServer {
+		// the authorization proxy server is not tied to a driver
+		configFolder = string(csmv1.Authorization)
+	} else {
+		drivers, ok := modules.GetSupportedDrivers(m.Name)
+		if !ok {
+			return fmt.Errorf("unsupported module type %s", m.Name)
+		}
+		supportedDrivers = drivers
+	}
+
+	if supportedDrivers != nil {
+		if _, ok := supportedDrivers[driverType]; !ok {
+			return fmt.Errorf("CSM %s does not support %s driver", m.Name, driverType)
+		}
+	}
+
+	if m.ConfigVersion != "" {
+		versions, _ := operatorutils.Templates(v.Config.ConfigDirectory).ModuleVersions(csmv1.ModuleType(configFolder))
+		if !slices.Contains(versions, m.ConfigVersion) {
+			return fmt.Errorf("CSM %s does not have %s version", m.Name, m.ConfigVersion)
+		}
+	}
+	return nil
+}
+
+// validateUpgrade - rejects upgrade paths that the reconciler would refuse to apply
+func (v *CSMValidator) validateUpgrade(ctx context.Context, oldCR, newCR *csmv1.ContainerStorageModule) error {
+	oldVersion, ok := oldCR.GetAnnotations()[operatorutils.ConfigVersionAnnotation]
+	if !ok || oldVersion == "" {
+		// nothing has been installed yet
+		return nil
+	}
+
+	newVersion, err := operatorutils.GetVersion(ctx, newCR, v.Config)
+	if err != nil {
+		return err
+	}
+
+	if newCR.HasModule(csmv1.AuthorizationServer) {
+		if strings.HasPrefix(oldVersion, "v1.") && strings.HasPrefix(newVersion, "v2.") ||
+			strings.HasPrefix(oldVersion, "v2.") && strings.HasPrefix(newVersion, "v1.") {
+			return fmt.Errorf("cannot switch between Authorization v1 and v2 (%s to %s)", oldVersion, newVersion)
+		}
+		return checkValidUpgrade(ctx, newCR, oldVersion, newVersion, csmv1.Authorization, v.Config)
+	}
+
+	driverType := newCR.Spec.Driver.CSIDriverType
+	if driverType == csmv1.PowerScale {
+		// use powerscale instead of isilon as the folder name is powerscale
+		driverType = csmv1.PowerScaleName
+	}
+	return checkValidUpgrade(ctx, newCR, oldVersion, newVersion, driverType, v.Config)
+}
+
+func checkValidUpgrade[T operatorutils.CSMComponentType](ctx context.Context, cr *csmv1.ContainerStorageModule, oldVersion, newVersion string, componentType T, op operatorutils.OperatorConfig) error {
+	valid, err := operatorutils.IsValidUpgrade(ctx, oldVersion, newVersion, componentType, op)
+	if !valid && cr.Spec.MultiHopUpgrade {
+		// the reconciler goes through intermediate versions when there are some
+		if _, planErr := operatorutils.PlanUpgrade(ctx, oldVersion, newVersion, componentType, operatorutils.ModulesFollowingDriverVersion(*cr), op); planErr == nil {
+			return nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed upgrade check: %v", err)
+	}
+	if !valid {
+		return fmt.Errorf("upgrade of %s from %s to %s is not supported", componentType, oldVersion, newVersion)
+	}
+	return nil
+}
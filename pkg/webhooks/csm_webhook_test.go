@@ -0,0 +1,344 @@
+//  Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//       http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	csmv1 "github.com/dell/csm-operator/api/v1"
+	operatorutils "github.com/dell/csm-operator/pkg/operatorutils"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var validator = &CSMValidator{
+	Config: operatorutils.OperatorConfig{
+		ConfigDirectory: "../../operatorconfig",
+	},
+}
+
+func getCSM(driverType csmv1.DriverType, configVersion string) *csmv1.ContainerStorageModule {
+	return &csmv1.ContainerStorageModule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "csm",
+			Namespace: "driver-test",
+		},
+		Spec: csmv1.ContainerStorageModuleSpec{
+			Driver: csmv1.Driver{
+				CSIDriverType: driverType,
+				ConfigVersion: configVersion,
+			},
+		},
+	}
+}
+
+func TestValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cr      func() *csmv1.ContainerStorageModule
+		wantErr string
+	}{
+		{
+			name: "valid powerstore",
+			cr: func() *csmv1.ContainerStorageModule {
+				return getCSM(csmv1.PowerStore, "v2.17.0")
+			},
+		},
+		{
+			name: "valid powerscale with spec.version",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := getCSM(csmv1.PowerScale, "")
+				cr.Spec.Version = "v1.17.0"
+				return cr
+			},
+		},
+		{
+			name: "unsupported driver type",
+			cr: func() *csmv1.ContainerStorageModule {
+				return getCSM("unknown", "v2.17.0")
+			},
+			wantErr: "unsupported driver type unknown",
+		},
+		{
+			name: "unsupported driver version",
+			cr: func() *csmv1.ContainerStorageModule {
+				return getCSM(csmv1.PowerStore, "v0.0.1")
+			},
+			wantErr: "powerstore v0.0.1 not supported",
+		},
+		{
+			name: "unknown spec.version",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := getCSM(csmv1.PowerStore, "")
+				cr.Spec.Version = "v0.0.1"
+				return cr
+			},
+			wantErr: "No custom resource configuration is available for CSM version v0.0.1",
+		},
+		{
+			name: "invalid custom registry",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := getCSM(csmv1.PowerStore, "")
+				cr.Spec.Version = "v1.17.0"
+				cr.Spec.CustomRegistry = "http://[::1"
+				return cr
+			},
+			wantErr: "failed custom registry validation",
+		},
+		{
+			name: "unsupported module for driver",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := getCSM(csmv1.Unity, "v2.17.0")
+				cr.Spec.Modules = []csmv1.Module{{Name: csmv1.ReverseProxy, Enabled: true}}
+				return cr
+			},
+			wantErr: "CSM csireverseproxy does not support unity driver",
+		},
+		{
+			name: "disabled module is not validated",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := getCSM(csmv1.PowerStore, "v2.17.0")
+				cr.Spec.Modules = []csmv1.Module{{Name: csmv1.ReverseProxy, Enabled: false}}
+				return cr
+			},
+		},
+		{
+			name: "vgsnapshotter",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := getCSM(csmv1.PowerStore, "v2.17.0")
+				cr.Spec.Modules = []csmv1.Module{{Name: csmv1.VgSnapShotter, Enabled: true, ConfigVersion: "v1.8.0"}}
+				return cr
+			},
+		},
+		{
+			name: "vgsnapshotter unsupported for driver",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := getCSM(csmv1.Unity, "v2.17.0")
+				cr.Spec.Modules = []csmv1.Module{{Name: csmv1.VgSnapShotter, Enabled: true}}
+				return cr
+			},
+			wantErr: "CSM vgsnapshotter does not support unity driver",
+		},
+		{
+			name: "unsupported module type",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := getCSM(csmv1.PowerStore, "v2.17.0")
+				cr.Spec.Modules = []csmv1.Module{{Name: "unknown", Enabled: true}}
+				return cr
+			},
+			wantErr: "unsupported module type unknown",
+		},
+		{
+			name: "unsupported module version",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := getCSM(csmv1.PowerStore, "v2.17.0")
+				cr.Spec.Modules = []csmv1.Module{{Name: csmv1.Replication, Enabled: true, ConfigVersion: "v0.0.1"}}
+				return cr
+			},
+			wantErr: "CSM replication does not have v0.0.1 version",
+		},
+		{
+			name: "standalone authorization proxy server",
+			cr: func() *csmv1.ContainerStorageModule {
+				cr := getCSM("", "")
+				cr.Spec.Modules = []csmv1.Module{{Name: csmv1.AuthorizationServer, Enabled: true, ConfigVersion: "v2.5.0"}}
+				return cr
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.cr())
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	installed := func(cr *csmv1.ContainerStorageModule, version string) *csmv1.ContainerStorageModule {
+		cr.Annotations = map[string]string{operatorutils.ConfigVersionAnnotation: version}
+		return cr
+	}
+
+	tests := []struct {
+		name    string
+		oldCR   *csmv1.ContainerStorageModule
+		newCR   *csmv1.ContainerStorageModule
+		wantErr string
+	}{
+		{
+			name:  "valid upgrade",
+			oldCR: installed(getCSM(csmv1.PowerStore, "v2.16.0"), "v2.16.0"),
+			newCR: installed(getCSM(csmv1.PowerStore, "v2.17.0"), "v2.16.0"),
+		},
+		{
+			name:  "not yet installed",
+			oldCR: getCSM(csmv1.PowerStore, "v2.15.0"),
+			newCR: getCSM(csmv1.PowerStore, "v2.17.0"),
+		},
+		{
+			name:    "invalid upgrade path",
+			oldCR:   installed(getCSM(csmv1.PowerStore, "v2.13.0"), "v2.13.0"),
+			newCR:   installed(getCSM(csmv1.PowerStore, "v2.17.0"), "v2.13.0"),
+			wantErr: "upgrade/downgrade of powerstore from version v2.13.0 to v2.17.0 not valid",
+		},
+		{
+			name:  "multi-hop upgrade",
+			oldCR: installed(getCSM(csmv1.PowerStore, "v2.13.0"), "v2.13.0"),
+			newCR: func() *csmv1.ContainerStorageModule {
+				cr := installed(getCSM(csmv1.PowerStore, "v2.17.0"), "v2.13.0")
+				cr.Spec.MultiHopUpgrade = true
+				return cr
+			}(),
+		},
+		{
+			name:  "multi-hop upgrade without a path",
+			oldCR: installed(getCSM(csmv1.PowerStore, "v2.10.0"), "v2.10.0"),
+			newCR: func() *csmv1.ContainerStorageModule {
+				cr := installed(getCSM(csmv1.PowerStore, "v2.17.0"), "v2.10.0")
+				cr.Spec.MultiHopUpgrade = true
+				return cr
+			}(),
+			wantErr: "upgrade/downgrade of powerstore from version v2.10.0 to v2.17.0 not valid",
+		},
+		{
+			name:  "unchanged spec is not validated",
+			oldCR: installed(getCSM("unknown", "v2.17.0"), "v2.17.0"),
+			newCR: installed(getCSM("unknown", "v2.17.0"), "v2.17.0"),
+		},
+		{
+			name: "authorization v1 to v2 switch",
+			oldCR: func() *csmv1.ContainerStorageModule {
+				cr := installed(getCSM("", ""), "v1.10.0")
+				cr.Spec.Modules = []csmv1.Module{{Name: csmv1.AuthorizationServer, Enabled: true, ConfigVersion: "v1.10.0"}}
+				return cr
+			}(),
+			newCR: func() *csmv1.ContainerStorageModule {
+				cr := installed(getCSM("", ""), "v1.10.0")
+				cr.Spec.Modules = []csmv1.Module{{Name: csmv1.AuthorizationServer, Enabled: true, ConfigVersion: "v2.5.0"}}
+				return cr
+			}(),
+			wantErr: "cannot switch between Authorization v1 and v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateUpdate(context.Background(), tt.oldCR, tt.newCR)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDelete(t *testing.T) {
+	_, err := validator.ValidateDelete(context.Background(), getCSM("unknown", ""))
+	assert.NoError(t, err)
+}
+
+func TestDefault(t *testing.T) {
+	defaulter := &CSMDefaulter{Config: validator.Config}
+
+	t.Run("driver defaults", func(t *testing.T) {
+		cr := getCSM(csmv1.PowerScale, "")
+		cr.Spec.Version = "v1.17.0"
+		cr.Spec.Modules = []csmv1.Module{{Name: csmv1.Observability, Enabled: true}}
+
+		err := defaulter.Default(context.Background(), cr)
+		assert.NoError(t, err)
+		assert.True(t, *cr.Spec.Driver.ForceRemoveDriver)
+		assert.Equal(t, int32(2), cr.Spec.Driver.Replicas)
+		assert.Equal(t, "ClusterFirstWithHostNet", cr.Spec.Driver.DNSPolicy)
+		assert.Equal(t, "v1.17.0", cr.Annotations[operatorutils.ResolvedCSMVersionAnnotation])
+		assert.Equal(t, "v2.17.0", cr.Annotations[operatorutils.ResolvedConfigVersionAnnotation])
+		assert.True(t, operatorutils.HasModuleComponent(*cr, csmv1.Observability, "metrics-powerscale"))
+	})
+
+	t.Run("user values are kept", func(t *testing.T) {
+		forceRemove := false
+		cr := getCSM(csmv1.PowerStore, "v2.17.0")
+		cr.Spec.Driver.ForceRemoveDriver = &forceRemove
+		cr.Spec.Driver.Replicas = 1
+		cr.Spec.Driver.DNSPolicy = "ClusterFirst"
+
+		err := defaulter.Default(context.Background(), cr)
+		assert.NoError(t, err)
+		assert.False(t, *cr.Spec.Driver.ForceRemoveDriver)
+		assert.Equal(t, int32(1), cr.Spec.Driver.Replicas)
+		assert.Equal(t, "ClusterFirst", cr.Spec.Driver.DNSPolicy)
+		assert.Empty(t, cr.Annotations[operatorutils.ResolvedConfigVersionAnnotation])
+	})
+
+	t.Run("pinned version is kept until spec.version changes", func(t *testing.T) {
+		cr := getCSM(csmv1.PowerStore, "")
+		cr.Spec.Version = "v1.17.0"
+		cr.Annotations = map[string]string{
+			operatorutils.ResolvedCSMVersionAnnotation:    "v1.17.0",
+			operatorutils.ResolvedConfigVersionAnnotation: "v2.16.0",
+		}
+
+		err := defaulter.Default(context.Background(), cr)
+		assert.NoError(t, err)
+		assert.Equal(t, "v2.16.0", cr.Annotations[operatorutils.ResolvedConfigVersionAnnotation])
+
+		cr.Spec.Version = "v1.15.0"
+		err = defaulter.Default(context.Background(), cr)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.15.0", cr.Annotations[operatorutils.ResolvedCSMVersionAnnotation])
+		assert.Equal(t, "v2.15.0", cr.Annotations[operatorutils.ResolvedConfigVersionAnnotation])
+	})
+
+	t.Run("unsupported pinned version is resolved again", func(t *testing.T) {
+		cr := getCSM(csmv1.PowerStore, "")
+		cr.Spec.Version = "v1.17.0"
+		cr.Annotations = map[string]string{
+			operatorutils.ResolvedCSMVersionAnnotation:    "v1.17.0",
+			operatorutils.ResolvedConfigVersionAnnotation: "v9.9.9",
+		}
+
+		err := defaulter.Default(context.Background(), cr)
+		assert.NoError(t, err)
+		assert.Equal(t, "v2.17.0", cr.Annotations[operatorutils.ResolvedConfigVersionAnnotation])
+	})
+
+	t.Run("authorization proxy server config version", func(t *testing.T) {
+		cr := getCSM("", "")
+		cr.Spec.Version = "v1.17.0"
+		cr.Spec.Modules = []csmv1.Module{{Name: csmv1.AuthorizationServer, Enabled: true}}
+
+		err := defaulter.Default(context.Background(), cr)
+		assert.NoError(t, err)
+		assert.Equal(t, "v2.5.0", cr.Spec.Modules[0].ConfigVersion)
+		assert.Equal(t, int32(0), cr.Spec.Driver.Replicas)
+		assert.Empty(t, cr.Spec.Driver.DNSPolicy)
+	})
+
+	t.Run("unknown spec.version is left to validation", func(t *testing.T) {
+		cr := getCSM(csmv1.PowerStore, "")
+		cr.Spec.Version = "v0.0.1"
+
+		err := defaulter.Default(context.Background(), cr)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(0), cr.Spec.Driver.Replicas)
+		assert.Empty(t, cr.Annotations[operatorutils.ResolvedConfigVersionAnnotation])
+	})
+}
@@ -128,7 +128,8 @@ function install_or_update_crd() {
   else
     log step "Install/Update CRD"
   fi
-  kubectl apply -f ${DEPLOYDIR}/crds/storage.dell.com.crds.all.yaml 2>&1 >/dev/null
+  # server-side, the CRD is too large for the last-applied-configuration annotation of a client-side apply
+  kubectl apply --server-side --force-conflicts -f ${DEPLOYDIR}/crds/storage.dell.com.crds.all.yaml 2>&1 >/dev/null
 
   if [ $? -ne 0 ]; then
     log error "Failed to install/update CRD"
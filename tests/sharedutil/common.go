@@ -130,14 +130,14 @@ func MakeDriver(configVersion, skipCertValid string) csmv1.Driver {
 				},
 			},
 		},
-		Node: &csmv1.ContainerTemplate{
+		Node: &csmv1.PodTemplate{ContainerTemplate: csmv1.ContainerTemplate{
 			Envs: []corev1.EnvVar{
 				{
 					Name:  "X_CSI_SDC_SFTP_REPO_ENABLED",
 					Value: "false",
 				},
 			},
-		},
+		}},
 	}
 
 	return driverObj
@@ -148,7 +148,7 @@ func MakeModule(configVersion string) csmv1.Module {
 	moduleObj := csmv1.Module{
 		ConfigVersion:     configVersion,
 		ForceRemoveModule: true,
-		Components:        []csmv1.ContainerTemplate{{}},
+		Components:        []csmv1.PodTemplate{{}},
 	}
 
 	return moduleObj
@@ -322,8 +322,8 @@ func MakeReverseProxyModule(_ string) csmv1.Module {
 		Name:          csmv1.ReverseProxy,
 		Enabled:       true,
 		ConfigVersion: "v2.6.0",
-		Components: []csmv1.ContainerTemplate{
-			{
+		Components: []csmv1.PodTemplate{
+			{ContainerTemplate: csmv1.ContainerTemplate{
 				Name:  string(csmv1.ReverseProxyServer),
 				Image: "dell/proxy:v2.6.0",
 				Envs: []corev1.EnvVar{
@@ -340,7 +340,7 @@ func MakeReverseProxyModule(_ string) csmv1.Module {
 						Value: "powermax-reverseproxy-config",
 					},
 				},
-			},
+			}},
 		},
 		ForceRemoveModule: false,
 	}
@@ -17,14 +17,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	shared "github.com/dell/csm-operator/tests/sharedutil"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -125,6 +129,34 @@ func (f Client) List(ctx context.Context, list client.ObjectList, opts ...client
 		return f.listNodeList(l, labelKey)
 	case *appsv1.DeploymentList:
 		return f.listDeploymentList(ctx, &appsv1.DeploymentList{})
+	case *storagev1.StorageClassList:
+		return f.listStorageClassList(l)
+	case *corev1.PersistentVolumeClaimList:
+		return f.listPersistentVolumeClaimList(l)
+	case *appsv1.ControllerRevisionList:
+		listOpts := &client.ListOptions{}
+		for _, opt := range opts {
+			if opt != nil {
+				opt.ApplyToList(listOpts)
+			}
+		}
+		return f.listControllerRevisionList(l, listOpts.Namespace, listOpts.LabelSelector)
+	case *corev1.SecretList:
+		listOpts := &client.ListOptions{}
+		for _, opt := range opts {
+			if opt != nil {
+				opt.ApplyToList(listOpts)
+			}
+		}
+		return f.listSecretList(l, listOpts.Namespace, listOpts.LabelSelector)
+	case *unstructured.UnstructuredList:
+		listOpts := &client.ListOptions{}
+		for _, opt := range opts {
+			if opt != nil {
+				opt.ApplyToList(listOpts)
+			}
+		}
+		return f.listUnstructuredList(l, listOpts.LabelSelector)
 	default:
 		return fmt.Errorf("fake client unknown type: %s", reflect.TypeOf(list))
 	}
@@ -168,6 +200,67 @@ func (f Client) listDeploymentList(_ context.Context, list *appsv1.DeploymentLis
 	return nil
 }
 
+func (f Client) listStorageClassList(list *storagev1.StorageClassList) error {
+	for k, v := range f.Objects {
+		if k.Kind == "StorageClass" {
+			list.Items = append(list.Items, *v.(*storagev1.StorageClass))
+		}
+	}
+	return nil
+}
+
+func (f Client) listPersistentVolumeClaimList(list *corev1.PersistentVolumeClaimList) error {
+	for k, v := range f.Objects {
+		if k.Kind == "PersistentVolumeClaim" {
+			list.Items = append(list.Items, *v.(*corev1.PersistentVolumeClaim))
+		}
+	}
+	return nil
+}
+
+func (f Client) listControllerRevisionList(list *appsv1.ControllerRevisionList, namespace string, selector labels.Selector) error {
+	for k, v := range f.Objects {
+		if k.Kind != "ControllerRevision" || (namespace != "" && k.Namespace != namespace) {
+			continue
+		}
+		if r, ok := v.(*appsv1.ControllerRevision); ok {
+			if selector == nil || selector.Matches(labels.Set(r.GetLabels())) {
+				list.Items = append(list.Items, *r)
+			}
+		}
+	}
+	return nil
+}
+
+func (f Client) listSecretList(list *corev1.SecretList, namespace string, selector labels.Selector) error {
+	for k, v := range f.Objects {
+		if k.Kind != "Secret" || (namespace != "" && k.Namespace != namespace) {
+			continue
+		}
+		if s, ok := v.(*corev1.Secret); ok {
+			if selector == nil || selector.Matches(labels.Set(s.GetLabels())) {
+				list.Items = append(list.Items, *s)
+			}
+		}
+	}
+	return nil
+}
+
+func (f Client) listUnstructuredList(list *unstructured.UnstructuredList, selector labels.Selector) error {
+	kind := strings.TrimSuffix(list.GetKind(), "List")
+	for k, v := range f.Objects {
+		if k.Kind != kind {
+			continue
+		}
+		if u, ok := v.(*unstructured.Unstructured); ok {
+			if selector == nil || selector.Matches(labels.Set(u.GetLabels())) {
+				list.Items = append(list.Items, *u)
+			}
+		}
+	}
+	return nil
+}
+
 // Create implements client.Client.
 func (f Client) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
 	if f.ErrorInjector != nil {